@@ -1,6 +1,6 @@
 module github.com/visionex-project/visionex
 
-go 1.21
+go 1.22.2
 
 require (
 	cloud.google.com/go/documentai v1.25.0
@@ -9,6 +9,8 @@ require (
 	cloud.google.com/go/vision v1.2.0
 	cloud.google.com/go/vision/v2 v2.8.0
 	firebase.google.com/go v3.13.0+incompatible
+	github.com/HugoSmits86/nativewebp v0.9.3
+	github.com/abadojack/whatlanggo v1.0.1
 	github.com/cenkalti/backoff/v4 v4.2.1
 	github.com/fogleman/gg v1.3.0
 	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0
@@ -2,10 +2,28 @@ package auth
 
 import (
 	"context"
+	"strings"
 )
 
 type Auth interface {
 	Verify(ctx context.Context, token string) (string, error)
+	// Verifies the token like Verify, and returns the email it belongs to.
+	VerifiedEmail(ctx context.Context, token string) (string, error)
+	// Verifies the token like Verify, and that it belongs to an admin.
+	VerifyAdmin(ctx context.Context, token string) error
+}
+
+type emailKey struct{}
+
+// Returns a copy of the context that carries the verified email of the caller.
+func WithEmail(ctx context.Context, email string) context.Context {
+	return context.WithValue(ctx, emailKey{}, strings.ToLower(email))
+}
+
+// Returns the verified email of the caller in lowercase, or an empty string when the context carries none.
+func EmailFromContext(ctx context.Context) string {
+	email, _ := ctx.Value(emailKey{}).(string)
+	return email
 }
 
 var validEmailDomains = []string{
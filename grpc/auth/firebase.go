@@ -18,15 +18,37 @@ type FirebaseAuthClient interface {
 
 type Authenticator struct {
 	client FirebaseAuthClient
+	// Users allowed to call the admin RPCs, such as UploadFont. E.g., ["admin@yanolja.com"]
+	adminEmails []string
 }
 
-func New(client FirebaseAuthClient) *Authenticator {
+func New(client FirebaseAuthClient, adminEmails []string) *Authenticator {
 	return &Authenticator{
-		client: client,
+		client:      client,
+		adminEmails: adminEmails,
 	}
 }
 
 func (a *Authenticator) Verify(ctx context.Context, token string) (string, error) {
+	if _, err := a.VerifiedEmail(ctx, token); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (a *Authenticator) VerifyAdmin(ctx context.Context, token string) error {
+	email, err := a.VerifiedEmail(ctx, token)
+	if err != nil {
+		return err
+	}
+	if !utils.Contains(a.adminEmails, strings.ToLower(email)) {
+		return fmt.Errorf("failed to verify the token: %s is not an admin", email)
+	}
+	return nil
+}
+
+// Returns the email of the token after checking that it belongs to one of the valid domains.
+func (a *Authenticator) VerifiedEmail(ctx context.Context, token string) (string, error) {
 	decodedToken, err := a.client.VerifyIDToken(ctx, token)
 	if err != nil {
 		return "", status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
@@ -54,5 +76,5 @@ func (a *Authenticator) Verify(ctx context.Context, token string) (string, error
 		return "", fmt.Errorf("failed to verify the token: invalid email domain")
 	}
 
-	return token, nil
+	return email, nil
 }
@@ -0,0 +1,51 @@
+#!/bin/sh
+# Downloads the fonts that are too large to ship with the repository to the paths listed in fonts.json.
+# Fonts that are already installed are skipped.
+# Usage: grpc/cmd/fonts/download.sh [font directory]
+set -eu
+
+FONT_DIR="${1:-$(dirname "$0")}"
+NOTO_CJK="https://github.com/notofonts/noto-cjk/raw/main"
+NOTO="https://github.com/notofonts/notofonts.github.io/raw/main/fonts"
+
+download() {
+	path="$FONT_DIR/$1"
+	if [ -f "$path" ]; then
+		return
+	fi
+	echo "Downloading $1"
+	mkdir -p "$(dirname "$path")"
+	curl -fsSL --retry 3 -o "$path.tmp" "$2"
+	mv "$path.tmp" "$path"
+}
+
+# Noto Sans CJK has no SemiBold, so Medium is used instead.
+download ChineseSimplified/SansSerif-Regular.otf "$NOTO_CJK/Sans/SubsetOTF/SC/NotoSansSC-Regular.otf"
+download ChineseSimplified/SansSerif-SemiBold.otf "$NOTO_CJK/Sans/SubsetOTF/SC/NotoSansSC-Medium.otf"
+download ChineseSimplified/SansSerif-Bold.otf "$NOTO_CJK/Sans/SubsetOTF/SC/NotoSansSC-Bold.otf"
+download ChineseTraditional/SansSerif-Regular.otf "$NOTO_CJK/Sans/SubsetOTF/TC/NotoSansTC-Regular.otf"
+download ChineseTraditional/SansSerif-SemiBold.otf "$NOTO_CJK/Sans/SubsetOTF/TC/NotoSansTC-Medium.otf"
+download ChineseTraditional/SansSerif-Bold.otf "$NOTO_CJK/Sans/SubsetOTF/TC/NotoSansTC-Bold.otf"
+download Thai/SansSerif-Regular.ttf "$NOTO/NotoSansThai/hinted/ttf/NotoSansThai-Regular.ttf"
+download Thai/SansSerif-SemiBold.ttf "$NOTO/NotoSansThai/hinted/ttf/NotoSansThai-SemiBold.ttf"
+download Thai/SansSerif-Bold.ttf "$NOTO/NotoSansThai/hinted/ttf/NotoSansThai-Bold.ttf"
+
+# The serif faces, which the texts detected as serif are drawn in.
+download English/Serif-Regular.ttf "$NOTO/NotoSerif/hinted/ttf/NotoSerif-Regular.ttf"
+download English/Serif-SemiBold.ttf "$NOTO/NotoSerif/hinted/ttf/NotoSerif-SemiBold.ttf"
+download English/Serif-Bold.ttf "$NOTO/NotoSerif/hinted/ttf/NotoSerif-Bold.ttf"
+download Korean/Serif-Regular.otf "$NOTO_CJK/Serif/SubsetOTF/KR/NotoSerifKR-Regular.otf"
+download Korean/Serif-SemiBold.otf "$NOTO_CJK/Serif/SubsetOTF/KR/NotoSerifKR-SemiBold.otf"
+download Korean/Serif-Bold.otf "$NOTO_CJK/Serif/SubsetOTF/KR/NotoSerifKR-Bold.otf"
+download Japanese/Serif-Regular.otf "$NOTO_CJK/Serif/SubsetOTF/JP/NotoSerifJP-Regular.otf"
+download Japanese/Serif-SemiBold.otf "$NOTO_CJK/Serif/SubsetOTF/JP/NotoSerifJP-SemiBold.otf"
+download Japanese/Serif-Bold.otf "$NOTO_CJK/Serif/SubsetOTF/JP/NotoSerifJP-Bold.otf"
+download ChineseSimplified/Serif-Regular.otf "$NOTO_CJK/Serif/SubsetOTF/SC/NotoSerifSC-Regular.otf"
+download ChineseSimplified/Serif-SemiBold.otf "$NOTO_CJK/Serif/SubsetOTF/SC/NotoSerifSC-SemiBold.otf"
+download ChineseSimplified/Serif-Bold.otf "$NOTO_CJK/Serif/SubsetOTF/SC/NotoSerifSC-Bold.otf"
+download ChineseTraditional/Serif-Regular.otf "$NOTO_CJK/Serif/SubsetOTF/TC/NotoSerifTC-Regular.otf"
+download ChineseTraditional/Serif-SemiBold.otf "$NOTO_CJK/Serif/SubsetOTF/TC/NotoSerifTC-SemiBold.otf"
+download ChineseTraditional/Serif-Bold.otf "$NOTO_CJK/Serif/SubsetOTF/TC/NotoSerifTC-Bold.otf"
+download Thai/Serif-Regular.ttf "$NOTO/NotoSerifThai/hinted/ttf/NotoSerifThai-Regular.ttf"
+download Thai/Serif-SemiBold.ttf "$NOTO/NotoSerifThai/hinted/ttf/NotoSerifThai-SemiBold.ttf"
+download Thai/Serif-Bold.ttf "$NOTO/NotoSerifThai/hinted/ttf/NotoSerifThai-Bold.ttf"
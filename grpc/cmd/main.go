@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	documentai "cloud.google.com/go/documentai/apiv1"
@@ -28,9 +29,11 @@ import (
 
 	pb "github.com/visionex-project/visionex/grpc"
 	visionexAuth "github.com/visionex-project/visionex/grpc/auth"
+	"github.com/visionex-project/visionex/grpc/gateway"
 	"github.com/visionex-project/visionex/grpc/impl"
 	"github.com/visionex-project/visionex/grpc/impl/font"
 	yaGenai "github.com/visionex-project/visionex/grpc/impl/genai"
+	"github.com/visionex-project/visionex/grpc/impl/job"
 	"github.com/visionex-project/visionex/grpc/impl/lama"
 	implOpenai "github.com/visionex-project/visionex/grpc/impl/openai"
 	"github.com/visionex-project/visionex/grpc/impl/storage"
@@ -38,15 +41,15 @@ import (
 	"github.com/visionex-project/visionex/pkg/env"
 	yaHttp "github.com/visionex-project/visionex/pkg/http"
 	yaOpenai "github.com/visionex-project/visionex/pkg/openai"
+	"github.com/visionex-project/visionex/pkg/utils"
 )
 
 func main() {
 	// Remove Bazel-specific working directory setup
 	env.Load()
 
-	// NOTE: The implementation of the New function contains hardcoded paths to specific font files.
-	// If fonts are added/removed/renamed, the implementation in font.go must be updated
-	// to maintain consistency with the actual font files in the cmd/fonts directory.
+	// The font files are listed in grpc/cmd/fonts/fonts.json.
+	// If fonts are added/removed/renamed, the manifest must be updated accordingly.
 	fontProvider := must.OK1(font.New("grpc/cmd/fonts"))
 
 	examples := impl.Examples{
@@ -93,12 +96,16 @@ func main() {
 	genaiClient := yaGenai.New(must.OK1(genai.NewClient(ctx, option.WithAPIKey(geminiKey))))
 	storageClient := storage.New(must.OK1(gcs.NewClient(ctx)))
 
-	// Initialize Lama client (optional)
-	var lamaClient lama.LamaClient
+	// Texts are removed in-process unless a LaMa service is configured.
+	lamaClient := lama.NewLocal()
 	if lamaURL := os.Getenv("LAMA_URL"); lamaURL != "" {
-		// For now, use mock implementation
-		// TODO: Replace with actual LaMa service when available
-		lamaClient = lama.New(lamaURL, "", "")
+		lamaClient = lama.New(lamaURL, lama.Options{
+			Timeout:      time.Duration(env.IntVariable("LAMA_TIMEOUT_SECONDS", 0)) * time.Second,
+			MaxRetries:   env.IntVariable("LAMA_MAX_RETRIES", 2),
+			AuthHeader:   os.Getenv("LAMA_AUTH_HEADER"),
+			AuthToken:    os.Getenv("LAMA_AUTH_TOKEN"),
+			MaxImageSize: env.IntVariable("LAMA_MAX_IMAGE_SIZE", 0),
+		})
 	}
 
 	visionClient := must.OK1(vision.NewImageAnnotatorClient(ctx))
@@ -114,41 +121,56 @@ func main() {
 		log.Fatalf("error getting Auth client: %v", err)
 	}
 
-	authClient := visionexAuth.New(firebaseClient)
+	// Emails of the users allowed to call the admin RPCs, separated by commas. E.g., "admin@yanolja.com,ops@yanolja.com"
+	adminEmails := utils.Map(strings.Split(strings.ToLower(env.StringVariable("ADMIN_EMAILS", "")), ","), strings.TrimSpace)
+	authClient := visionexAuth.New(firebaseClient, utils.Filter(adminEmails, func(email string) bool {
+		return email != ""
+	}))
 
+	unaryInterceptor := apiKeyInterceptor(ctx, authClient)
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(apiKeyInterceptor(ctx, authClient)),
+		grpc.UnaryInterceptor(unaryInterceptor),
+		grpc.StreamInterceptor(streamApiKeyInterceptor(authClient)),
 		grpc.MaxRecvMsgSize(20*1024*1024),
 	)
 
 	// Create OpenAI client for replacing Fragma
 	openaiImplClient := implOpenai.New(openai.NewClient(openaiKey))
 
+	jobStore := must.OK1(job.NewFileStore(env.StringVariable("JOB_STORE_DIR", "jobs")))
+
 	// Image size limit for Vision & OpenAI API is 20MB.
 	// Ref: https://cloud.google.com/vision/quotas#limits
 	// Ref: https://platform.openai.com/docs/guides/vision/is-there-a-limit-to-the-size-of-the-image-i-can-upload
-	pb.RegisterVisionExServer(grpcServer,
-		impl.New(
-			authClient,
-			visionClient,
-			openaiClient,
-			documentaiClient,
-			genaiClient,
-			documentaiSpec,
-			examples,
-			lamaClient,
-			openaiImplClient,
-			impl.Storage{
-				Client:           storageClient,
-				ToImageBucket:    env.RequiredStringVariable("GCP_TO_IMAGE_STORAGE"),
-				ToMarkdownBucket: env.RequiredStringVariable("GCP_TO_MARKDOWN_STORAGE"),
-			},
-			fontProvider,
-			time.Second/2, /* =backoffDuration */
-		))
+	server := impl.New(
+		authClient,
+		visionClient,
+		openaiClient,
+		documentaiClient,
+		genaiClient,
+		documentaiSpec,
+		examples,
+		lamaClient,
+		openaiImplClient,
+		impl.Storage{
+			Client:           storageClient,
+			ToImageBucket:    env.RequiredStringVariable("GCP_TO_IMAGE_STORAGE"),
+			ToMarkdownBucket: env.RequiredStringVariable("GCP_TO_MARKDOWN_STORAGE"),
+			FontBucket:       env.StringVariable("GCP_FONT_STORAGE", ""),
+		},
+		fontProvider,
+		time.Second/2, /* =backoffDuration */
+		jobStore,
+	)
+	// Finished jobs are deleted with their images after this many hours. 0 keeps them forever.
+	must.OK(server.StartJobWorkers(ctx, time.Duration(env.IntVariable("JOB_RETENTION_HOURS", 168))*time.Hour))
+	must.OK(server.LoadUploadedFonts(ctx))
+	pb.RegisterVisionExServer(grpcServer, server)
 
 	go runGrpcServer(grpcServer, env.RequiredIntVariable("GRPC_PORT"))
-	runGrpcWebServer(grpcServer, env.RequiredIntVariable("WEB_PORT"), env.RequiredStringVariable("VISIONEX_UI_URL"))
+	// The REST gateway calls the server directly, so the interceptor is passed explicitly.
+	gatewayHandler := gateway.New(server, &pb.VisionEx_ServiceDesc, unaryInterceptor)
+	runGrpcWebServer(grpcServer, gatewayHandler, env.RequiredIntVariable("WEB_PORT"), env.RequiredStringVariable("VISIONEX_UI_URL"))
 }
 
 func runGrpcServer(grpcServer *grpc.Server, port int) {
@@ -156,7 +178,7 @@ func runGrpcServer(grpcServer *grpc.Server, port int) {
 	must.OK(grpcServer.Serve(must.OK1(net.Listen("tcp", fmt.Sprintf(":%d", port)))))
 }
 
-func runGrpcWebServer(grpcServer *grpc.Server, port int, url string) {
+func runGrpcWebServer(grpcServer *grpc.Server, gatewayHandler http.Handler, port int, url string) {
 	grpcwebServer := grpcweb.WrapServer(grpcServer,
 		grpcweb.WithOriginFunc(func(origin string) bool {
 			return origin == url
@@ -175,6 +197,7 @@ func runGrpcWebServer(grpcServer *grpc.Server, port int, url string) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", defaultHandler)
 	mux.HandleFunc("/assets/", yaHttp.HandleFileServer(http.FileServer(http.Dir(staticFileDir))))
+	mux.Handle(gateway.PATH_PREFIX, gatewayHandler)
 	log.Printf("VisionEx gRPC-web server listening on port %d", port)
 	must.OK(http.ListenAndServe(fmt.Sprintf(":%d", port), mux))
 }
@@ -192,28 +215,68 @@ func readExampleFromFile(path string) (string, error) {
 	return string(example), nil
 }
 
+// RPCs that change the server for every user, so only admins may call them.
+var adminMethods = []string{
+	pb.VisionEx_UploadFont_FullMethodName,
+	pb.VisionEx_DeleteFont_FullMethodName,
+}
+
 func apiKeyInterceptor(ctx context.Context, authClient visionexAuth.Auth) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		metadatas, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Errorf(codes.Unauthenticated, "missing context metadata")
-		}
-		key := metadatas.Get("Authorization")
-		if len(key) != 1 {
-			return nil, status.Errorf(codes.Unauthenticated, "missing authorization token")
-		}
-		token, extractTokenErr := auth.ExtractBearerToken(key[0])
-		if extractTokenErr != nil {
-			return nil, status.Errorf(codes.Unauthenticated, extractTokenErr.Error())
-		}
-		_, err := authClient.Verify(ctx, token)
+		email, err := verifyAuthorization(ctx, authClient)
 		if err != nil {
-			return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+			return nil, err
+		}
+		// Jobs are owned by the email of the caller who submitted them.
+		ctx = visionexAuth.WithEmail(ctx, email)
+		if utils.Contains(adminMethods, info.FullMethod) {
+			if err := verifyAdminAuthorization(ctx, authClient); err != nil {
+				return nil, err
+			}
 		}
 		return handler(ctx, request)
 	}
 }
 
+func streamApiKeyInterceptor(authClient visionexAuth.Auth) grpc.StreamServerInterceptor {
+	return func(server any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if _, err := verifyAuthorization(stream.Context(), authClient); err != nil {
+			return err
+		}
+		return handler(server, stream)
+	}
+}
+
+func verifyAuthorization(ctx context.Context, authClient visionexAuth.Auth) (string, error) {
+	metadatas, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Errorf(codes.Unauthenticated, "missing context metadata")
+	}
+	key := metadatas.Get("Authorization")
+	if len(key) != 1 {
+		return "", status.Errorf(codes.Unauthenticated, "missing authorization token")
+	}
+	token, extractTokenErr := auth.ExtractBearerToken(key[0])
+	if extractTokenErr != nil {
+		return "", status.Errorf(codes.Unauthenticated, extractTokenErr.Error())
+	}
+	email, err := authClient.VerifiedEmail(ctx, token)
+	if err != nil {
+		return "", status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+	return email, nil
+}
+
+// Must be called after verifyAuthorization, which checks the format of the metadata.
+func verifyAdminAuthorization(ctx context.Context, authClient visionexAuth.Auth) error {
+	metadatas, _ := metadata.FromIncomingContext(ctx)
+	token, _ := auth.ExtractBearerToken(metadatas.Get("Authorization")[0])
+	if err := authClient.VerifyAdmin(ctx, token); err != nil {
+		return status.Errorf(codes.PermissionDenied, "admin permission is required: %v", err)
+	}
+	return nil
+}
+
 func secretFromGCP(secretmanagerClient *secretmanager.Client, ctx context.Context, secretName string) string {
 	secretValue := must.OK1(secretmanagerClient.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
 		Name: fmt.Sprintf("projects/%s/secrets/%s/versions/latest",
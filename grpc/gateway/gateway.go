@@ -0,0 +1,253 @@
+package gateway
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// E.g., "/api/v1/TranslateToImage"
+	PATH_PREFIX = "/api/v1/"
+	// The gRPC server accepts messages up to 20MB, and base64 encoding in JSON adds a third on top.
+	MAX_REQUEST_BYTES = 28 * 1024 * 1024
+	// Files up to this size are kept in memory while parsing multipart forms, larger ones go to temporary files.
+	MAX_MULTIPART_MEMORY_BYTES = 8 * 1024 * 1024
+	// The form field that holds the whole request as JSON in multipart forms.
+	// Other form fields are set on top of it.
+	REQUEST_FORM_FIELD = "request"
+)
+
+type gateway struct {
+	server      any
+	methods     map[string]grpc.MethodDesc
+	interceptor grpc.UnaryServerInterceptor
+}
+
+// Serves every unary RPC of the service as "POST /api/v1/<MethodName>".
+//
+// The request body is either the request message as JSON, or a multipart form whose fields are named after
+// the request fields. File parts are set on bytes fields, and message fields take JSON values. E.g.,
+//
+//	curl -H "Authorization: Bearer $TOKEN" \
+//	  -F image=@menu.png -F target_language=LANGUAGE_EN_US -F 'output_encoding={"format": "OUTPUT_FORMAT_JPEG"}' \
+//	  https://visionex.example.com/api/v1/TranslateToImage
+//
+// The response is the response message as JSON with the original proto field names.
+// Every call goes through the interceptor, so the same authorization applies as for gRPC.
+// Streaming RPCs are not served.
+func New(server any, serviceDesc *grpc.ServiceDesc, interceptor grpc.UnaryServerInterceptor) http.Handler {
+	methods := map[string]grpc.MethodDesc{}
+	for _, method := range serviceDesc.Methods {
+		methods[method.MethodName] = method
+	}
+	return &gateway{server: server, methods: methods, interceptor: interceptor}
+}
+
+func (g *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeErrorWithHttpStatus(w, status.Error(codes.Unimplemented, "only POST is allowed"), http.StatusMethodNotAllowed)
+		return
+	}
+	method, ok := g.methods[strings.TrimPrefix(r.URL.Path, PATH_PREFIX)]
+	if !ok {
+		writeError(w, status.Errorf(codes.NotFound, "unknown method %s", r.URL.Path))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, MAX_REQUEST_BYTES)
+	ctx := metadata.NewIncomingContext(r.Context(), metadata.Pairs("authorization", r.Header.Get("Authorization")))
+	var decodeErr error
+	response, err := method.Handler(g.server, ctx, func(request any) error {
+		decodeErr = decodeRequest(r, request.(proto.Message))
+		if decodeErr != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid request: %v", decodeErr)
+		}
+		return nil
+	}, g.interceptor)
+	// Like gRPC servers for too large messages, the code is ResourceExhausted, but the HTTP status is 413 rather than 429.
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(decodeErr, &maxBytesErr) {
+		writeErrorWithHttpStatus(w, status.Errorf(codes.ResourceExhausted, "request body is larger than %d bytes", maxBytesErr.Limit), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	content, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(response.(proto.Message))
+	if err != nil {
+		log.Printf("Failed to marshal response: %v", err)
+		writeError(w, status.Error(codes.Internal, codes.Internal.String()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(content)
+}
+
+func decodeRequest(r *http.Request, request proto.Message) error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return fmt.Errorf("invalid content type: %w", err)
+	}
+	switch mediaType {
+	case "application/json":
+		content, err := io.ReadAll(r.Body)
+		if err != nil {
+			return err
+		}
+		if len(content) == 0 {
+			return nil
+		}
+		return protojson.Unmarshal(content, request)
+	case "multipart/form-data":
+		return decodeMultipartRequest(r, request)
+	default:
+		return fmt.Errorf("unsupported content type %s", mediaType)
+	}
+}
+
+// Converts the form into the JSON of the request, so that protojson does the parsing of every field type.
+func decodeMultipartRequest(r *http.Request, request proto.Message) error {
+	if err := r.ParseMultipartForm(MAX_MULTIPART_MEMORY_BYTES); err != nil {
+		return err
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	if values := r.MultipartForm.Value[REQUEST_FORM_FIELD]; len(values) > 0 {
+		if err := protojson.Unmarshal([]byte(values[0]), request); err != nil {
+			return err
+		}
+	}
+
+	fields := request.ProtoReflect().Descriptor().Fields()
+	formJson := map[string]any{}
+	for name, values := range r.MultipartForm.Value {
+		if name == REQUEST_FORM_FIELD {
+			continue
+		}
+		field := fields.ByName(protoreflect.Name(name))
+		if field == nil {
+			return fmt.Errorf("unknown field %s", name)
+		}
+		jsonValues := []any{}
+		for _, value := range values {
+			switch field.Kind() {
+			case protoreflect.MessageKind, protoreflect.BoolKind:
+				jsonValues = append(jsonValues, json.RawMessage(value))
+			default:
+				// protojson accepts numbers and enum names as strings.
+				jsonValues = append(jsonValues, value)
+			}
+		}
+		formJson[name] = toJsonField(field, jsonValues)
+	}
+	for name, files := range r.MultipartForm.File {
+		field := fields.ByName(protoreflect.Name(name))
+		if field == nil || field.Kind() != protoreflect.BytesKind {
+			return fmt.Errorf("unknown file field %s", name)
+		}
+		jsonValues := []any{}
+		for _, file := range files {
+			content, err := readFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", name, err)
+			}
+			jsonValues = append(jsonValues, base64.StdEncoding.EncodeToString(content))
+		}
+		formJson[name] = toJsonField(field, jsonValues)
+	}
+
+	content, err := json.Marshal(formJson)
+	if err != nil {
+		return err
+	}
+	formRequest := request.ProtoReflect().New().Interface()
+	if err := protojson.Unmarshal(content, formRequest); err != nil {
+		return err
+	}
+	// Set form fields override the same fields of the "request" form field, and repeated ones are appended.
+	proto.Merge(request, formRequest)
+	return nil
+}
+
+func toJsonField(field protoreflect.FieldDescriptor, values []any) any {
+	if field.IsList() {
+		return values
+	}
+	return values[len(values)-1]
+}
+
+func readFile(fileHeader *multipart.FileHeader) ([]byte, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	writeErrorWithHttpStatus(w, err, toHttpStatus(status.Code(err)))
+}
+
+func writeErrorWithHttpStatus(w http.ResponseWriter, err error, httpStatus int) {
+	grpcStatus := status.Convert(err)
+	content, marshalErr := json.Marshal(struct {
+		Code    int32  `json:"code"`
+		Message string `json:"message"`
+	}{int32(grpcStatus.Code()), grpcStatus.Message()})
+	if marshalErr != nil {
+		log.Printf("Failed to marshal error: %v", marshalErr)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	w.Write(content)
+}
+
+// Ref: https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto
+func toHttpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,208 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/visionex-project/visionex/grpc"
+)
+
+const (
+	// The only token the test interceptor accepts.
+	TEST_TOKEN = "Bearer valid-token"
+)
+
+// Records the request of TranslateToImage and answers with its source language.
+type recordingServer struct {
+	pb.UnimplementedVisionExServer
+	request *pb.TranslateToImageRequest
+}
+
+func (s *recordingServer) TranslateToImage(ctx context.Context, request *pb.TranslateToImageRequest) (*pb.TranslateToImageResponse, error) {
+	s.request = request
+	return &pb.TranslateToImageResponse{MimeType: "image/png", SourceLanguage: request.GetSourceLanguage()}, nil
+}
+
+// Rejects the calls without TEST_TOKEN, like the API key interceptor of the server.
+func testInterceptor(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) == 0 || values[0] != TEST_TOKEN {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return handler(ctx, request)
+}
+
+type formPart struct {
+	name     string
+	fileName string
+	content  string
+}
+
+func multipartBody(t *testing.T, parts []formPart) (*bytes.Buffer, string) {
+	t.Helper()
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	for _, part := range parts {
+		var partWriter io.Writer
+		var err error
+		if part.fileName != "" {
+			partWriter, err = writer.CreateFormFile(part.name, part.fileName)
+		} else {
+			partWriter, err = writer.CreateFormField(part.name)
+		}
+		if err == nil {
+			_, err = partWriter.Write([]byte(part.content))
+		}
+		if err != nil {
+			t.Fatalf("failed to write %s: %v", part.name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close the form: %v", err)
+	}
+	return body, writer.FormDataContentType()
+}
+
+func serve(server *recordingServer, method string, path string, contentType string, body *bytes.Buffer, token string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, body)
+	request.Header.Set("Content-Type", contentType)
+	if token != "" {
+		request.Header.Set("Authorization", token)
+	}
+	recorder := httptest.NewRecorder()
+	New(server, &pb.VisionEx_ServiceDesc, testInterceptor).ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestGatewayJson(t *testing.T) {
+	server := &recordingServer{}
+	body := bytes.NewBufferString(`{"target_language": "LANGUAGE_EN_US", "source_language": "LANGUAGE_KO_KR", "image": "aW1hZ2U="}`)
+	recorder := serve(server, http.MethodPost, PATH_PREFIX+"TranslateToImage", "application/json", body, TEST_TOKEN)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body)
+	}
+	want := &pb.TranslateToImageRequest{
+		TargetLanguage: pb.Language_LANGUAGE_EN_US,
+		SourceLanguage: pb.Language_LANGUAGE_KO_KR,
+		Image:          []byte("image"),
+	}
+	if !proto.Equal(server.request, want) {
+		t.Errorf("got %v, want %v", server.request, want)
+	}
+	// Responses use the proto field names.
+	var response map[string]any
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to parse the response: %v", err)
+	}
+	if response["mime_type"] != "image/png" || response["source_language"] != "LANGUAGE_KO_KR" {
+		t.Errorf("got %v, want the mime_type and source_language fields", response)
+	}
+}
+
+func TestGatewayMultipart(t *testing.T) {
+	server := &recordingServer{}
+	body, contentType := multipartBody(t, []formPart{
+		{name: REQUEST_FORM_FIELD, content: `{"target_language": "LANGUAGE_JA_JP", "font_family": "Acme Sans", "max_slice_height": 1000}`},
+		// Overrides the target language of the "request" form field.
+		{name: "target_language", content: "LANGUAGE_EN_US"},
+		{name: "output_encoding", content: `{"format": "OUTPUT_FORMAT_JPEG", "quality": 80}`},
+		{name: "image", fileName: "menu.png", content: "image"},
+	})
+	recorder := serve(server, http.MethodPost, PATH_PREFIX+"TranslateToImage", contentType, body, TEST_TOKEN)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body)
+	}
+	want := &pb.TranslateToImageRequest{
+		TargetLanguage: pb.Language_LANGUAGE_EN_US,
+		Image:          []byte("image"),
+		OutputEncoding: &pb.OutputEncoding{Format: pb.OutputFormat_OUTPUT_FORMAT_JPEG, Quality: 80},
+		FontFamily:     "Acme Sans",
+		MaxSliceHeight: 1000,
+	}
+	if !proto.Equal(server.request, want) {
+		t.Errorf("got %v, want %v", server.request, want)
+	}
+}
+
+func TestGatewayErrors(t *testing.T) {
+	jsonBody := `{"target_language": "LANGUAGE_EN_US"}`
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		parts       []formPart
+		body        string
+		token       string
+		wantStatus  int
+	}{
+		{name: "missing token", method: http.MethodPost, path: "TranslateToImage", contentType: "application/json", body: jsonBody, wantStatus: http.StatusUnauthorized},
+		{name: "invalid token", method: http.MethodPost, path: "TranslateToImage", contentType: "application/json", body: jsonBody, token: "Bearer invalid-token", wantStatus: http.StatusUnauthorized},
+		{name: "GET", method: http.MethodGet, path: "TranslateToImage", token: TEST_TOKEN, wantStatus: http.StatusMethodNotAllowed},
+		{name: "unknown method", method: http.MethodPost, path: "Unknown", contentType: "application/json", body: jsonBody, token: TEST_TOKEN, wantStatus: http.StatusNotFound},
+		{name: "unknown JSON field", method: http.MethodPost, path: "TranslateToImage", contentType: "application/json", body: `{"unknown": 1}`, token: TEST_TOKEN, wantStatus: http.StatusBadRequest},
+		{name: "unknown form field", method: http.MethodPost, path: "TranslateToImage", parts: []formPart{{name: "unknown", content: "1"}}, token: TEST_TOKEN, wantStatus: http.StatusBadRequest},
+		// Only bytes fields take files.
+		{name: "file on a string field", method: http.MethodPost, path: "TranslateToImage", parts: []formPart{{name: "font_family", fileName: "font.ttf", content: "font"}}, token: TEST_TOKEN, wantStatus: http.StatusBadRequest},
+		{name: "unsupported content type", method: http.MethodPost, path: "TranslateToImage", contentType: "text/plain", body: jsonBody, token: TEST_TOKEN, wantStatus: http.StatusBadRequest},
+		{
+			name:        "oversized JSON",
+			method:      http.MethodPost,
+			path:        "TranslateToImage",
+			contentType: "application/json",
+			body:        `{"font_family": "` + strings.Repeat("a", MAX_REQUEST_BYTES) + `"}`,
+			token:       TEST_TOKEN,
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "oversized file",
+			method:     http.MethodPost,
+			path:       "TranslateToImage",
+			parts:      []formPart{{name: "image", fileName: "menu.png", content: strings.Repeat("a", MAX_REQUEST_BYTES)}},
+			token:      TEST_TOKEN,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &recordingServer{}
+			body, contentType := bytes.NewBufferString(test.body), test.contentType
+			if test.parts != nil {
+				body, contentType = multipartBody(t, test.parts)
+			}
+			recorder := serve(server, test.method, PATH_PREFIX+test.path, contentType, body, test.token)
+
+			if recorder.Code != test.wantStatus {
+				t.Errorf("got %d, want %d: %s", recorder.Code, test.wantStatus, recorder.Body)
+			}
+			if server.request != nil {
+				t.Errorf("got the request %v served, want it rejected", server.request)
+			}
+			var response struct {
+				Code    int32  `json:"code"`
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil || response.Message == "" {
+				t.Errorf("got %q, want the error as JSON", recorder.Body)
+			}
+		})
+	}
+	if recorder := serve(&recordingServer{}, http.MethodGet, PATH_PREFIX+"TranslateToImage", "", new(bytes.Buffer), TEST_TOKEN); recorder.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("got the Allow header %q, want %q", recorder.Header().Get("Allow"), http.MethodPost)
+	}
+}
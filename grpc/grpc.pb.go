@@ -20,6 +20,210 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Stage int32
+
+const (
+	// Unspecified stage.
+	Stage_STAGE_UNSPECIFIED Stage = 0
+	// Text and style detection with Document AI is done.
+	Stage_STAGE_DETECT_DOCUMENT Stage = 1
+	// Lines are grouped into sentences.
+	Stage_STAGE_GROUPED_LINES Stage = 2
+	// One chunk of sentences is translated.
+	Stage_STAGE_TRANSLATE Stage = 3
+	// The original texts are removed from the image.
+	Stage_STAGE_IMAGE_WITHOUT_TEXTS Stage = 4
+	// The translated texts are drawn on the image.
+	Stage_STAGE_DRAW_TEXTS Stage = 5
+	// The translated image is ready.
+	Stage_STAGE_DONE Stage = 6
+)
+
+// Enum value maps for Stage.
+var (
+	Stage_name = map[int32]string{
+		0: "STAGE_UNSPECIFIED",
+		1: "STAGE_DETECT_DOCUMENT",
+		2: "STAGE_GROUPED_LINES",
+		3: "STAGE_TRANSLATE",
+		4: "STAGE_IMAGE_WITHOUT_TEXTS",
+		5: "STAGE_DRAW_TEXTS",
+		6: "STAGE_DONE",
+	}
+	Stage_value = map[string]int32{
+		"STAGE_UNSPECIFIED":         0,
+		"STAGE_DETECT_DOCUMENT":     1,
+		"STAGE_GROUPED_LINES":       2,
+		"STAGE_TRANSLATE":           3,
+		"STAGE_IMAGE_WITHOUT_TEXTS": 4,
+		"STAGE_DRAW_TEXTS":          5,
+		"STAGE_DONE":                6,
+	}
+)
+
+func (x Stage) Enum() *Stage {
+	p := new(Stage)
+	*p = x
+	return p
+}
+
+func (x Stage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Stage) Descriptor() protoreflect.EnumDescriptor {
+	return file_grpc_grpc_proto_enumTypes[0].Descriptor()
+}
+
+func (Stage) Type() protoreflect.EnumType {
+	return &file_grpc_grpc_proto_enumTypes[0]
+}
+
+func (x Stage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Stage.Descriptor instead.
+func (Stage) EnumDescriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{0}
+}
+
+type JobState int32
+
+const (
+	// Unspecified state.
+	JobState_JOB_STATE_UNSPECIFIED JobState = 0
+	// Waiting for a free worker.
+	JobState_JOB_STATE_QUEUED JobState = 1
+	// Being translated.
+	JobState_JOB_STATE_RUNNING JobState = 2
+	// Every image has finished. Single images may still have failed.
+	JobState_JOB_STATE_SUCCEEDED JobState = 3
+	// The job could not run. See error.
+	JobState_JOB_STATE_FAILED JobState = 4
+	// Cancelled by CancelTranslationJob.
+	JobState_JOB_STATE_CANCELLED JobState = 5
+)
+
+// Enum value maps for JobState.
+var (
+	JobState_name = map[int32]string{
+		0: "JOB_STATE_UNSPECIFIED",
+		1: "JOB_STATE_QUEUED",
+		2: "JOB_STATE_RUNNING",
+		3: "JOB_STATE_SUCCEEDED",
+		4: "JOB_STATE_FAILED",
+		5: "JOB_STATE_CANCELLED",
+	}
+	JobState_value = map[string]int32{
+		"JOB_STATE_UNSPECIFIED": 0,
+		"JOB_STATE_QUEUED":      1,
+		"JOB_STATE_RUNNING":     2,
+		"JOB_STATE_SUCCEEDED":   3,
+		"JOB_STATE_FAILED":      4,
+		"JOB_STATE_CANCELLED":   5,
+	}
+)
+
+func (x JobState) Enum() *JobState {
+	p := new(JobState)
+	*p = x
+	return p
+}
+
+func (x JobState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobState) Descriptor() protoreflect.EnumDescriptor {
+	return file_grpc_grpc_proto_enumTypes[1].Descriptor()
+}
+
+func (JobState) Type() protoreflect.EnumType {
+	return &file_grpc_grpc_proto_enumTypes[1]
+}
+
+func (x JobState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobState.Descriptor instead.
+func (JobState) EnumDescriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{1}
+}
+
+type OutputFormat int32
+
+const (
+	// Unspecified format. Treated as PNG.
+	OutputFormat_OUTPUT_FORMAT_UNSPECIFIED OutputFormat = 0
+	// Lossless PNG.
+	OutputFormat_OUTPUT_FORMAT_PNG OutputFormat = 1
+	// JPEG with the given quality.
+	OutputFormat_OUTPUT_FORMAT_JPEG OutputFormat = 2
+	// Lossless WEBP.
+	OutputFormat_OUTPUT_FORMAT_WEBP_LOSSLESS OutputFormat = 3
+	// Lossy WEBP with the given quality. Transparency is kept losslessly.
+	OutputFormat_OUTPUT_FORMAT_WEBP_LOSSY OutputFormat = 4
+	// SVG with the background without texts as a PNG image, and the translated texts as text elements
+	// with the fonts embedded, so that the texts stay selectable and editable. Only for TranslateToImage.
+	// The embedded fonts keep only the glyphs of the translated texts, so characters added while editing
+	// may be drawn with the fonts of the viewer.
+	OutputFormat_OUTPUT_FORMAT_SVG OutputFormat = 5
+	// HTML with the background without texts as a PNG image, and the translated texts as absolutely positioned elements
+	// with the fonts embedded as in OUTPUT_FORMAT_SVG. Only for TranslateToImage.
+	OutputFormat_OUTPUT_FORMAT_HTML OutputFormat = 6
+)
+
+// Enum value maps for OutputFormat.
+var (
+	OutputFormat_name = map[int32]string{
+		0: "OUTPUT_FORMAT_UNSPECIFIED",
+		1: "OUTPUT_FORMAT_PNG",
+		2: "OUTPUT_FORMAT_JPEG",
+		3: "OUTPUT_FORMAT_WEBP_LOSSLESS",
+		4: "OUTPUT_FORMAT_WEBP_LOSSY",
+		5: "OUTPUT_FORMAT_SVG",
+		6: "OUTPUT_FORMAT_HTML",
+	}
+	OutputFormat_value = map[string]int32{
+		"OUTPUT_FORMAT_UNSPECIFIED":   0,
+		"OUTPUT_FORMAT_PNG":           1,
+		"OUTPUT_FORMAT_JPEG":          2,
+		"OUTPUT_FORMAT_WEBP_LOSSLESS": 3,
+		"OUTPUT_FORMAT_WEBP_LOSSY":    4,
+		"OUTPUT_FORMAT_SVG":           5,
+		"OUTPUT_FORMAT_HTML":          6,
+	}
+)
+
+func (x OutputFormat) Enum() *OutputFormat {
+	p := new(OutputFormat)
+	*p = x
+	return p
+}
+
+func (x OutputFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutputFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_grpc_grpc_proto_enumTypes[2].Descriptor()
+}
+
+func (OutputFormat) Type() protoreflect.EnumType {
+	return &file_grpc_grpc_proto_enumTypes[2]
+}
+
+func (x OutputFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutputFormat.Descriptor instead.
+func (OutputFormat) EnumDescriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{2}
+}
+
 type Language int32
 
 const (
@@ -31,21 +235,53 @@ const (
 	Language_LANGUAGE_KO_KR Language = 2
 	// Japanese (Japan). ja-JP.
 	Language_LANGUAGE_JA_JP Language = 3
+	// Simplified Chinese (China). zh-CN.
+	Language_LANGUAGE_ZH_CN Language = 4
+	// Traditional Chinese (Taiwan). zh-TW.
+	Language_LANGUAGE_ZH_TW Language = 5
+	// Thai (Thailand). th-TH.
+	Language_LANGUAGE_TH_TH Language = 6
+	// Vietnamese (Vietnam). vi-VN.
+	Language_LANGUAGE_VI_VN Language = 7
+	// Indonesian (Indonesia). id-ID.
+	Language_LANGUAGE_ID_ID Language = 8
+	// Spanish (Spain). es-ES.
+	Language_LANGUAGE_ES_ES Language = 9
+	// French (France). fr-FR.
+	Language_LANGUAGE_FR_FR Language = 10
+	// German (Germany). de-DE.
+	Language_LANGUAGE_DE_DE Language = 11
 )
 
 // Enum value maps for Language.
 var (
 	Language_name = map[int32]string{
-		0: "LANGUAGE_UNSPECIFIED",
-		1: "LANGUAGE_EN_US",
-		2: "LANGUAGE_KO_KR",
-		3: "LANGUAGE_JA_JP",
+		0:  "LANGUAGE_UNSPECIFIED",
+		1:  "LANGUAGE_EN_US",
+		2:  "LANGUAGE_KO_KR",
+		3:  "LANGUAGE_JA_JP",
+		4:  "LANGUAGE_ZH_CN",
+		5:  "LANGUAGE_ZH_TW",
+		6:  "LANGUAGE_TH_TH",
+		7:  "LANGUAGE_VI_VN",
+		8:  "LANGUAGE_ID_ID",
+		9:  "LANGUAGE_ES_ES",
+		10: "LANGUAGE_FR_FR",
+		11: "LANGUAGE_DE_DE",
 	}
 	Language_value = map[string]int32{
 		"LANGUAGE_UNSPECIFIED": 0,
 		"LANGUAGE_EN_US":       1,
 		"LANGUAGE_KO_KR":       2,
 		"LANGUAGE_JA_JP":       3,
+		"LANGUAGE_ZH_CN":       4,
+		"LANGUAGE_ZH_TW":       5,
+		"LANGUAGE_TH_TH":       6,
+		"LANGUAGE_VI_VN":       7,
+		"LANGUAGE_ID_ID":       8,
+		"LANGUAGE_ES_ES":       9,
+		"LANGUAGE_FR_FR":       10,
+		"LANGUAGE_DE_DE":       11,
 	}
 )
 
@@ -60,11 +296,11 @@ func (x Language) String() string {
 }
 
 func (Language) Descriptor() protoreflect.EnumDescriptor {
-	return file_grpc_grpc_proto_enumTypes[0].Descriptor()
+	return file_grpc_grpc_proto_enumTypes[3].Descriptor()
 }
 
 func (Language) Type() protoreflect.EnumType {
-	return &file_grpc_grpc_proto_enumTypes[0]
+	return &file_grpc_grpc_proto_enumTypes[3]
 }
 
 func (x Language) Number() protoreflect.EnumNumber {
@@ -73,7 +309,7 @@ func (x Language) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Language.Descriptor instead.
 func (Language) EnumDescriptor() ([]byte, []int) {
-	return file_grpc_grpc_proto_rawDescGZIP(), []int{0}
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{3}
 }
 
 type Model int32
@@ -116,11 +352,11 @@ func (x Model) String() string {
 }
 
 func (Model) Descriptor() protoreflect.EnumDescriptor {
-	return file_grpc_grpc_proto_enumTypes[1].Descriptor()
+	return file_grpc_grpc_proto_enumTypes[4].Descriptor()
 }
 
 func (Model) Type() protoreflect.EnumType {
-	return &file_grpc_grpc_proto_enumTypes[1]
+	return &file_grpc_grpc_proto_enumTypes[4]
 }
 
 func (x Model) Number() protoreflect.EnumNumber {
@@ -129,7 +365,63 @@ func (x Model) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Model.Descriptor instead.
 func (Model) EnumDescriptor() ([]byte, []int) {
-	return file_grpc_grpc_proto_rawDescGZIP(), []int{1}
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{4}
+}
+
+type FontWeight int32
+
+const (
+	// Unspecified weight.
+	FontWeight_FONT_WEIGHT_UNSPECIFIED FontWeight = 0
+	// 400 in CSS.
+	FontWeight_FONT_WEIGHT_REGULAR FontWeight = 1
+	// 600 in CSS.
+	FontWeight_FONT_WEIGHT_SEMI_BOLD FontWeight = 2
+	// 700 in CSS.
+	FontWeight_FONT_WEIGHT_BOLD FontWeight = 3
+)
+
+// Enum value maps for FontWeight.
+var (
+	FontWeight_name = map[int32]string{
+		0: "FONT_WEIGHT_UNSPECIFIED",
+		1: "FONT_WEIGHT_REGULAR",
+		2: "FONT_WEIGHT_SEMI_BOLD",
+		3: "FONT_WEIGHT_BOLD",
+	}
+	FontWeight_value = map[string]int32{
+		"FONT_WEIGHT_UNSPECIFIED": 0,
+		"FONT_WEIGHT_REGULAR":     1,
+		"FONT_WEIGHT_SEMI_BOLD":   2,
+		"FONT_WEIGHT_BOLD":        3,
+	}
+)
+
+func (x FontWeight) Enum() *FontWeight {
+	p := new(FontWeight)
+	*p = x
+	return p
+}
+
+func (x FontWeight) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FontWeight) Descriptor() protoreflect.EnumDescriptor {
+	return file_grpc_grpc_proto_enumTypes[5].Descriptor()
+}
+
+func (FontWeight) Type() protoreflect.EnumType {
+	return &file_grpc_grpc_proto_enumTypes[5]
+}
+
+func (x FontWeight) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FontWeight.Descriptor instead.
+func (FontWeight) EnumDescriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{5}
 }
 
 type TranslateTextFromImageRequest struct {
@@ -141,6 +433,13 @@ type TranslateTextFromImageRequest struct {
 	Image []byte `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
 	// Target language for translation. E.g., ko-KR
 	TargetLanguage Language `protobuf:"varint,2,opt,name=target_language,json=targetLanguage,proto3,enum=visionex.grpc.Language" json:"target_language,omitempty"`
+	// The encoding of the returned image. Defaults to PNG.
+	OutputEncoding *OutputEncoding `protobuf:"bytes,3,opt,name=output_encoding,json=outputEncoding,proto3" json:"output_encoding,omitempty"`
+	// Language of the texts in the image. E.g., LANGUAGE_KO_KR
+	// Detected automatically when unspecified.
+	SourceLanguage Language `protobuf:"varint,4,opt,name=source_language,json=sourceLanguage,proto3,enum=visionex.grpc.Language" json:"source_language,omitempty"`
+	// Terms to translate consistently. Violations are reported in the response.
+	Glossary *Glossary `protobuf:"bytes,5,opt,name=glossary,proto3" json:"glossary,omitempty"`
 }
 
 func (x *TranslateTextFromImageRequest) Reset() {
@@ -189,6 +488,27 @@ func (x *TranslateTextFromImageRequest) GetTargetLanguage() Language {
 	return Language_LANGUAGE_UNSPECIFIED
 }
 
+func (x *TranslateTextFromImageRequest) GetOutputEncoding() *OutputEncoding {
+	if x != nil {
+		return x.OutputEncoding
+	}
+	return nil
+}
+
+func (x *TranslateTextFromImageRequest) GetSourceLanguage() Language {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return Language_LANGUAGE_UNSPECIFIED
+}
+
+func (x *TranslateTextFromImageRequest) GetGlossary() *Glossary {
+	if x != nil {
+		return x.Glossary
+	}
+	return nil
+}
+
 type TranslateTextFromImageResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -198,6 +518,13 @@ type TranslateTextFromImageResponse struct {
 	UriImage string `protobuf:"bytes,1,opt,name=uri_image,json=uriImage,proto3" json:"uri_image,omitempty"`
 	// The translated sentences.
 	Sentences []*Sentence `protobuf:"bytes,2,rep,name=sentences,proto3" json:"sentences,omitempty"`
+	// The MIME type of uri_image. E.g., "image/png"
+	MimeType string `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	// Language of the texts in the image.
+	// The requested source language, or the detected one when it was unspecified.
+	SourceLanguage Language `protobuf:"varint,4,opt,name=source_language,json=sourceLanguage,proto3,enum=visionex.grpc.Language" json:"source_language,omitempty"`
+	// The sentences whose translation does not follow the glossary.
+	GlossaryViolations []*GlossaryViolation `protobuf:"bytes,5,rep,name=glossary_violations,json=glossaryViolations,proto3" json:"glossary_violations,omitempty"`
 }
 
 func (x *TranslateTextFromImageResponse) Reset() {
@@ -246,6 +573,27 @@ func (x *TranslateTextFromImageResponse) GetSentences() []*Sentence {
 	return nil
 }
 
+func (x *TranslateTextFromImageResponse) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *TranslateTextFromImageResponse) GetSourceLanguage() Language {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return Language_LANGUAGE_UNSPECIFIED
+}
+
+func (x *TranslateTextFromImageResponse) GetGlossaryViolations() []*GlossaryViolation {
+	if x != nil {
+		return x.GlossaryViolations
+	}
+	return nil
+}
+
 type Sentence struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -313,7 +661,13 @@ type TranslateToMarkdownRequest struct {
 	// Model to be used for create Markdown.
 	Model Model `protobuf:"varint,3,opt,name=model,proto3,enum=visionex.grpc.Model" json:"model,omitempty"`
 	// The image to be translated into Markdown format.
+	// May also be a PDF or multi-page TIFF document.
 	Image []byte `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	// Language of the texts in the image. E.g., LANGUAGE_KO_KR
+	// Detected automatically when unspecified.
+	SourceLanguage Language `protobuf:"varint,5,opt,name=source_language,json=sourceLanguage,proto3,enum=visionex.grpc.Language" json:"source_language,omitempty"`
+	// Terms to translate consistently. Violations are reported in the response.
+	Glossary *Glossary `protobuf:"bytes,6,opt,name=glossary,proto3" json:"glossary,omitempty"`
 }
 
 func (x *TranslateToMarkdownRequest) Reset() {
@@ -369,6 +723,20 @@ func (x *TranslateToMarkdownRequest) GetImage() []byte {
 	return nil
 }
 
+func (x *TranslateToMarkdownRequest) GetSourceLanguage() Language {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return Language_LANGUAGE_UNSPECIFIED
+}
+
+func (x *TranslateToMarkdownRequest) GetGlossary() *Glossary {
+	if x != nil {
+		return x.Glossary
+	}
+	return nil
+}
+
 type TranslateToImageRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -376,8 +744,22 @@ type TranslateToImageRequest struct {
 
 	// Target language for translation.
 	TargetLanguage Language `protobuf:"varint,2,opt,name=target_language,json=targetLanguage,proto3,enum=visionex.grpc.Language" json:"target_language,omitempty"`
-	// The image to be translated into Markdown format.
+	// The image to be translated.
+	// May also be a PDF or multi-page TIFF document.
 	Image []byte `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	// The encoding of the returned images. Defaults to PNG.
+	OutputEncoding *OutputEncoding `protobuf:"bytes,4,opt,name=output_encoding,json=outputEncoding,proto3" json:"output_encoding,omitempty"`
+	// Language of the texts in the image. E.g., LANGUAGE_KO_KR
+	// Detected automatically when unspecified.
+	SourceLanguage Language `protobuf:"varint,5,opt,name=source_language,json=sourceLanguage,proto3,enum=visionex.grpc.Language" json:"source_language,omitempty"`
+	// Terms to translate consistently. Violations are reported in the response.
+	Glossary *Glossary `protobuf:"bytes,6,opt,name=glossary,proto3" json:"glossary,omitempty"`
+	// The name of an uploaded font family to draw the translated texts with, instead of the fonts of the target language.
+	// Characters the family lacks are drawn with the fonts of the target language. E.g., "Acme Sans"
+	FontFamily string `protobuf:"bytes,7,opt,name=font_family,json=fontFamily,proto3" json:"font_family,omitempty"`
+	// When set, the translated image of every page is returned in slices at most this many pixels tall
+	// instead of as a whole, for platforms that cap the image height. Slices are cut between texts. E.g., 3000
+	MaxSliceHeight int32 `protobuf:"varint,8,opt,name=max_slice_height,json=maxSliceHeight,proto3" json:"max_slice_height,omitempty"`
 }
 
 func (x *TranslateToImageRequest) Reset() {
@@ -426,14 +808,57 @@ func (x *TranslateToImageRequest) GetImage() []byte {
 	return nil
 }
 
+func (x *TranslateToImageRequest) GetOutputEncoding() *OutputEncoding {
+	if x != nil {
+		return x.OutputEncoding
+	}
+	return nil
+}
+
+func (x *TranslateToImageRequest) GetSourceLanguage() Language {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return Language_LANGUAGE_UNSPECIFIED
+}
+
+func (x *TranslateToImageRequest) GetGlossary() *Glossary {
+	if x != nil {
+		return x.Glossary
+	}
+	return nil
+}
+
+func (x *TranslateToImageRequest) GetFontFamily() string {
+	if x != nil {
+		return x.FontFamily
+	}
+	return ""
+}
+
+func (x *TranslateToImageRequest) GetMaxSliceHeight() int32 {
+	if x != nil {
+		return x.MaxSliceHeight
+	}
+	return 0
+}
+
 type TranslateToMarkdownResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	// The translated Markdown.
+	// For PDF and multi-page TIFF inputs, the Markdown of every page joined by a horizontal rule.
 	// E.g., "# Example Title\nThis is an example paragraph.".
 	Markdown string `protobuf:"bytes,1,opt,name=markdown,proto3" json:"markdown,omitempty"`
+	// The translated Markdown of each page. Only set for PDF and multi-page TIFF inputs.
+	PageMarkdowns []string `protobuf:"bytes,2,rep,name=page_markdowns,json=pageMarkdowns,proto3" json:"page_markdowns,omitempty"`
+	// Language of the texts in the image.
+	// The requested source language, or the detected one when it was unspecified.
+	SourceLanguage Language `protobuf:"varint,3,opt,name=source_language,json=sourceLanguage,proto3,enum=visionex.grpc.Language" json:"source_language,omitempty"`
+	// The pages whose translation does not follow the glossary.
+	GlossaryViolations []*GlossaryViolation `protobuf:"bytes,4,rep,name=glossary_violations,json=glossaryViolations,proto3" json:"glossary_violations,omitempty"`
 }
 
 func (x *TranslateToMarkdownResponse) Reset() {
@@ -475,15 +900,50 @@ func (x *TranslateToMarkdownResponse) GetMarkdown() string {
 	return ""
 }
 
+func (x *TranslateToMarkdownResponse) GetPageMarkdowns() []string {
+	if x != nil {
+		return x.PageMarkdowns
+	}
+	return nil
+}
+
+func (x *TranslateToMarkdownResponse) GetSourceLanguage() Language {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return Language_LANGUAGE_UNSPECIFIED
+}
+
+func (x *TranslateToMarkdownResponse) GetGlossaryViolations() []*GlossaryViolation {
+	if x != nil {
+		return x.GlossaryViolations
+	}
+	return nil
+}
+
 type TranslateToImageResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The translated image in URI format.
-	// Currently only PNG format will be returned.
+	// The translated image in URI format, encoded as requested in output_encoding.
+	// For PDF and multi-page TIFF inputs, the first page. Empty when max_slice_height is set.
 	// E.g., "data:image/png;base64,..."
 	UriImage string `protobuf:"bytes,1,opt,name=uri_image,json=uriImage,proto3" json:"uri_image,omitempty"`
+	// The translated image of each page in URI format. Only set for PDF and multi-page TIFF inputs,
+	// unless max_slice_height is set.
+	// E.g., ["data:image/png;base64,...", "data:image/png;base64,..."]
+	PageUriImages []string `protobuf:"bytes,2,rep,name=page_uri_images,json=pageUriImages,proto3" json:"page_uri_images,omitempty"`
+	// The MIME type of the translated images. E.g., "image/webp"
+	MimeType string `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	// Language of the texts in the image.
+	// The requested source language, or the detected one when it was unspecified.
+	SourceLanguage Language `protobuf:"varint,4,opt,name=source_language,json=sourceLanguage,proto3,enum=visionex.grpc.Language" json:"source_language,omitempty"`
+	// The sentences whose translation does not follow the glossary.
+	GlossaryViolations []*GlossaryViolation `protobuf:"bytes,5,rep,name=glossary_violations,json=glossaryViolations,proto3" json:"glossary_violations,omitempty"`
+	// The translated images cut into slices from top to bottom, page after page.
+	// Only set when max_slice_height is set.
+	Slices []*ImageSlice `protobuf:"bytes,6,rep,name=slices,proto3" json:"slices,omitempty"`
 }
 
 func (x *TranslateToImageResponse) Reset() {
@@ -525,17 +985,58 @@ func (x *TranslateToImageResponse) GetUriImage() string {
 	return ""
 }
 
-type SignInRequest struct {
+func (x *TranslateToImageResponse) GetPageUriImages() []string {
+	if x != nil {
+		return x.PageUriImages
+	}
+	return nil
+}
+
+func (x *TranslateToImageResponse) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *TranslateToImageResponse) GetSourceLanguage() Language {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return Language_LANGUAGE_UNSPECIFIED
+}
+
+func (x *TranslateToImageResponse) GetGlossaryViolations() []*GlossaryViolation {
+	if x != nil {
+		return x.GlossaryViolations
+	}
+	return nil
+}
+
+func (x *TranslateToImageResponse) GetSlices() []*ImageSlice {
+	if x != nil {
+		return x.Slices
+	}
+	return nil
+}
+
+type ImageSlice struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Google OpenID token of the user. E.g., "abcdef123ghijk"
-	GoogleOpenIdToken string `protobuf:"bytes,1,opt,name=google_open_id_token,json=googleOpenIdToken,proto3" json:"google_open_id_token,omitempty"`
+	// The slice in URI format, encoded as requested in output_encoding. E.g., "data:image/png;base64,..."
+	UriImage string `protobuf:"bytes,1,opt,name=uri_image,json=uriImage,proto3" json:"uri_image,omitempty"`
+	// The index of the page the slice is cut from. Always 0 for raster images.
+	PageIndex int32 `protobuf:"varint,2,opt,name=page_index,json=pageIndex,proto3" json:"page_index,omitempty"`
+	// The row of the page where the slice starts, in pixels. E.g., 3000
+	Top int32 `protobuf:"varint,3,opt,name=top,proto3" json:"top,omitempty"`
+	// The height of the slice in pixels. E.g., 2840
+	Height int32 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
 }
 
-func (x *SignInRequest) Reset() {
-	*x = SignInRequest{}
+func (x *ImageSlice) Reset() {
+	*x = ImageSlice{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_grpc_grpc_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -543,13 +1044,13 @@ func (x *SignInRequest) Reset() {
 	}
 }
 
-func (x *SignInRequest) String() string {
+func (x *ImageSlice) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignInRequest) ProtoMessage() {}
+func (*ImageSlice) ProtoMessage() {}
 
-func (x *SignInRequest) ProtoReflect() protoreflect.Message {
+func (x *ImageSlice) ProtoReflect() protoreflect.Message {
 	mi := &file_grpc_grpc_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -561,29 +1062,52 @@ func (x *SignInRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignInRequest.ProtoReflect.Descriptor instead.
-func (*SignInRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ImageSlice.ProtoReflect.Descriptor instead.
+func (*ImageSlice) Descriptor() ([]byte, []int) {
 	return file_grpc_grpc_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *SignInRequest) GetGoogleOpenIdToken() string {
+func (x *ImageSlice) GetUriImage() string {
 	if x != nil {
-		return x.GoogleOpenIdToken
+		return x.UriImage
 	}
 	return ""
 }
 
-type SignInResponse struct {
+func (x *ImageSlice) GetPageIndex() int32 {
+	if x != nil {
+		return x.PageIndex
+	}
+	return 0
+}
+
+func (x *ImageSlice) GetTop() int32 {
+	if x != nil {
+		return x.Top
+	}
+	return 0
+}
+
+func (x *ImageSlice) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type Glossary struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// A Bearer token that must be set in the "Authorization" header for every follow-up request. E.g., "Bearer abc123"
-	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Terms that must be translated into the given target term.
+	Terms []*GlossaryTerm `protobuf:"bytes,1,rep,name=terms,proto3" json:"terms,omitempty"`
+	// Terms that must be kept as they are. E.g., ["VisionEx", "iPhone"]
+	DoNotTranslate []string `protobuf:"bytes,2,rep,name=do_not_translate,json=doNotTranslate,proto3" json:"do_not_translate,omitempty"`
 }
 
-func (x *SignInResponse) Reset() {
-	*x = SignInResponse{}
+func (x *Glossary) Reset() {
+	*x = Glossary{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_grpc_grpc_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -591,13 +1115,13 @@ func (x *SignInResponse) Reset() {
 	}
 }
 
-func (x *SignInResponse) String() string {
+func (x *Glossary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignInResponse) ProtoMessage() {}
+func (*Glossary) ProtoMessage() {}
 
-func (x *SignInResponse) ProtoReflect() protoreflect.Message {
+func (x *Glossary) ProtoReflect() protoreflect.Message {
 	mi := &file_grpc_grpc_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -609,9 +1133,2159 @@ func (x *SignInResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignInResponse.ProtoReflect.Descriptor instead.
+// Deprecated: Use Glossary.ProtoReflect.Descriptor instead.
+func (*Glossary) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Glossary) GetTerms() []*GlossaryTerm {
+	if x != nil {
+		return x.Terms
+	}
+	return nil
+}
+
+func (x *Glossary) GetDoNotTranslate() []string {
+	if x != nil {
+		return x.DoNotTranslate
+	}
+	return nil
+}
+
+type GlossaryTerm struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The term in the source language. E.g., "롯데월드"
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// The term that must be used in the translation. E.g., "Lotte World"
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+}
+
+func (x *GlossaryTerm) Reset() {
+	*x = GlossaryTerm{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GlossaryTerm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GlossaryTerm) ProtoMessage() {}
+
+func (x *GlossaryTerm) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GlossaryTerm.ProtoReflect.Descriptor instead.
+func (*GlossaryTerm) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GlossaryTerm) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *GlossaryTerm) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type GlossaryViolation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The glossary term found in the original text. E.g., "롯데월드"
+	SourceTerm string `protobuf:"bytes,1,opt,name=source_term,json=sourceTerm,proto3" json:"source_term,omitempty"`
+	// The term missing from the translation.
+	// Same as source_term for do-not-translate terms. E.g., "Lotte World"
+	ExpectedTerm string `protobuf:"bytes,2,opt,name=expected_term,json=expectedTerm,proto3" json:"expected_term,omitempty"`
+	// The original text containing the source term. E.g., "롯데월드 자유이용권"
+	SourceText string `protobuf:"bytes,3,opt,name=source_text,json=sourceText,proto3" json:"source_text,omitempty"`
+	// The translated text. E.g., "Lotte Land Free Pass"
+	TranslatedText string `protobuf:"bytes,4,opt,name=translated_text,json=translatedText,proto3" json:"translated_text,omitempty"`
+	// The zero-based page index for PDF and multi-page TIFF inputs. E.g., 0
+	PageIndex int32 `protobuf:"varint,5,opt,name=page_index,json=pageIndex,proto3" json:"page_index,omitempty"`
+}
+
+func (x *GlossaryViolation) Reset() {
+	*x = GlossaryViolation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GlossaryViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GlossaryViolation) ProtoMessage() {}
+
+func (x *GlossaryViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GlossaryViolation.ProtoReflect.Descriptor instead.
+func (*GlossaryViolation) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GlossaryViolation) GetSourceTerm() string {
+	if x != nil {
+		return x.SourceTerm
+	}
+	return ""
+}
+
+func (x *GlossaryViolation) GetExpectedTerm() string {
+	if x != nil {
+		return x.ExpectedTerm
+	}
+	return ""
+}
+
+func (x *GlossaryViolation) GetSourceText() string {
+	if x != nil {
+		return x.SourceText
+	}
+	return ""
+}
+
+func (x *GlossaryViolation) GetTranslatedText() string {
+	if x != nil {
+		return x.TranslatedText
+	}
+	return ""
+}
+
+func (x *GlossaryViolation) GetPageIndex() int32 {
+	if x != nil {
+		return x.PageIndex
+	}
+	return 0
+}
+
+type OutputEncoding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The image format. Defaults to PNG. Other RPCs return PNG for SVG and HTML.
+	Format OutputFormat `protobuf:"varint,1,opt,name=format,proto3,enum=visionex.grpc.OutputFormat" json:"format,omitempty"`
+	// The quality between 1 and 100 for JPEG and lossy WEBP. Defaults to 90. E.g., 80
+	Quality int32 `protobuf:"varint,2,opt,name=quality,proto3" json:"quality,omitempty"`
+}
+
+func (x *OutputEncoding) Reset() {
+	*x = OutputEncoding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OutputEncoding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputEncoding) ProtoMessage() {}
+
+func (x *OutputEncoding) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputEncoding.ProtoReflect.Descriptor instead.
+func (*OutputEncoding) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *OutputEncoding) GetFormat() OutputFormat {
+	if x != nil {
+		return x.Format
+	}
+	return OutputFormat_OUTPUT_FORMAT_UNSPECIFIED
+}
+
+func (x *OutputEncoding) GetQuality() int32 {
+	if x != nil {
+		return x.Quality
+	}
+	return 0
+}
+
+type TranslateToImageProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The pipeline stage that has just finished.
+	Stage Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=visionex.grpc.Stage" json:"stage,omitempty"`
+	// Time elapsed since the request was received, in milliseconds. E.g., 1532
+	ElapsedMilliseconds int64 `protobuf:"varint,2,opt,name=elapsed_milliseconds,json=elapsedMilliseconds,proto3" json:"elapsed_milliseconds,omitempty"`
+	// The number of translated chunks so far. Only set for STAGE_TRANSLATE. E.g., 3
+	TranslatedChunkCount int32 `protobuf:"varint,3,opt,name=translated_chunk_count,json=translatedChunkCount,proto3" json:"translated_chunk_count,omitempty"`
+	// The total number of chunks to translate. Only set for STAGE_TRANSLATE. E.g., 7
+	TotalChunkCount int32 `protobuf:"varint,4,opt,name=total_chunk_count,json=totalChunkCount,proto3" json:"total_chunk_count,omitempty"`
+	// The final result. Only set for STAGE_DONE.
+	Result *TranslateToImageResponse `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *TranslateToImageProgress) Reset() {
+	*x = TranslateToImageProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TranslateToImageProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranslateToImageProgress) ProtoMessage() {}
+
+func (x *TranslateToImageProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranslateToImageProgress.ProtoReflect.Descriptor instead.
+func (*TranslateToImageProgress) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TranslateToImageProgress) GetStage() Stage {
+	if x != nil {
+		return x.Stage
+	}
+	return Stage_STAGE_UNSPECIFIED
+}
+
+func (x *TranslateToImageProgress) GetElapsedMilliseconds() int64 {
+	if x != nil {
+		return x.ElapsedMilliseconds
+	}
+	return 0
+}
+
+func (x *TranslateToImageProgress) GetTranslatedChunkCount() int32 {
+	if x != nil {
+		return x.TranslatedChunkCount
+	}
+	return 0
+}
+
+func (x *TranslateToImageProgress) GetTotalChunkCount() int32 {
+	if x != nil {
+		return x.TotalChunkCount
+	}
+	return 0
+}
+
+func (x *TranslateToImageProgress) GetResult() *TranslateToImageResponse {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type TranslateToImageBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Target language for translation, shared by every image.
+	TargetLanguage Language `protobuf:"varint,1,opt,name=target_language,json=targetLanguage,proto3,enum=visionex.grpc.Language" json:"target_language,omitempty"`
+	// The images to be translated. At most 20 images are allowed.
+	Images [][]byte `protobuf:"bytes,2,rep,name=images,proto3" json:"images,omitempty"`
+	// The encoding of the returned images, shared by every image. Defaults to PNG.
+	OutputEncoding *OutputEncoding `protobuf:"bytes,3,opt,name=output_encoding,json=outputEncoding,proto3" json:"output_encoding,omitempty"`
+	// Language of the texts in the images, shared by every image.
+	// Detected automatically for each image when unspecified.
+	SourceLanguage Language `protobuf:"varint,4,opt,name=source_language,json=sourceLanguage,proto3,enum=visionex.grpc.Language" json:"source_language,omitempty"`
+	// Terms to translate consistently, shared by every image.
+	Glossary *Glossary `protobuf:"bytes,5,opt,name=glossary,proto3" json:"glossary,omitempty"`
+	// The name of an uploaded font family, shared by every image. E.g., "Acme Sans"
+	FontFamily string `protobuf:"bytes,6,opt,name=font_family,json=fontFamily,proto3" json:"font_family,omitempty"`
+	// The maximum height of the slices, shared by every image. See TranslateToImageRequest. E.g., 3000
+	MaxSliceHeight int32 `protobuf:"varint,7,opt,name=max_slice_height,json=maxSliceHeight,proto3" json:"max_slice_height,omitempty"`
+}
+
+func (x *TranslateToImageBatchRequest) Reset() {
+	*x = TranslateToImageBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TranslateToImageBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranslateToImageBatchRequest) ProtoMessage() {}
+
+func (x *TranslateToImageBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranslateToImageBatchRequest.ProtoReflect.Descriptor instead.
+func (*TranslateToImageBatchRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TranslateToImageBatchRequest) GetTargetLanguage() Language {
+	if x != nil {
+		return x.TargetLanguage
+	}
+	return Language_LANGUAGE_UNSPECIFIED
+}
+
+func (x *TranslateToImageBatchRequest) GetImages() [][]byte {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *TranslateToImageBatchRequest) GetOutputEncoding() *OutputEncoding {
+	if x != nil {
+		return x.OutputEncoding
+	}
+	return nil
+}
+
+func (x *TranslateToImageBatchRequest) GetSourceLanguage() Language {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return Language_LANGUAGE_UNSPECIFIED
+}
+
+func (x *TranslateToImageBatchRequest) GetGlossary() *Glossary {
+	if x != nil {
+		return x.Glossary
+	}
+	return nil
+}
+
+func (x *TranslateToImageBatchRequest) GetFontFamily() string {
+	if x != nil {
+		return x.FontFamily
+	}
+	return ""
+}
+
+func (x *TranslateToImageBatchRequest) GetMaxSliceHeight() int32 {
+	if x != nil {
+		return x.MaxSliceHeight
+	}
+	return 0
+}
+
+type TranslateToImageBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// One result per image, in the same order as the request images.
+	Results []*TranslateToImageBatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *TranslateToImageBatchResponse) Reset() {
+	*x = TranslateToImageBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TranslateToImageBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranslateToImageBatchResponse) ProtoMessage() {}
+
+func (x *TranslateToImageBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranslateToImageBatchResponse.ProtoReflect.Descriptor instead.
+func (*TranslateToImageBatchResponse) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TranslateToImageBatchResponse) GetResults() []*TranslateToImageBatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type TranslateToImageBatchResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The status of the translation of this image.
+	Status *BatchStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// The translated image. Only set when the status code is OK.
+	Response *TranslateToImageResponse `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+}
+
+func (x *TranslateToImageBatchResult) Reset() {
+	*x = TranslateToImageBatchResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TranslateToImageBatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranslateToImageBatchResult) ProtoMessage() {}
+
+func (x *TranslateToImageBatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranslateToImageBatchResult.ProtoReflect.Descriptor instead.
+func (*TranslateToImageBatchResult) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TranslateToImageBatchResult) GetStatus() *BatchStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *TranslateToImageBatchResult) GetResponse() *TranslateToImageResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+type TranslateToMarkdownBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Target language for translation, shared by every image.
+	TargetLanguage Language `protobuf:"varint,1,opt,name=target_language,json=targetLanguage,proto3,enum=visionex.grpc.Language" json:"target_language,omitempty"`
+	// Model to be used for create Markdown, shared by every image.
+	Model Model `protobuf:"varint,2,opt,name=model,proto3,enum=visionex.grpc.Model" json:"model,omitempty"`
+	// The images to be translated. At most 20 images are allowed.
+	Images [][]byte `protobuf:"bytes,3,rep,name=images,proto3" json:"images,omitempty"`
+	// Language of the texts in the images, shared by every image.
+	// Detected automatically for each image when unspecified.
+	SourceLanguage Language `protobuf:"varint,4,opt,name=source_language,json=sourceLanguage,proto3,enum=visionex.grpc.Language" json:"source_language,omitempty"`
+	// Terms to translate consistently, shared by every image.
+	Glossary *Glossary `protobuf:"bytes,5,opt,name=glossary,proto3" json:"glossary,omitempty"`
+}
+
+func (x *TranslateToMarkdownBatchRequest) Reset() {
+	*x = TranslateToMarkdownBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TranslateToMarkdownBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranslateToMarkdownBatchRequest) ProtoMessage() {}
+
+func (x *TranslateToMarkdownBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranslateToMarkdownBatchRequest.ProtoReflect.Descriptor instead.
+func (*TranslateToMarkdownBatchRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TranslateToMarkdownBatchRequest) GetTargetLanguage() Language {
+	if x != nil {
+		return x.TargetLanguage
+	}
+	return Language_LANGUAGE_UNSPECIFIED
+}
+
+func (x *TranslateToMarkdownBatchRequest) GetModel() Model {
+	if x != nil {
+		return x.Model
+	}
+	return Model_MODEL_UNSPECIFIED
+}
+
+func (x *TranslateToMarkdownBatchRequest) GetImages() [][]byte {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *TranslateToMarkdownBatchRequest) GetSourceLanguage() Language {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return Language_LANGUAGE_UNSPECIFIED
+}
+
+func (x *TranslateToMarkdownBatchRequest) GetGlossary() *Glossary {
+	if x != nil {
+		return x.Glossary
+	}
+	return nil
+}
+
+type TranslateToMarkdownBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// One result per image, in the same order as the request images.
+	Results []*TranslateToMarkdownBatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *TranslateToMarkdownBatchResponse) Reset() {
+	*x = TranslateToMarkdownBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TranslateToMarkdownBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranslateToMarkdownBatchResponse) ProtoMessage() {}
+
+func (x *TranslateToMarkdownBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranslateToMarkdownBatchResponse.ProtoReflect.Descriptor instead.
+func (*TranslateToMarkdownBatchResponse) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TranslateToMarkdownBatchResponse) GetResults() []*TranslateToMarkdownBatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type TranslateToMarkdownBatchResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The status of the translation of this image.
+	Status *BatchStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// The translated Markdown. Only set when the status code is OK.
+	Response *TranslateToMarkdownResponse `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+}
+
+func (x *TranslateToMarkdownBatchResult) Reset() {
+	*x = TranslateToMarkdownBatchResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TranslateToMarkdownBatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranslateToMarkdownBatchResult) ProtoMessage() {}
+
+func (x *TranslateToMarkdownBatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranslateToMarkdownBatchResult.ProtoReflect.Descriptor instead.
+func (*TranslateToMarkdownBatchResult) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TranslateToMarkdownBatchResult) GetStatus() *BatchStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *TranslateToMarkdownBatchResult) GetResponse() *TranslateToMarkdownResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+type BatchStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The gRPC status code. E.g., 0 for OK, 3 for INVALID_ARGUMENT.
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// The error message. Empty when the code is OK.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *BatchStatus) Reset() {
+	*x = BatchStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchStatus) ProtoMessage() {}
+
+func (x *BatchStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchStatus.ProtoReflect.Descriptor instead.
+func (*BatchStatus) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BatchStatus) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BatchStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DetectLayoutRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The image to be analyzed. May also be a PDF or multi-page TIFF document.
+	Image []byte `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// When set, lines that are already written in this language are left out,
+	// the same way TranslateToImage skips them. E.g., LANGUAGE_EN_US
+	TargetLanguage Language `protobuf:"varint,2,opt,name=target_language,json=targetLanguage,proto3,enum=visionex.grpc.Language" json:"target_language,omitempty"`
+	// Language of the texts in the image. E.g., LANGUAGE_KO_KR
+	// Detected automatically when unspecified.
+	SourceLanguage Language `protobuf:"varint,3,opt,name=source_language,json=sourceLanguage,proto3,enum=visionex.grpc.Language" json:"source_language,omitempty"`
+}
+
+func (x *DetectLayoutRequest) Reset() {
+	*x = DetectLayoutRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DetectLayoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectLayoutRequest) ProtoMessage() {}
+
+func (x *DetectLayoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectLayoutRequest.ProtoReflect.Descriptor instead.
+func (*DetectLayoutRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DetectLayoutRequest) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *DetectLayoutRequest) GetTargetLanguage() Language {
+	if x != nil {
+		return x.TargetLanguage
+	}
+	return Language_LANGUAGE_UNSPECIFIED
+}
+
+func (x *DetectLayoutRequest) GetSourceLanguage() Language {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return Language_LANGUAGE_UNSPECIFIED
+}
+
+type DetectLayoutResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// One layout per page. Raster images always have a single page.
+	Pages []*LayoutPage `protobuf:"bytes,1,rep,name=pages,proto3" json:"pages,omitempty"`
+	// Language of the texts in the image.
+	// The requested source language, or the detected one when it was unspecified.
+	SourceLanguage Language `protobuf:"varint,2,opt,name=source_language,json=sourceLanguage,proto3,enum=visionex.grpc.Language" json:"source_language,omitempty"`
+}
+
+func (x *DetectLayoutResponse) Reset() {
+	*x = DetectLayoutResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DetectLayoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectLayoutResponse) ProtoMessage() {}
+
+func (x *DetectLayoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectLayoutResponse.ProtoReflect.Descriptor instead.
+func (*DetectLayoutResponse) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DetectLayoutResponse) GetPages() []*LayoutPage {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+func (x *DetectLayoutResponse) GetSourceLanguage() Language {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return Language_LANGUAGE_UNSPECIFIED
+}
+
+type LayoutPage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The width of the page image in pixels. E.g., 860
+	Width int32 `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	// The height of the page image in pixels. E.g., 2400
+	Height int32 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// The paragraphs detected in the page.
+	Paragraphs []*LayoutParagraph `protobuf:"bytes,3,rep,name=paragraphs,proto3" json:"paragraphs,omitempty"`
+}
+
+func (x *LayoutPage) Reset() {
+	*x = LayoutPage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LayoutPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LayoutPage) ProtoMessage() {}
+
+func (x *LayoutPage) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LayoutPage.ProtoReflect.Descriptor instead.
+func (*LayoutPage) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LayoutPage) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *LayoutPage) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *LayoutPage) GetParagraphs() []*LayoutParagraph {
+	if x != nil {
+		return x.Paragraphs
+	}
+	return nil
+}
+
+type LayoutParagraph struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The bounding box of the whole paragraph.
+	BoundingBox *BoundingBox `protobuf:"bytes,1,opt,name=bounding_box,json=boundingBox,proto3" json:"bounding_box,omitempty"`
+	// The lines of the paragraph, from top to bottom.
+	Lines []*LayoutLine `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+}
+
+func (x *LayoutParagraph) Reset() {
+	*x = LayoutParagraph{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LayoutParagraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LayoutParagraph) ProtoMessage() {}
+
+func (x *LayoutParagraph) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LayoutParagraph.ProtoReflect.Descriptor instead.
+func (*LayoutParagraph) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LayoutParagraph) GetBoundingBox() *BoundingBox {
+	if x != nil {
+		return x.BoundingBox
+	}
+	return nil
+}
+
+func (x *LayoutParagraph) GetLines() []*LayoutLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type LayoutLine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The bounding box of the whole line.
+	BoundingBox *BoundingBox `protobuf:"bytes,1,opt,name=bounding_box,json=boundingBox,proto3" json:"bounding_box,omitempty"`
+	// The words of the line, grouped by similar style.
+	Words []*LayoutWord `protobuf:"bytes,2,rep,name=words,proto3" json:"words,omitempty"`
+}
+
+func (x *LayoutLine) Reset() {
+	*x = LayoutLine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LayoutLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LayoutLine) ProtoMessage() {}
+
+func (x *LayoutLine) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LayoutLine.ProtoReflect.Descriptor instead.
+func (*LayoutLine) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *LayoutLine) GetBoundingBox() *BoundingBox {
+	if x != nil {
+		return x.BoundingBox
+	}
+	return nil
+}
+
+func (x *LayoutLine) GetWords() []*LayoutWord {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+type LayoutWord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The text. E.g., "Hello"
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// The bounding box of the text.
+	BoundingBox *BoundingBox `protobuf:"bytes,2,opt,name=bounding_box,json=boundingBox,proto3" json:"bounding_box,omitempty"`
+	// The font size of the text in pixels. E.g., 12
+	FontSize float64 `protobuf:"fixed64,3,opt,name=font_size,json=fontSize,proto3" json:"font_size,omitempty"`
+	// The color of the text.
+	TextColor *Color `protobuf:"bytes,4,opt,name=text_color,json=textColor,proto3" json:"text_color,omitempty"`
+	// Numeric font weight based on standard CSS values. E.g., 700
+	FontWeight int32 `protobuf:"varint,5,opt,name=font_weight,json=fontWeight,proto3" json:"font_weight,omitempty"`
+}
+
+func (x *LayoutWord) Reset() {
+	*x = LayoutWord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LayoutWord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LayoutWord) ProtoMessage() {}
+
+func (x *LayoutWord) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LayoutWord.ProtoReflect.Descriptor instead.
+func (*LayoutWord) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *LayoutWord) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *LayoutWord) GetBoundingBox() *BoundingBox {
+	if x != nil {
+		return x.BoundingBox
+	}
+	return nil
+}
+
+func (x *LayoutWord) GetFontSize() float64 {
+	if x != nil {
+		return x.FontSize
+	}
+	return 0
+}
+
+func (x *LayoutWord) GetTextColor() *Color {
+	if x != nil {
+		return x.TextColor
+	}
+	return nil
+}
+
+func (x *LayoutWord) GetFontWeight() int32 {
+	if x != nil {
+		return x.FontWeight
+	}
+	return 0
+}
+
+type BoundingBox struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// E.g., 0
+	Top int32 `protobuf:"varint,1,opt,name=top,proto3" json:"top,omitempty"`
+	// E.g., 0
+	Left int32 `protobuf:"varint,2,opt,name=left,proto3" json:"left,omitempty"`
+	// E.g., 100
+	Bottom int32 `protobuf:"varint,3,opt,name=bottom,proto3" json:"bottom,omitempty"`
+	// E.g., 100
+	Right int32 `protobuf:"varint,4,opt,name=right,proto3" json:"right,omitempty"`
+}
+
+func (x *BoundingBox) Reset() {
+	*x = BoundingBox{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BoundingBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoundingBox) ProtoMessage() {}
+
+func (x *BoundingBox) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoundingBox.ProtoReflect.Descriptor instead.
+func (*BoundingBox) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BoundingBox) GetTop() int32 {
+	if x != nil {
+		return x.Top
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetLeft() int32 {
+	if x != nil {
+		return x.Left
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetBottom() int32 {
+	if x != nil {
+		return x.Bottom
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetRight() int32 {
+	if x != nil {
+		return x.Right
+	}
+	return 0
+}
+
+type Color struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The red channel between 0 and 1. E.g., 0.1
+	Red float64 `protobuf:"fixed64,1,opt,name=red,proto3" json:"red,omitempty"`
+	// The green channel between 0 and 1. E.g., 0.0
+	Green float64 `protobuf:"fixed64,2,opt,name=green,proto3" json:"green,omitempty"`
+	// The blue channel between 0 and 1. E.g., 0.5
+	Blue float64 `protobuf:"fixed64,3,opt,name=blue,proto3" json:"blue,omitempty"`
+}
+
+func (x *Color) Reset() {
+	*x = Color{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Color) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Color) ProtoMessage() {}
+
+func (x *Color) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Color.ProtoReflect.Descriptor instead.
+func (*Color) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Color) GetRed() float64 {
+	if x != nil {
+		return x.Red
+	}
+	return 0
+}
+
+func (x *Color) GetGreen() float64 {
+	if x != nil {
+		return x.Green
+	}
+	return 0
+}
+
+func (x *Color) GetBlue() float64 {
+	if x != nil {
+		return x.Blue
+	}
+	return 0
+}
+
+type SubmitTranslationJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The batch to translate. At most 200 images are allowed,
+	// and the request as a whole must fit in the 20MB message size limit.
+	//
+	// Types that are assignable to Request:
+	//	*SubmitTranslationJobRequest_ToImage
+	//	*SubmitTranslationJobRequest_ToMarkdown
+	Request isSubmitTranslationJobRequest_Request `protobuf_oneof:"request"`
+}
+
+func (x *SubmitTranslationJobRequest) Reset() {
+	*x = SubmitTranslationJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitTranslationJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitTranslationJobRequest) ProtoMessage() {}
+
+func (x *SubmitTranslationJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitTranslationJobRequest.ProtoReflect.Descriptor instead.
+func (*SubmitTranslationJobRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{28}
+}
+
+func (m *SubmitTranslationJobRequest) GetRequest() isSubmitTranslationJobRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *SubmitTranslationJobRequest) GetToImage() *TranslateToImageBatchRequest {
+	if x, ok := x.GetRequest().(*SubmitTranslationJobRequest_ToImage); ok {
+		return x.ToImage
+	}
+	return nil
+}
+
+func (x *SubmitTranslationJobRequest) GetToMarkdown() *TranslateToMarkdownBatchRequest {
+	if x, ok := x.GetRequest().(*SubmitTranslationJobRequest_ToMarkdown); ok {
+		return x.ToMarkdown
+	}
+	return nil
+}
+
+type isSubmitTranslationJobRequest_Request interface {
+	isSubmitTranslationJobRequest_Request()
+}
+
+type SubmitTranslationJobRequest_ToImage struct {
+	ToImage *TranslateToImageBatchRequest `protobuf:"bytes,1,opt,name=to_image,json=toImage,proto3,oneof"`
+}
+
+type SubmitTranslationJobRequest_ToMarkdown struct {
+	ToMarkdown *TranslateToMarkdownBatchRequest `protobuf:"bytes,2,opt,name=to_markdown,json=toMarkdown,proto3,oneof"`
+}
+
+func (*SubmitTranslationJobRequest_ToImage) isSubmitTranslationJobRequest_Request() {}
+
+func (*SubmitTranslationJobRequest_ToMarkdown) isSubmitTranslationJobRequest_Request() {}
+
+type GetTranslationJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// E.g., "3f2b9c0e8d7a6b5c4f3e2d1c0b9a8f7e"
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The maximum number of images whose responses are returned. Defaults to 5, and at most 20 are allowed. E.g., 10
+	// Fewer are returned when the responses would exceed 3MB, but always at least one.
+	ResultPageSize int32 `protobuf:"varint,2,opt,name=result_page_size,json=resultPageSize,proto3" json:"result_page_size,omitempty"`
+	// The next_result_page_token of the previous response. Empty for the first page.
+	ResultPageToken string `protobuf:"bytes,3,opt,name=result_page_token,json=resultPageToken,proto3" json:"result_page_token,omitempty"`
+}
+
+func (x *GetTranslationJobRequest) Reset() {
+	*x = GetTranslationJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTranslationJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTranslationJobRequest) ProtoMessage() {}
+
+func (x *GetTranslationJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTranslationJobRequest.ProtoReflect.Descriptor instead.
+func (*GetTranslationJobRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetTranslationJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetTranslationJobRequest) GetResultPageSize() int32 {
+	if x != nil {
+		return x.ResultPageSize
+	}
+	return 0
+}
+
+func (x *GetTranslationJobRequest) GetResultPageToken() string {
+	if x != nil {
+		return x.ResultPageToken
+	}
+	return ""
+}
+
+type ListTranslationJobsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The maximum number of jobs to return. Defaults to 50, and at most 200 are allowed. E.g., 20
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token of the previous response. Empty for the first page.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListTranslationJobsRequest) Reset() {
+	*x = ListTranslationJobsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTranslationJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTranslationJobsRequest) ProtoMessage() {}
+
+func (x *ListTranslationJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTranslationJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListTranslationJobsRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListTranslationJobsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTranslationJobsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListTranslationJobsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The jobs without their results, from newest to oldest.
+	Jobs []*TranslationJob `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	// Empty when there are no more jobs.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListTranslationJobsResponse) Reset() {
+	*x = ListTranslationJobsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTranslationJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTranslationJobsResponse) ProtoMessage() {}
+
+func (x *ListTranslationJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTranslationJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListTranslationJobsResponse) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListTranslationJobsResponse) GetJobs() []*TranslationJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *ListTranslationJobsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CancelTranslationJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// E.g., "3f2b9c0e8d7a6b5c4f3e2d1c0b9a8f7e"
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CancelTranslationJobRequest) Reset() {
+	*x = CancelTranslationJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelTranslationJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTranslationJobRequest) ProtoMessage() {}
+
+func (x *CancelTranslationJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTranslationJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelTranslationJobRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CancelTranslationJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type TranslationJob struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// E.g., "3f2b9c0e8d7a6b5c4f3e2d1c0b9a8f7e"
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The current state of the job.
+	State JobState `protobuf:"varint,2,opt,name=state,proto3,enum=visionex.grpc.JobState" json:"state,omitempty"`
+	// The number of images in the job. E.g., 40
+	TotalImageCount int32 `protobuf:"varint,3,opt,name=total_image_count,json=totalImageCount,proto3" json:"total_image_count,omitempty"`
+	// The number of images whose translation has finished, successfully or not. E.g., 12
+	CompletedImageCount int32 `protobuf:"varint,4,opt,name=completed_image_count,json=completedImageCount,proto3" json:"completed_image_count,omitempty"`
+	// Milliseconds since the Unix epoch. E.g., 1718000000000
+	CreateTimeMilliseconds int64 `protobuf:"varint,5,opt,name=create_time_milliseconds,json=createTimeMilliseconds,proto3" json:"create_time_milliseconds,omitempty"`
+	// Milliseconds since the Unix epoch. E.g., 1718000042000
+	UpdateTimeMilliseconds int64 `protobuf:"varint,6,opt,name=update_time_milliseconds,json=updateTimeMilliseconds,proto3" json:"update_time_milliseconds,omitempty"`
+	// Why the whole job failed. Only set for JOB_STATE_FAILED.
+	// Failures of single images are reported in the result instead.
+	Error *BatchStatus `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	// One entry per image, in the same order as the request images.
+	// Entries of images that have not finished yet have no status.
+	// Responses are only filled in by GetTranslationJob for the images of the requested page,
+	// and CancelTranslationJob returns the statuses alone.
+	// Left out by ListTranslationJobs.
+	//
+	// Types that are assignable to Result:
+	//	*TranslationJob_ToImageResult
+	//	*TranslationJob_ToMarkdownResult
+	Result isTranslationJob_Result `protobuf_oneof:"result"`
+	// The email of the user who submitted the job. E.g., "user@yanolja.com"
+	OwnerEmail string `protobuf:"bytes,10,opt,name=owner_email,json=ownerEmail,proto3" json:"owner_email,omitempty"`
+	// Pass as result_page_token to GetTranslationJob to get the responses of the following images.
+	// Empty when the page ends at the last image. Only set by GetTranslationJob.
+	NextResultPageToken string `protobuf:"bytes,11,opt,name=next_result_page_token,json=nextResultPageToken,proto3" json:"next_result_page_token,omitempty"`
+}
+
+func (x *TranslationJob) Reset() {
+	*x = TranslationJob{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TranslationJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranslationJob) ProtoMessage() {}
+
+func (x *TranslationJob) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranslationJob.ProtoReflect.Descriptor instead.
+func (*TranslationJob) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TranslationJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TranslationJob) GetState() JobState {
+	if x != nil {
+		return x.State
+	}
+	return JobState_JOB_STATE_UNSPECIFIED
+}
+
+func (x *TranslationJob) GetTotalImageCount() int32 {
+	if x != nil {
+		return x.TotalImageCount
+	}
+	return 0
+}
+
+func (x *TranslationJob) GetCompletedImageCount() int32 {
+	if x != nil {
+		return x.CompletedImageCount
+	}
+	return 0
+}
+
+func (x *TranslationJob) GetCreateTimeMilliseconds() int64 {
+	if x != nil {
+		return x.CreateTimeMilliseconds
+	}
+	return 0
+}
+
+func (x *TranslationJob) GetUpdateTimeMilliseconds() int64 {
+	if x != nil {
+		return x.UpdateTimeMilliseconds
+	}
+	return 0
+}
+
+func (x *TranslationJob) GetError() *BatchStatus {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (m *TranslationJob) GetResult() isTranslationJob_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *TranslationJob) GetToImageResult() *TranslateToImageBatchResponse {
+	if x, ok := x.GetResult().(*TranslationJob_ToImageResult); ok {
+		return x.ToImageResult
+	}
+	return nil
+}
+
+func (x *TranslationJob) GetToMarkdownResult() *TranslateToMarkdownBatchResponse {
+	if x, ok := x.GetResult().(*TranslationJob_ToMarkdownResult); ok {
+		return x.ToMarkdownResult
+	}
+	return nil
+}
+
+func (x *TranslationJob) GetOwnerEmail() string {
+	if x != nil {
+		return x.OwnerEmail
+	}
+	return ""
+}
+
+func (x *TranslationJob) GetNextResultPageToken() string {
+	if x != nil {
+		return x.NextResultPageToken
+	}
+	return ""
+}
+
+type isTranslationJob_Result interface {
+	isTranslationJob_Result()
+}
+
+type TranslationJob_ToImageResult struct {
+	ToImageResult *TranslateToImageBatchResponse `protobuf:"bytes,8,opt,name=to_image_result,json=toImageResult,proto3,oneof"`
+}
+
+type TranslationJob_ToMarkdownResult struct {
+	ToMarkdownResult *TranslateToMarkdownBatchResponse `protobuf:"bytes,9,opt,name=to_markdown_result,json=toMarkdownResult,proto3,oneof"`
+}
+
+func (*TranslationJob_ToImageResult) isTranslationJob_Result() {}
+
+func (*TranslationJob_ToMarkdownResult) isTranslationJob_Result() {}
+
+type UploadFontRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the family. Letters, digits, spaces, hyphens and underscores are allowed,
+	// up to 64 characters. E.g., "Acme Sans"
+	Family string `protobuf:"bytes,1,opt,name=family,proto3" json:"family,omitempty"`
+	// The weight of the font.
+	Weight FontWeight `protobuf:"varint,2,opt,name=weight,proto3,enum=visionex.grpc.FontWeight" json:"weight,omitempty"`
+	// The content of a TrueType (.ttf) or OpenType (.otf) font file.
+	Content []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *UploadFontRequest) Reset() {
+	*x = UploadFontRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadFontRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFontRequest) ProtoMessage() {}
+
+func (x *UploadFontRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadFontRequest.ProtoReflect.Descriptor instead.
+func (*UploadFontRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UploadFontRequest) GetFamily() string {
+	if x != nil {
+		return x.Family
+	}
+	return ""
+}
+
+func (x *UploadFontRequest) GetWeight() FontWeight {
+	if x != nil {
+		return x.Weight
+	}
+	return FontWeight_FONT_WEIGHT_UNSPECIFIED
+}
+
+func (x *UploadFontRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type ListFontsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListFontsRequest) Reset() {
+	*x = ListFontsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListFontsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFontsRequest) ProtoMessage() {}
+
+func (x *ListFontsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFontsRequest.ProtoReflect.Descriptor instead.
+func (*ListFontsRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{35}
+}
+
+type ListFontsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Sorted by the family name.
+	Families []*FontFamily `protobuf:"bytes,1,rep,name=families,proto3" json:"families,omitempty"`
+}
+
+func (x *ListFontsResponse) Reset() {
+	*x = ListFontsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListFontsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFontsResponse) ProtoMessage() {}
+
+func (x *ListFontsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFontsResponse.ProtoReflect.Descriptor instead.
+func (*ListFontsResponse) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListFontsResponse) GetFamilies() []*FontFamily {
+	if x != nil {
+		return x.Families
+	}
+	return nil
+}
+
+type DeleteFontRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// E.g., "Acme Sans"
+	Family string `protobuf:"bytes,1,opt,name=family,proto3" json:"family,omitempty"`
+}
+
+func (x *DeleteFontRequest) Reset() {
+	*x = DeleteFontRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteFontRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFontRequest) ProtoMessage() {}
+
+func (x *DeleteFontRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFontRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFontRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DeleteFontRequest) GetFamily() string {
+	if x != nil {
+		return x.Family
+	}
+	return ""
+}
+
+type DeleteFontResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteFontResponse) Reset() {
+	*x = DeleteFontResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteFontResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFontResponse) ProtoMessage() {}
+
+func (x *DeleteFontResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFontResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFontResponse) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{38}
+}
+
+type FontFamily struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// E.g., "Acme Sans"
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The uploaded weights, from the lightest.
+	// Text of a weight the family lacks is drawn with the nearest uploaded weight.
+	Weights []FontWeight `protobuf:"varint,2,rep,packed,name=weights,proto3,enum=visionex.grpc.FontWeight" json:"weights,omitempty"`
+}
+
+func (x *FontFamily) Reset() {
+	*x = FontFamily{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FontFamily) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FontFamily) ProtoMessage() {}
+
+func (x *FontFamily) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FontFamily.ProtoReflect.Descriptor instead.
+func (*FontFamily) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *FontFamily) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FontFamily) GetWeights() []FontWeight {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+type SignInRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Google OpenID token of the user. E.g., "abcdef123ghijk"
+	GoogleOpenIdToken string `protobuf:"bytes,1,opt,name=google_open_id_token,json=googleOpenIdToken,proto3" json:"google_open_id_token,omitempty"`
+}
+
+func (x *SignInRequest) Reset() {
+	*x = SignInRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignInRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignInRequest) ProtoMessage() {}
+
+func (x *SignInRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignInRequest.ProtoReflect.Descriptor instead.
+func (*SignInRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SignInRequest) GetGoogleOpenIdToken() string {
+	if x != nil {
+		return x.GoogleOpenIdToken
+	}
+	return ""
+}
+
+type SignInResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A Bearer token that must be set in the "Authorization" header for every follow-up request. E.g., "Bearer abc123"
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *SignInResponse) Reset() {
+	*x = SignInResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_grpc_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignInResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignInResponse) ProtoMessage() {}
+
+func (x *SignInResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_grpc_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignInResponse.ProtoReflect.Descriptor instead.
 func (*SignInResponse) Descriptor() ([]byte, []int) {
-	return file_grpc_grpc_proto_rawDescGZIP(), []int{8}
+	return file_grpc_grpc_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *SignInResponse) GetToken() string {
@@ -626,84 +3300,533 @@ var File_grpc_grpc_proto protoreflect.FileDescriptor
 var file_grpc_grpc_proto_rawDesc = []byte{
 	0x0a, 0x0f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x12, 0x0d, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63,
-	0x22, 0x77, 0x0a, 0x1d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x65, 0x78,
-	0x74, 0x46, 0x72, 0x6f, 0x6d, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x40, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63,
-	0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x74, 0x0a, 0x1e, 0x54, 0x72, 0x61,
-	0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x65, 0x78, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x49, 0x6d,
-	0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x75,
-	0x72, 0x69, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x75, 0x72, 0x69, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x35, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x74,
-	0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x69,
-	0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x6e, 0x74,
-	0x65, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x73, 0x65, 0x6e, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22,
-	0x47, 0x0a, 0x08, 0x53, 0x65, 0x6e, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74,
-	0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12,
-	0x27, 0x0a, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x65,
-	0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6c,
-	0x61, 0x74, 0x65, 0x64, 0x54, 0x65, 0x78, 0x74, 0x22, 0xa6, 0x01, 0x0a, 0x1a, 0x54, 0x72, 0x61,
-	0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x4d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e,
+	0x22, 0xb6, 0x02, 0x0a, 0x1d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x65,
+	0x78, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x40, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x46, 0x0a, 0x0f, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69,
+	0x6e, 0x67, 0x52, 0x0e, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69,
+	0x6e, 0x67, 0x12, 0x40, 0x0a, 0x0f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x67, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65,
+	0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x52,
+	0x08, 0x67, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x22, 0xa6, 0x02, 0x0a, 0x1e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x65, 0x78, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x75, 0x72, 0x69, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x75, 0x72, 0x69, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x35, 0x0a, 0x09, 0x73, 0x65, 0x6e,
+	0x74, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x6e,
+	0x74, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x73, 0x65, 0x6e, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x69, 0x6d, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x40, 0x0a,
+	0x0f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65,
+	0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x52,
+	0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12,
+	0x51, 0x0a, 0x13, 0x67, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x5f, 0x76, 0x69, 0x6f, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x6c, 0x6f,
+	0x73, 0x73, 0x61, 0x72, 0x79, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x12,
+	0x67, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x22, 0x47, 0x0a, 0x08, 0x53, 0x65, 0x6e, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65,
+	0x78, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x54, 0x65, 0x78, 0x74, 0x22, 0x9d, 0x02, 0x0a, 0x1a,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x4d, 0x61, 0x72, 0x6b, 0x64,
+	0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x0f, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x05,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x64, 0x65,
+	0x6c, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x40,
+	0x0a, 0x0f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x52, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x12, 0x33, 0x0a, 0x08, 0x67, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x47, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x52, 0x08, 0x67, 0x6c, 0x6f,
+	0x73, 0x73, 0x61, 0x72, 0x79, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x22, 0x81, 0x03, 0x0a, 0x17,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65,
 	0x74, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
 	0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63,
 	0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x05, 0x6d, 0x6f, 0x64,
-	0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f,
-	0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x05,
-	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x4a, 0x04, 0x08, 0x01, 0x10,
-	0x02, 0x22, 0x77, 0x0a, 0x17, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f,
-	0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x0f,
-	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78,
-	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x52, 0x0e,
-	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69,
-	0x6d, 0x61, 0x67, 0x65, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x22, 0x39, 0x0a, 0x1b, 0x54, 0x72,
-	0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x4d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x72,
-	0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x61, 0x72,
-	0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x22, 0x37, 0x0a, 0x18, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61,
-	0x74, 0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x72, 0x69, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x72, 0x69, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x22, 0x40,
-	0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x2f, 0x0a, 0x14, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x5f, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x69,
-	0x64, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x64, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x22, 0x26, 0x0a, 0x0e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2a, 0x60, 0x0a, 0x08, 0x4c, 0x61, 0x6e, 0x67,
-	0x75, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x14, 0x4c, 0x41, 0x4e, 0x47, 0x55, 0x41, 0x47, 0x45,
-	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12,
-	0x0a, 0x0e, 0x4c, 0x41, 0x4e, 0x47, 0x55, 0x41, 0x47, 0x45, 0x5f, 0x45, 0x4e, 0x5f, 0x55, 0x53,
-	0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x4c, 0x41, 0x4e, 0x47, 0x55, 0x41, 0x47, 0x45, 0x5f, 0x4b,
-	0x4f, 0x5f, 0x4b, 0x52, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x4c, 0x41, 0x4e, 0x47, 0x55, 0x41,
-	0x47, 0x45, 0x5f, 0x4a, 0x41, 0x5f, 0x4a, 0x50, 0x10, 0x03, 0x2a, 0x5d, 0x0a, 0x05, 0x4d, 0x6f,
-	0x64, 0x65, 0x6c, 0x12, 0x15, 0x0a, 0x11, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x53,
-	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x4f,
-	0x44, 0x45, 0x4c, 0x5f, 0x47, 0x50, 0x54, 0x34, 0x4f, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x4d,
-	0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x47, 0x50, 0x54, 0x34, 0x4f, 0x5f, 0x4d, 0x49, 0x4e, 0x49, 0x10,
-	0x02, 0x12, 0x16, 0x0a, 0x12, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x47, 0x45, 0x4d, 0x49, 0x4e,
-	0x49, 0x5f, 0x46, 0x4c, 0x41, 0x53, 0x48, 0x10, 0x03, 0x32, 0xa3, 0x03, 0x0a, 0x08, 0x56, 0x69,
-	0x73, 0x69, 0x6f, 0x6e, 0x45, 0x78, 0x12, 0x65, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c,
-	0x61, 0x74, 0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x26, 0x2e, 0x76, 0x69, 0x73,
-	0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x27, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72,
+	0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61,
+	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12,
+	0x46, 0x0a, 0x0f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69,
+	0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x45,
+	0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x0e, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x45,
+	0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x40, 0x0a, 0x0f, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63,
+	0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x67, 0x6c, 0x6f,
+	0x73, 0x73, 0x61, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x6c, 0x6f, 0x73,
+	0x73, 0x61, 0x72, 0x79, 0x52, 0x08, 0x67, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x12, 0x1f,
+	0x0a, 0x0b, 0x66, 0x6f, 0x6e, 0x74, 0x5f, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x6f, 0x6e, 0x74, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x12,
+	0x28, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x6c, 0x69, 0x63, 0x65, 0x5f, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x53, 0x6c,
+	0x69, 0x63, 0x65, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x22,
+	0xf5, 0x01, 0x0a, 0x1b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x4d,
+	0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x6d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x6d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x61, 0x67, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77,
+	0x6e, 0x73, 0x12, 0x40, 0x0a, 0x0f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x67, 0x65, 0x12, 0x51, 0x0a, 0x13, 0x67, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79,
+	0x5f, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x2e, 0x47, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x12, 0x67, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x56, 0x69, 0x6f,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xc4, 0x02, 0x0a, 0x18, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x72, 0x69, 0x5f, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x72, 0x69, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x12, 0x26, 0x0a, 0x0f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x75, 0x72, 0x69, 0x5f, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x61, 0x67, 0x65,
+	0x55, 0x72, 0x69, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6d,
+	0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x69,
+	0x6d, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x40, 0x0a, 0x0f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e,
+	0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x51, 0x0a, 0x13, 0x67, 0x6c, 0x6f, 0x73,
+	0x73, 0x61, 0x72, 0x79, 0x5f, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x56, 0x69,
+	0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x67, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72,
+	0x79, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x73,
+	0x6c, 0x69, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x52, 0x06, 0x73, 0x6c, 0x69, 0x63, 0x65, 0x73, 0x22, 0x72,
+	0x0a, 0x0a, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x75, 0x72, 0x69, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x75, 0x72, 0x69, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70,
+	0x61, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x6f, 0x70, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x74, 0x6f, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x22, 0x67, 0x0a, 0x08, 0x47, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x12, 0x31,
+	0x0a, 0x05, 0x74, 0x65, 0x72, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x6c,
+	0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x52, 0x05, 0x74, 0x65, 0x72, 0x6d,
+	0x73, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x6f, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x6f, 0x4e,
+	0x6f, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x22, 0x3e, 0x0a, 0x0c, 0x47,
+	0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0xc2, 0x01, 0x0a, 0x11,
+	0x47, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x65, 0x72, 0x6d,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x65,
+	0x72, 0x6d, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x74,
+	0x65, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x78, 0x70, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x54, 0x65, 0x72, 0x6d, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x54, 0x65, 0x78, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x54, 0x65, 0x78,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x22, 0x5f, 0x0a, 0x0e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69,
+	0x6e, 0x67, 0x12, 0x33, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52,
+	0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x71, 0x75, 0x61, 0x6c, 0x69,
+	0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74,
+	0x79, 0x22, 0x9c, 0x02, 0x0a, 0x18, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54,
+	0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2a,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74,
+	0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x31, 0x0a, 0x14, 0x65, 0x6c,
+	0x61, 0x70, 0x73, 0x65, 0x64, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65,
+	0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x34, 0x0a,
+	0x16, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x68, 0x75, 0x6e,
+	0x6b, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x14, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x3f, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x27, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x22, 0x82, 0x03, 0x0a, 0x1c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f,
+	0x49, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x40, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6c, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75,
+	0x61, 0x67, 0x65, 0x52, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75,
+	0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x06, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x0f, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x45, 0x6e, 0x63, 0x6f, 0x64,
+	0x69, 0x6e, 0x67, 0x52, 0x0e, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x45, 0x6e, 0x63, 0x6f, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x40, 0x0a, 0x0f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x67, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72,
+	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79,
+	0x52, 0x08, 0x67, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x6f,
+	0x6e, 0x74, 0x5f, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x66, 0x6f, 0x6e, 0x74, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x12, 0x28, 0x0a, 0x10, 0x6d,
+	0x61, 0x78, 0x5f, 0x73, 0x6c, 0x69, 0x63, 0x65, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x48,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x65, 0x0a, 0x1d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61,
+	0x74, 0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74,
+	0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x96, 0x01, 0x0a,
+	0x1b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x32, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x43, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72,
 	0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x49, 0x6d,
-	0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6e, 0x0a,
-	0x13, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x4d, 0x61, 0x72, 0x6b,
-	0x64, 0x6f, 0x77, 0x6e, 0x12, 0x29, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e,
-	0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f,
-	0x4d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x2a, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x9e, 0x02, 0x0a, 0x1f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c,
+	0x61, 0x74, 0x65, 0x54, 0x6f, 0x4d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x0f, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x05, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x6c,
+	0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12,
+	0x40, 0x0a, 0x0f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61,
+	0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
+	0x65, 0x52, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
+	0x65, 0x12, 0x33, 0x0a, 0x08, 0x67, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x2e, 0x47, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x52, 0x08, 0x67, 0x6c,
+	0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x22, 0x6b, 0x0a, 0x20, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c,
+	0x61, 0x74, 0x65, 0x54, 0x6f, 0x4d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x4d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x22, 0x9c, 0x01, 0x0a, 0x1e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74,
+	0x65, 0x54, 0x6f, 0x4d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x32, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65,
+	0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x46, 0x0a, 0x08, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x4d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x3b, 0x0a, 0x0b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0xaf, 0x01, 0x0a, 0x13, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x40, 0x0a,
+	0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65,
+	0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x52,
+	0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12,
+	0x40, 0x0a, 0x0f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61,
+	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
+	0x65, 0x52, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
+	0x65, 0x22, 0x89, 0x01, 0x0a, 0x14, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x4c, 0x61, 0x79, 0x6f,
+	0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x70, 0x61,
+	0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74,
+	0x50, 0x61, 0x67, 0x65, 0x52, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x12, 0x40, 0x0a, 0x0f, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x7a, 0x0a,
+	0x0a, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x50, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x77,
+	0x69, 0x64, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74,
+	0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x3e, 0x0a, 0x0a, 0x70, 0x61, 0x72,
+	0x61, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61,
+	0x79, 0x6f, 0x75, 0x74, 0x50, 0x61, 0x72, 0x61, 0x67, 0x72, 0x61, 0x70, 0x68, 0x52, 0x0a, 0x70,
+	0x61, 0x72, 0x61, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x22, 0x81, 0x01, 0x0a, 0x0f, 0x4c, 0x61,
+	0x79, 0x6f, 0x75, 0x74, 0x50, 0x61, 0x72, 0x61, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x3d, 0x0a,
+	0x0c, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x62, 0x6f, 0x78, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x2e, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x52,
+	0x0b, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x12, 0x2f, 0x0a, 0x05,
+	0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x79, 0x6f,
+	0x75, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x22, 0x7c, 0x0a,
+	0x0a, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x3d, 0x0a, 0x0c, 0x62,
+	0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x62, 0x6f, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x2e, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x52, 0x0b, 0x62,
+	0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x12, 0x2f, 0x0a, 0x05, 0x77, 0x6f,
+	0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74,
+	0x57, 0x6f, 0x72, 0x64, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x22, 0xd2, 0x01, 0x0a, 0x0a,
+	0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65,
+	0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x3d,
+	0x0a, 0x0c, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x62, 0x6f, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78,
+	0x52, 0x0b, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x12, 0x1b, 0x0a,
+	0x09, 0x66, 0x6f, 0x6e, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x08, 0x66, 0x6f, 0x6e, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x33, 0x0a, 0x0a, 0x74, 0x65,
+	0x78, 0x74, 0x5f, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x09, 0x74, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12,
+	0x1f, 0x0a, 0x0b, 0x66, 0x6f, 0x6e, 0x74, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x66, 0x6f, 0x6e, 0x74, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x22, 0x61, 0x0a, 0x0b, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78, 0x12,
+	0x10, 0x0a, 0x03, 0x74, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x74, 0x6f,
+	0x70, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x6c, 0x65, 0x66, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x6f, 0x74, 0x74, 0x6f, 0x6d, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x6f, 0x74, 0x74, 0x6f, 0x6d, 0x12, 0x14, 0x0a,
+	0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x69,
+	0x67, 0x68, 0x74, 0x22, 0x43, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03,
+	0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x72, 0x65, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x67, 0x72, 0x65, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x67,
+	0x72, 0x65, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x04, 0x62, 0x6c, 0x75, 0x65, 0x22, 0xc5, 0x01, 0x0a, 0x1b, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x6f,
+	0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x48, 0x0a, 0x08, 0x74, 0x6f, 0x5f, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x07, 0x74, 0x6f, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x12, 0x51, 0x0a, 0x0b, 0x74, 0x6f, 0x5f, 0x6d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74,
+	0x65, 0x54, 0x6f, 0x4d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x74, 0x6f, 0x4d, 0x61, 0x72,
+	0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x80, 0x01, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x28, 0x0a,
+	0x10, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50,
+	0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x22, 0x58, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x78, 0x0a,
+	0x1b, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x04,
+	0x6a, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x12,
+	0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x2d, 0x0a, 0x1b, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x6f, 0x62, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xee, 0x04, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x6f, 0x62, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x13, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x38, 0x0a, 0x18, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x16, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x16, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65,
+	0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x30, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x56,
+	0x0a, 0x0f, 0x74, 0x6f, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74,
+	0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x74, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x5f, 0x0a, 0x12, 0x74, 0x6f, 0x5f, 0x6d, 0x61, 0x72,
+	0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x4d, 0x61,
+	0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x10, 0x74, 0x6f, 0x4d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77,
+	0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x33, 0x0a, 0x16, 0x6e, 0x65, 0x78, 0x74,
+	0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x08, 0x0a,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x78, 0x0a, 0x11, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x46, 0x6f, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x61,
+	0x6d, 0x69, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x6f, 0x6e, 0x74, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52,
+	0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x22, 0x12, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6f, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4a, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6f, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x66, 0x61,
+	0x6d, 0x69, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x6f, 0x6e,
+	0x74, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x52, 0x08, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x69, 0x65,
+	0x73, 0x22, 0x2b, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x6f, 0x6e, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x22, 0x14,
+	0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x6f, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x55, 0x0a, 0x0a, 0x46, 0x6f, 0x6e, 0x74, 0x46, 0x61, 0x6d, 0x69,
+	0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x6f, 0x6e, 0x74, 0x57, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x52, 0x07, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x22, 0x40, 0x0a, 0x0d, 0x53,
+	0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x14,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x5f, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x69, 0x64, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x64, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x26, 0x0a,
+	0x0e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2a, 0xac, 0x01, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12,
+	0x15, 0x0a, 0x11, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f,
+	0x44, 0x45, 0x54, 0x45, 0x43, 0x54, 0x5f, 0x44, 0x4f, 0x43, 0x55, 0x4d, 0x45, 0x4e, 0x54, 0x10,
+	0x01, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x47, 0x52, 0x4f, 0x55, 0x50,
+	0x45, 0x44, 0x5f, 0x4c, 0x49, 0x4e, 0x45, 0x53, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x54,
+	0x41, 0x47, 0x45, 0x5f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x4c, 0x41, 0x54, 0x45, 0x10, 0x03, 0x12,
+	0x1d, 0x0a, 0x19, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x49, 0x4d, 0x41, 0x47, 0x45, 0x5f, 0x57,
+	0x49, 0x54, 0x48, 0x4f, 0x55, 0x54, 0x5f, 0x54, 0x45, 0x58, 0x54, 0x53, 0x10, 0x04, 0x12, 0x14,
+	0x0a, 0x10, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x44, 0x52, 0x41, 0x57, 0x5f, 0x54, 0x45, 0x58,
+	0x54, 0x53, 0x10, 0x05, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x44, 0x4f,
+	0x4e, 0x45, 0x10, 0x06, 0x2a, 0x9a, 0x01, 0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x19, 0x0a, 0x15, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10,
+	0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x51, 0x55, 0x45, 0x55, 0x45, 0x44,
+	0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f,
+	0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x4a, 0x4f, 0x42,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x53, 0x55, 0x43, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44,
+	0x10, 0x03, 0x12, 0x14, 0x0a, 0x10, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f,
+	0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x12, 0x17, 0x0a, 0x13, 0x4a, 0x4f, 0x42, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44, 0x10,
+	0x05, 0x2a, 0xca, 0x01, 0x0a, 0x0c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x46, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x12, 0x1d, 0x0a, 0x19, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x46, 0x4f, 0x52,
+	0x4d, 0x41, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x15, 0x0a, 0x11, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d,
+	0x41, 0x54, 0x5f, 0x50, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x4f, 0x55, 0x54, 0x50,
+	0x55, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x4a, 0x50, 0x45, 0x47, 0x10, 0x02,
+	0x12, 0x1f, 0x0a, 0x1b, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41,
+	0x54, 0x5f, 0x57, 0x45, 0x42, 0x50, 0x5f, 0x4c, 0x4f, 0x53, 0x53, 0x4c, 0x45, 0x53, 0x53, 0x10,
+	0x03, 0x12, 0x1c, 0x0a, 0x18, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d,
+	0x41, 0x54, 0x5f, 0x57, 0x45, 0x42, 0x50, 0x5f, 0x4c, 0x4f, 0x53, 0x53, 0x59, 0x10, 0x04, 0x12,
+	0x15, 0x0a, 0x11, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54,
+	0x5f, 0x53, 0x56, 0x47, 0x10, 0x05, 0x12, 0x16, 0x0a, 0x12, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54,
+	0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x48, 0x54, 0x4d, 0x4c, 0x10, 0x06, 0x2a, 0x80,
+	0x02, 0x0a, 0x08, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x14, 0x4c,
+	0x41, 0x4e, 0x47, 0x55, 0x41, 0x47, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x4c, 0x41, 0x4e, 0x47, 0x55, 0x41, 0x47,
+	0x45, 0x5f, 0x45, 0x4e, 0x5f, 0x55, 0x53, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x4c, 0x41, 0x4e,
+	0x47, 0x55, 0x41, 0x47, 0x45, 0x5f, 0x4b, 0x4f, 0x5f, 0x4b, 0x52, 0x10, 0x02, 0x12, 0x12, 0x0a,
+	0x0e, 0x4c, 0x41, 0x4e, 0x47, 0x55, 0x41, 0x47, 0x45, 0x5f, 0x4a, 0x41, 0x5f, 0x4a, 0x50, 0x10,
+	0x03, 0x12, 0x12, 0x0a, 0x0e, 0x4c, 0x41, 0x4e, 0x47, 0x55, 0x41, 0x47, 0x45, 0x5f, 0x5a, 0x48,
+	0x5f, 0x43, 0x4e, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x4c, 0x41, 0x4e, 0x47, 0x55, 0x41, 0x47,
+	0x45, 0x5f, 0x5a, 0x48, 0x5f, 0x54, 0x57, 0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x4c, 0x41, 0x4e,
+	0x47, 0x55, 0x41, 0x47, 0x45, 0x5f, 0x54, 0x48, 0x5f, 0x54, 0x48, 0x10, 0x06, 0x12, 0x12, 0x0a,
+	0x0e, 0x4c, 0x41, 0x4e, 0x47, 0x55, 0x41, 0x47, 0x45, 0x5f, 0x56, 0x49, 0x5f, 0x56, 0x4e, 0x10,
+	0x07, 0x12, 0x12, 0x0a, 0x0e, 0x4c, 0x41, 0x4e, 0x47, 0x55, 0x41, 0x47, 0x45, 0x5f, 0x49, 0x44,
+	0x5f, 0x49, 0x44, 0x10, 0x08, 0x12, 0x12, 0x0a, 0x0e, 0x4c, 0x41, 0x4e, 0x47, 0x55, 0x41, 0x47,
+	0x45, 0x5f, 0x45, 0x53, 0x5f, 0x45, 0x53, 0x10, 0x09, 0x12, 0x12, 0x0a, 0x0e, 0x4c, 0x41, 0x4e,
+	0x47, 0x55, 0x41, 0x47, 0x45, 0x5f, 0x46, 0x52, 0x5f, 0x46, 0x52, 0x10, 0x0a, 0x12, 0x12, 0x0a,
+	0x0e, 0x4c, 0x41, 0x4e, 0x47, 0x55, 0x41, 0x47, 0x45, 0x5f, 0x44, 0x45, 0x5f, 0x44, 0x45, 0x10,
+	0x0b, 0x2a, 0x5d, 0x0a, 0x05, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x15, 0x0a, 0x11, 0x4d, 0x4f,
+	0x44, 0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x47, 0x50, 0x54, 0x34, 0x4f,
+	0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x47, 0x50, 0x54, 0x34,
+	0x4f, 0x5f, 0x4d, 0x49, 0x4e, 0x49, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x4d, 0x4f, 0x44, 0x45,
+	0x4c, 0x5f, 0x47, 0x45, 0x4d, 0x49, 0x4e, 0x49, 0x5f, 0x46, 0x4c, 0x41, 0x53, 0x48, 0x10, 0x03,
+	0x2a, 0x73, 0x0a, 0x0a, 0x46, 0x6f, 0x6e, 0x74, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1b,
+	0x0a, 0x17, 0x46, 0x4f, 0x4e, 0x54, 0x5f, 0x57, 0x45, 0x49, 0x47, 0x48, 0x54, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x46,
+	0x4f, 0x4e, 0x54, 0x5f, 0x57, 0x45, 0x49, 0x47, 0x48, 0x54, 0x5f, 0x52, 0x45, 0x47, 0x55, 0x4c,
+	0x41, 0x52, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x46, 0x4f, 0x4e, 0x54, 0x5f, 0x57, 0x45, 0x49,
+	0x47, 0x48, 0x54, 0x5f, 0x53, 0x45, 0x4d, 0x49, 0x5f, 0x42, 0x4f, 0x4c, 0x44, 0x10, 0x02, 0x12,
+	0x14, 0x0a, 0x10, 0x46, 0x4f, 0x4e, 0x54, 0x5f, 0x57, 0x45, 0x49, 0x47, 0x48, 0x54, 0x5f, 0x42,
+	0x4f, 0x4c, 0x44, 0x10, 0x03, 0x32, 0xef, 0x0b, 0x0a, 0x08, 0x56, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x45, 0x78, 0x12, 0x65, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54,
+	0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x26, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65,
+	0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65,
+	0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27,
+	0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6d, 0x0a, 0x16, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x12, 0x26, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x22, 0x00, 0x30, 0x01, 0x12, 0x6e, 0x0a, 0x13, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x4d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12,
+	0x29, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e,
 	0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x4d, 0x61, 0x72, 0x6b, 0x64,
-	0x6f, 0x77, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x77, 0x0a,
+	0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x4d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x74, 0x0a, 0x15, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x2b, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c,
+	0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x7d,
+	0x0a, 0x18, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x4d, 0x61, 0x72,
+	0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x2e, 0x2e, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x4d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x6c, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x4d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x77, 0x0a,
 	0x16, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54, 0x65, 0x78, 0x74, 0x46, 0x72,
 	0x6f, 0x6d, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x2c, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
 	0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74,
@@ -711,15 +3834,61 @@ var file_grpc_grpc_proto_rawDesc = []byte{
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78,
 	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x54,
 	0x65, 0x78, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e,
-	0x12, 0x1c, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63,
-	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
-	0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53,
-	0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42,
-	0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x76, 0x69,
-	0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2d, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f, 0x76,
-	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x0c, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74,
+	0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x22, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65,
+	0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x4c, 0x61, 0x79,
+	0x6f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x63,
+	0x74, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x63, 0x0a, 0x14, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x6f, 0x62, 0x12, 0x2a, 0x2e, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x4a, 0x6f, 0x62, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x6f, 0x62, 0x12, 0x27, 0x2e, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4a, 0x6f, 0x62, 0x22, 0x00, 0x12, 0x6e, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x29, 0x2e, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x6f, 0x62, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x14, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x6f, 0x62, 0x12, 0x2a, 0x2e,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x6f, 0x62, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0a, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x46, 0x6f, 0x6e, 0x74, 0x12, 0x20, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46,
+	0x6f, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x6f, 0x6e, 0x74, 0x46,
+	0x61, 0x6d, 0x69, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x46,
+	0x6f, 0x6e, 0x74, 0x73, 0x12, 0x1f, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2e,
+	0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6f, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6f, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0a, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x46, 0x6f, 0x6e, 0x74, 0x12, 0x20, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x6f,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x46, 0x6f, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47,
+	0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x12, 0x1c, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x65, 0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65,
+	0x78, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2d, 0x70,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x78, 0x2f,
+	0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -734,40 +3903,153 @@ func file_grpc_grpc_proto_rawDescGZIP() []byte {
 	return file_grpc_grpc_proto_rawDescData
 }
 
-var file_grpc_grpc_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_grpc_grpc_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_grpc_grpc_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_grpc_grpc_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_grpc_grpc_proto_goTypes = []any{
-	(Language)(0),                          // 0: visionex.grpc.Language
-	(Model)(0),                             // 1: visionex.grpc.Model
-	(*TranslateTextFromImageRequest)(nil),  // 2: visionex.grpc.TranslateTextFromImageRequest
-	(*TranslateTextFromImageResponse)(nil), // 3: visionex.grpc.TranslateTextFromImageResponse
-	(*Sentence)(nil),                       // 4: visionex.grpc.Sentence
-	(*TranslateToMarkdownRequest)(nil),     // 5: visionex.grpc.TranslateToMarkdownRequest
-	(*TranslateToImageRequest)(nil),        // 6: visionex.grpc.TranslateToImageRequest
-	(*TranslateToMarkdownResponse)(nil),    // 7: visionex.grpc.TranslateToMarkdownResponse
-	(*TranslateToImageResponse)(nil),       // 8: visionex.grpc.TranslateToImageResponse
-	(*SignInRequest)(nil),                  // 9: visionex.grpc.SignInRequest
-	(*SignInResponse)(nil),                 // 10: visionex.grpc.SignInResponse
+	(Stage)(0),                               // 0: visionex.grpc.Stage
+	(JobState)(0),                            // 1: visionex.grpc.JobState
+	(OutputFormat)(0),                        // 2: visionex.grpc.OutputFormat
+	(Language)(0),                            // 3: visionex.grpc.Language
+	(Model)(0),                               // 4: visionex.grpc.Model
+	(FontWeight)(0),                          // 5: visionex.grpc.FontWeight
+	(*TranslateTextFromImageRequest)(nil),    // 6: visionex.grpc.TranslateTextFromImageRequest
+	(*TranslateTextFromImageResponse)(nil),   // 7: visionex.grpc.TranslateTextFromImageResponse
+	(*Sentence)(nil),                         // 8: visionex.grpc.Sentence
+	(*TranslateToMarkdownRequest)(nil),       // 9: visionex.grpc.TranslateToMarkdownRequest
+	(*TranslateToImageRequest)(nil),          // 10: visionex.grpc.TranslateToImageRequest
+	(*TranslateToMarkdownResponse)(nil),      // 11: visionex.grpc.TranslateToMarkdownResponse
+	(*TranslateToImageResponse)(nil),         // 12: visionex.grpc.TranslateToImageResponse
+	(*ImageSlice)(nil),                       // 13: visionex.grpc.ImageSlice
+	(*Glossary)(nil),                         // 14: visionex.grpc.Glossary
+	(*GlossaryTerm)(nil),                     // 15: visionex.grpc.GlossaryTerm
+	(*GlossaryViolation)(nil),                // 16: visionex.grpc.GlossaryViolation
+	(*OutputEncoding)(nil),                   // 17: visionex.grpc.OutputEncoding
+	(*TranslateToImageProgress)(nil),         // 18: visionex.grpc.TranslateToImageProgress
+	(*TranslateToImageBatchRequest)(nil),     // 19: visionex.grpc.TranslateToImageBatchRequest
+	(*TranslateToImageBatchResponse)(nil),    // 20: visionex.grpc.TranslateToImageBatchResponse
+	(*TranslateToImageBatchResult)(nil),      // 21: visionex.grpc.TranslateToImageBatchResult
+	(*TranslateToMarkdownBatchRequest)(nil),  // 22: visionex.grpc.TranslateToMarkdownBatchRequest
+	(*TranslateToMarkdownBatchResponse)(nil), // 23: visionex.grpc.TranslateToMarkdownBatchResponse
+	(*TranslateToMarkdownBatchResult)(nil),   // 24: visionex.grpc.TranslateToMarkdownBatchResult
+	(*BatchStatus)(nil),                      // 25: visionex.grpc.BatchStatus
+	(*DetectLayoutRequest)(nil),              // 26: visionex.grpc.DetectLayoutRequest
+	(*DetectLayoutResponse)(nil),             // 27: visionex.grpc.DetectLayoutResponse
+	(*LayoutPage)(nil),                       // 28: visionex.grpc.LayoutPage
+	(*LayoutParagraph)(nil),                  // 29: visionex.grpc.LayoutParagraph
+	(*LayoutLine)(nil),                       // 30: visionex.grpc.LayoutLine
+	(*LayoutWord)(nil),                       // 31: visionex.grpc.LayoutWord
+	(*BoundingBox)(nil),                      // 32: visionex.grpc.BoundingBox
+	(*Color)(nil),                            // 33: visionex.grpc.Color
+	(*SubmitTranslationJobRequest)(nil),      // 34: visionex.grpc.SubmitTranslationJobRequest
+	(*GetTranslationJobRequest)(nil),         // 35: visionex.grpc.GetTranslationJobRequest
+	(*ListTranslationJobsRequest)(nil),       // 36: visionex.grpc.ListTranslationJobsRequest
+	(*ListTranslationJobsResponse)(nil),      // 37: visionex.grpc.ListTranslationJobsResponse
+	(*CancelTranslationJobRequest)(nil),      // 38: visionex.grpc.CancelTranslationJobRequest
+	(*TranslationJob)(nil),                   // 39: visionex.grpc.TranslationJob
+	(*UploadFontRequest)(nil),                // 40: visionex.grpc.UploadFontRequest
+	(*ListFontsRequest)(nil),                 // 41: visionex.grpc.ListFontsRequest
+	(*ListFontsResponse)(nil),                // 42: visionex.grpc.ListFontsResponse
+	(*DeleteFontRequest)(nil),                // 43: visionex.grpc.DeleteFontRequest
+	(*DeleteFontResponse)(nil),               // 44: visionex.grpc.DeleteFontResponse
+	(*FontFamily)(nil),                       // 45: visionex.grpc.FontFamily
+	(*SignInRequest)(nil),                    // 46: visionex.grpc.SignInRequest
+	(*SignInResponse)(nil),                   // 47: visionex.grpc.SignInResponse
 }
 var file_grpc_grpc_proto_depIdxs = []int32{
-	0,  // 0: visionex.grpc.TranslateTextFromImageRequest.target_language:type_name -> visionex.grpc.Language
-	4,  // 1: visionex.grpc.TranslateTextFromImageResponse.sentences:type_name -> visionex.grpc.Sentence
-	0,  // 2: visionex.grpc.TranslateToMarkdownRequest.target_language:type_name -> visionex.grpc.Language
-	1,  // 3: visionex.grpc.TranslateToMarkdownRequest.model:type_name -> visionex.grpc.Model
-	0,  // 4: visionex.grpc.TranslateToImageRequest.target_language:type_name -> visionex.grpc.Language
-	6,  // 5: visionex.grpc.VisionEx.TranslateToImage:input_type -> visionex.grpc.TranslateToImageRequest
-	5,  // 6: visionex.grpc.VisionEx.TranslateToMarkdown:input_type -> visionex.grpc.TranslateToMarkdownRequest
-	2,  // 7: visionex.grpc.VisionEx.TranslateTextFromImage:input_type -> visionex.grpc.TranslateTextFromImageRequest
-	9,  // 8: visionex.grpc.VisionEx.SignIn:input_type -> visionex.grpc.SignInRequest
-	8,  // 9: visionex.grpc.VisionEx.TranslateToImage:output_type -> visionex.grpc.TranslateToImageResponse
-	7,  // 10: visionex.grpc.VisionEx.TranslateToMarkdown:output_type -> visionex.grpc.TranslateToMarkdownResponse
-	3,  // 11: visionex.grpc.VisionEx.TranslateTextFromImage:output_type -> visionex.grpc.TranslateTextFromImageResponse
-	10, // 12: visionex.grpc.VisionEx.SignIn:output_type -> visionex.grpc.SignInResponse
-	9,  // [9:13] is the sub-list for method output_type
-	5,  // [5:9] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	3,  // 0: visionex.grpc.TranslateTextFromImageRequest.target_language:type_name -> visionex.grpc.Language
+	17, // 1: visionex.grpc.TranslateTextFromImageRequest.output_encoding:type_name -> visionex.grpc.OutputEncoding
+	3,  // 2: visionex.grpc.TranslateTextFromImageRequest.source_language:type_name -> visionex.grpc.Language
+	14, // 3: visionex.grpc.TranslateTextFromImageRequest.glossary:type_name -> visionex.grpc.Glossary
+	8,  // 4: visionex.grpc.TranslateTextFromImageResponse.sentences:type_name -> visionex.grpc.Sentence
+	3,  // 5: visionex.grpc.TranslateTextFromImageResponse.source_language:type_name -> visionex.grpc.Language
+	16, // 6: visionex.grpc.TranslateTextFromImageResponse.glossary_violations:type_name -> visionex.grpc.GlossaryViolation
+	3,  // 7: visionex.grpc.TranslateToMarkdownRequest.target_language:type_name -> visionex.grpc.Language
+	4,  // 8: visionex.grpc.TranslateToMarkdownRequest.model:type_name -> visionex.grpc.Model
+	3,  // 9: visionex.grpc.TranslateToMarkdownRequest.source_language:type_name -> visionex.grpc.Language
+	14, // 10: visionex.grpc.TranslateToMarkdownRequest.glossary:type_name -> visionex.grpc.Glossary
+	3,  // 11: visionex.grpc.TranslateToImageRequest.target_language:type_name -> visionex.grpc.Language
+	17, // 12: visionex.grpc.TranslateToImageRequest.output_encoding:type_name -> visionex.grpc.OutputEncoding
+	3,  // 13: visionex.grpc.TranslateToImageRequest.source_language:type_name -> visionex.grpc.Language
+	14, // 14: visionex.grpc.TranslateToImageRequest.glossary:type_name -> visionex.grpc.Glossary
+	3,  // 15: visionex.grpc.TranslateToMarkdownResponse.source_language:type_name -> visionex.grpc.Language
+	16, // 16: visionex.grpc.TranslateToMarkdownResponse.glossary_violations:type_name -> visionex.grpc.GlossaryViolation
+	3,  // 17: visionex.grpc.TranslateToImageResponse.source_language:type_name -> visionex.grpc.Language
+	16, // 18: visionex.grpc.TranslateToImageResponse.glossary_violations:type_name -> visionex.grpc.GlossaryViolation
+	13, // 19: visionex.grpc.TranslateToImageResponse.slices:type_name -> visionex.grpc.ImageSlice
+	15, // 20: visionex.grpc.Glossary.terms:type_name -> visionex.grpc.GlossaryTerm
+	2,  // 21: visionex.grpc.OutputEncoding.format:type_name -> visionex.grpc.OutputFormat
+	0,  // 22: visionex.grpc.TranslateToImageProgress.stage:type_name -> visionex.grpc.Stage
+	12, // 23: visionex.grpc.TranslateToImageProgress.result:type_name -> visionex.grpc.TranslateToImageResponse
+	3,  // 24: visionex.grpc.TranslateToImageBatchRequest.target_language:type_name -> visionex.grpc.Language
+	17, // 25: visionex.grpc.TranslateToImageBatchRequest.output_encoding:type_name -> visionex.grpc.OutputEncoding
+	3,  // 26: visionex.grpc.TranslateToImageBatchRequest.source_language:type_name -> visionex.grpc.Language
+	14, // 27: visionex.grpc.TranslateToImageBatchRequest.glossary:type_name -> visionex.grpc.Glossary
+	21, // 28: visionex.grpc.TranslateToImageBatchResponse.results:type_name -> visionex.grpc.TranslateToImageBatchResult
+	25, // 29: visionex.grpc.TranslateToImageBatchResult.status:type_name -> visionex.grpc.BatchStatus
+	12, // 30: visionex.grpc.TranslateToImageBatchResult.response:type_name -> visionex.grpc.TranslateToImageResponse
+	3,  // 31: visionex.grpc.TranslateToMarkdownBatchRequest.target_language:type_name -> visionex.grpc.Language
+	4,  // 32: visionex.grpc.TranslateToMarkdownBatchRequest.model:type_name -> visionex.grpc.Model
+	3,  // 33: visionex.grpc.TranslateToMarkdownBatchRequest.source_language:type_name -> visionex.grpc.Language
+	14, // 34: visionex.grpc.TranslateToMarkdownBatchRequest.glossary:type_name -> visionex.grpc.Glossary
+	24, // 35: visionex.grpc.TranslateToMarkdownBatchResponse.results:type_name -> visionex.grpc.TranslateToMarkdownBatchResult
+	25, // 36: visionex.grpc.TranslateToMarkdownBatchResult.status:type_name -> visionex.grpc.BatchStatus
+	11, // 37: visionex.grpc.TranslateToMarkdownBatchResult.response:type_name -> visionex.grpc.TranslateToMarkdownResponse
+	3,  // 38: visionex.grpc.DetectLayoutRequest.target_language:type_name -> visionex.grpc.Language
+	3,  // 39: visionex.grpc.DetectLayoutRequest.source_language:type_name -> visionex.grpc.Language
+	28, // 40: visionex.grpc.DetectLayoutResponse.pages:type_name -> visionex.grpc.LayoutPage
+	3,  // 41: visionex.grpc.DetectLayoutResponse.source_language:type_name -> visionex.grpc.Language
+	29, // 42: visionex.grpc.LayoutPage.paragraphs:type_name -> visionex.grpc.LayoutParagraph
+	32, // 43: visionex.grpc.LayoutParagraph.bounding_box:type_name -> visionex.grpc.BoundingBox
+	30, // 44: visionex.grpc.LayoutParagraph.lines:type_name -> visionex.grpc.LayoutLine
+	32, // 45: visionex.grpc.LayoutLine.bounding_box:type_name -> visionex.grpc.BoundingBox
+	31, // 46: visionex.grpc.LayoutLine.words:type_name -> visionex.grpc.LayoutWord
+	32, // 47: visionex.grpc.LayoutWord.bounding_box:type_name -> visionex.grpc.BoundingBox
+	33, // 48: visionex.grpc.LayoutWord.text_color:type_name -> visionex.grpc.Color
+	19, // 49: visionex.grpc.SubmitTranslationJobRequest.to_image:type_name -> visionex.grpc.TranslateToImageBatchRequest
+	22, // 50: visionex.grpc.SubmitTranslationJobRequest.to_markdown:type_name -> visionex.grpc.TranslateToMarkdownBatchRequest
+	39, // 51: visionex.grpc.ListTranslationJobsResponse.jobs:type_name -> visionex.grpc.TranslationJob
+	1,  // 52: visionex.grpc.TranslationJob.state:type_name -> visionex.grpc.JobState
+	25, // 53: visionex.grpc.TranslationJob.error:type_name -> visionex.grpc.BatchStatus
+	20, // 54: visionex.grpc.TranslationJob.to_image_result:type_name -> visionex.grpc.TranslateToImageBatchResponse
+	23, // 55: visionex.grpc.TranslationJob.to_markdown_result:type_name -> visionex.grpc.TranslateToMarkdownBatchResponse
+	5,  // 56: visionex.grpc.UploadFontRequest.weight:type_name -> visionex.grpc.FontWeight
+	45, // 57: visionex.grpc.ListFontsResponse.families:type_name -> visionex.grpc.FontFamily
+	5,  // 58: visionex.grpc.FontFamily.weights:type_name -> visionex.grpc.FontWeight
+	10, // 59: visionex.grpc.VisionEx.TranslateToImage:input_type -> visionex.grpc.TranslateToImageRequest
+	10, // 60: visionex.grpc.VisionEx.TranslateToImageStream:input_type -> visionex.grpc.TranslateToImageRequest
+	9,  // 61: visionex.grpc.VisionEx.TranslateToMarkdown:input_type -> visionex.grpc.TranslateToMarkdownRequest
+	19, // 62: visionex.grpc.VisionEx.TranslateToImageBatch:input_type -> visionex.grpc.TranslateToImageBatchRequest
+	22, // 63: visionex.grpc.VisionEx.TranslateToMarkdownBatch:input_type -> visionex.grpc.TranslateToMarkdownBatchRequest
+	6,  // 64: visionex.grpc.VisionEx.TranslateTextFromImage:input_type -> visionex.grpc.TranslateTextFromImageRequest
+	26, // 65: visionex.grpc.VisionEx.DetectLayout:input_type -> visionex.grpc.DetectLayoutRequest
+	34, // 66: visionex.grpc.VisionEx.SubmitTranslationJob:input_type -> visionex.grpc.SubmitTranslationJobRequest
+	35, // 67: visionex.grpc.VisionEx.GetTranslationJob:input_type -> visionex.grpc.GetTranslationJobRequest
+	36, // 68: visionex.grpc.VisionEx.ListTranslationJobs:input_type -> visionex.grpc.ListTranslationJobsRequest
+	38, // 69: visionex.grpc.VisionEx.CancelTranslationJob:input_type -> visionex.grpc.CancelTranslationJobRequest
+	40, // 70: visionex.grpc.VisionEx.UploadFont:input_type -> visionex.grpc.UploadFontRequest
+	41, // 71: visionex.grpc.VisionEx.ListFonts:input_type -> visionex.grpc.ListFontsRequest
+	43, // 72: visionex.grpc.VisionEx.DeleteFont:input_type -> visionex.grpc.DeleteFontRequest
+	46, // 73: visionex.grpc.VisionEx.SignIn:input_type -> visionex.grpc.SignInRequest
+	12, // 74: visionex.grpc.VisionEx.TranslateToImage:output_type -> visionex.grpc.TranslateToImageResponse
+	18, // 75: visionex.grpc.VisionEx.TranslateToImageStream:output_type -> visionex.grpc.TranslateToImageProgress
+	11, // 76: visionex.grpc.VisionEx.TranslateToMarkdown:output_type -> visionex.grpc.TranslateToMarkdownResponse
+	20, // 77: visionex.grpc.VisionEx.TranslateToImageBatch:output_type -> visionex.grpc.TranslateToImageBatchResponse
+	23, // 78: visionex.grpc.VisionEx.TranslateToMarkdownBatch:output_type -> visionex.grpc.TranslateToMarkdownBatchResponse
+	7,  // 79: visionex.grpc.VisionEx.TranslateTextFromImage:output_type -> visionex.grpc.TranslateTextFromImageResponse
+	27, // 80: visionex.grpc.VisionEx.DetectLayout:output_type -> visionex.grpc.DetectLayoutResponse
+	39, // 81: visionex.grpc.VisionEx.SubmitTranslationJob:output_type -> visionex.grpc.TranslationJob
+	39, // 82: visionex.grpc.VisionEx.GetTranslationJob:output_type -> visionex.grpc.TranslationJob
+	37, // 83: visionex.grpc.VisionEx.ListTranslationJobs:output_type -> visionex.grpc.ListTranslationJobsResponse
+	39, // 84: visionex.grpc.VisionEx.CancelTranslationJob:output_type -> visionex.grpc.TranslationJob
+	45, // 85: visionex.grpc.VisionEx.UploadFont:output_type -> visionex.grpc.FontFamily
+	42, // 86: visionex.grpc.VisionEx.ListFonts:output_type -> visionex.grpc.ListFontsResponse
+	44, // 87: visionex.grpc.VisionEx.DeleteFont:output_type -> visionex.grpc.DeleteFontResponse
+	47, // 88: visionex.grpc.VisionEx.SignIn:output_type -> visionex.grpc.SignInResponse
+	74, // [74:89] is the sub-list for method output_type
+	59, // [59:74] is the sub-list for method input_type
+	59, // [59:59] is the sub-list for extension type_name
+	59, // [59:59] is the sub-list for extension extendee
+	0,  // [0:59] is the sub-list for field type_name
 }
 
 func init() { file_grpc_grpc_proto_init() }
@@ -788,8 +4070,116 @@ func file_grpc_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_grpc_grpc_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*TranslateTextFromImageResponse); i {
+		file_grpc_grpc_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*TranslateTextFromImageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*Sentence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*TranslateToMarkdownRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*TranslateToImageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*TranslateToMarkdownResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*TranslateToImageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*ImageSlice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*Glossary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*GlossaryTerm); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*GlossaryViolation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -800,8 +4190,8 @@ func file_grpc_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_grpc_grpc_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*Sentence); i {
+		file_grpc_grpc_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*OutputEncoding); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -812,8 +4202,8 @@ func file_grpc_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_grpc_grpc_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*TranslateToMarkdownRequest); i {
+		file_grpc_grpc_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*TranslateToImageProgress); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -824,8 +4214,8 @@ func file_grpc_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_grpc_grpc_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*TranslateToImageRequest); i {
+		file_grpc_grpc_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*TranslateToImageBatchRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -836,8 +4226,8 @@ func file_grpc_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_grpc_grpc_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*TranslateToMarkdownResponse); i {
+		file_grpc_grpc_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*TranslateToImageBatchResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -848,8 +4238,8 @@ func file_grpc_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_grpc_grpc_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*TranslateToImageResponse); i {
+		file_grpc_grpc_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*TranslateToImageBatchResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -860,7 +4250,295 @@ func file_grpc_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_grpc_grpc_proto_msgTypes[7].Exporter = func(v any, i int) any {
+		file_grpc_grpc_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*TranslateToMarkdownBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*TranslateToMarkdownBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*TranslateToMarkdownBatchResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*BatchStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*DetectLayoutRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*DetectLayoutResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*LayoutPage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*LayoutParagraph); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*LayoutLine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*LayoutWord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*BoundingBox); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*Color); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*SubmitTranslationJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*GetTranslationJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*ListTranslationJobsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[31].Exporter = func(v any, i int) any {
+			switch v := v.(*ListTranslationJobsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[32].Exporter = func(v any, i int) any {
+			switch v := v.(*CancelTranslationJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[33].Exporter = func(v any, i int) any {
+			switch v := v.(*TranslationJob); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[34].Exporter = func(v any, i int) any {
+			switch v := v.(*UploadFontRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[35].Exporter = func(v any, i int) any {
+			switch v := v.(*ListFontsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[36].Exporter = func(v any, i int) any {
+			switch v := v.(*ListFontsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[37].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteFontRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[38].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteFontResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[39].Exporter = func(v any, i int) any {
+			switch v := v.(*FontFamily); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_grpc_proto_msgTypes[40].Exporter = func(v any, i int) any {
 			switch v := v.(*SignInRequest); i {
 			case 0:
 				return &v.state
@@ -872,7 +4550,7 @@ func file_grpc_grpc_proto_init() {
 				return nil
 			}
 		}
-		file_grpc_grpc_proto_msgTypes[8].Exporter = func(v any, i int) any {
+		file_grpc_grpc_proto_msgTypes[41].Exporter = func(v any, i int) any {
 			switch v := v.(*SignInResponse); i {
 			case 0:
 				return &v.state
@@ -885,13 +4563,21 @@ func file_grpc_grpc_proto_init() {
 			}
 		}
 	}
+	file_grpc_grpc_proto_msgTypes[28].OneofWrappers = []any{
+		(*SubmitTranslationJobRequest_ToImage)(nil),
+		(*SubmitTranslationJobRequest_ToMarkdown)(nil),
+	}
+	file_grpc_grpc_proto_msgTypes[33].OneofWrappers = []any{
+		(*TranslationJob_ToImageResult)(nil),
+		(*TranslationJob_ToMarkdownResult)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_grpc_grpc_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   9,
+			NumEnums:      6,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
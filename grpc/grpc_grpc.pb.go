@@ -19,27 +19,73 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	VisionEx_TranslateToImage_FullMethodName       = "/visionex.grpc.VisionEx/TranslateToImage"
-	VisionEx_TranslateToMarkdown_FullMethodName    = "/visionex.grpc.VisionEx/TranslateToMarkdown"
-	VisionEx_TranslateTextFromImage_FullMethodName = "/visionex.grpc.VisionEx/TranslateTextFromImage"
-	VisionEx_SignIn_FullMethodName                 = "/visionex.grpc.VisionEx/SignIn"
+	VisionEx_TranslateToImage_FullMethodName         = "/visionex.grpc.VisionEx/TranslateToImage"
+	VisionEx_TranslateToImageStream_FullMethodName   = "/visionex.grpc.VisionEx/TranslateToImageStream"
+	VisionEx_TranslateToMarkdown_FullMethodName      = "/visionex.grpc.VisionEx/TranslateToMarkdown"
+	VisionEx_TranslateToImageBatch_FullMethodName    = "/visionex.grpc.VisionEx/TranslateToImageBatch"
+	VisionEx_TranslateToMarkdownBatch_FullMethodName = "/visionex.grpc.VisionEx/TranslateToMarkdownBatch"
+	VisionEx_TranslateTextFromImage_FullMethodName   = "/visionex.grpc.VisionEx/TranslateTextFromImage"
+	VisionEx_DetectLayout_FullMethodName             = "/visionex.grpc.VisionEx/DetectLayout"
+	VisionEx_SubmitTranslationJob_FullMethodName     = "/visionex.grpc.VisionEx/SubmitTranslationJob"
+	VisionEx_GetTranslationJob_FullMethodName        = "/visionex.grpc.VisionEx/GetTranslationJob"
+	VisionEx_ListTranslationJobs_FullMethodName      = "/visionex.grpc.VisionEx/ListTranslationJobs"
+	VisionEx_CancelTranslationJob_FullMethodName     = "/visionex.grpc.VisionEx/CancelTranslationJob"
+	VisionEx_UploadFont_FullMethodName               = "/visionex.grpc.VisionEx/UploadFont"
+	VisionEx_ListFonts_FullMethodName                = "/visionex.grpc.VisionEx/ListFonts"
+	VisionEx_DeleteFont_FullMethodName               = "/visionex.grpc.VisionEx/DeleteFont"
+	VisionEx_SignIn_FullMethodName                   = "/visionex.grpc.VisionEx/SignIn"
 )
 
 // VisionExClient is the client API for VisionEx service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type VisionExClient interface {
-	// Translates an image into a PNG format.
-	// Currently only PNG, JPEG, WEBP and non-animated GIF are supported.
+	// Translates an image into the format of `output_encoding`, PNG by default.
+	// Currently only PNG, JPEG, WEBP, non-animated GIF, PDF and TIFF are supported.
 	// The image will be translated into the target language.
+	// PDF and multi-page TIFF inputs return one translated image per page.
 	TranslateToImage(ctx context.Context, in *TranslateToImageRequest, opts ...grpc.CallOption) (*TranslateToImageResponse, error)
+	// Same as TranslateToImage, but streams progress events while the pipeline runs.
+	// The last message carries the translated image in `result`.
+	TranslateToImageStream(ctx context.Context, in *TranslateToImageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TranslateToImageProgress], error)
 	// Translates an image into a Markdown format.
-	// Currently only PNG, JPEG, WEBP and non-animated GIF are supported.
+	// Currently only PNG, JPEG, WEBP, non-animated GIF, PDF and TIFF are supported.
 	// The image will be translated into the target language.
+	// PDF and multi-page TIFF inputs return one Markdown per page.
 	TranslateToMarkdown(ctx context.Context, in *TranslateToMarkdownRequest, opts ...grpc.CallOption) (*TranslateToMarkdownResponse, error)
+	// Translates several images into the format of `output_encoding`, PNG by default, with shared settings.
+	// Each image gets its own result and status, so one failed image does not fail the whole batch.
+	TranslateToImageBatch(ctx context.Context, in *TranslateToImageBatchRequest, opts ...grpc.CallOption) (*TranslateToImageBatchResponse, error)
+	// Translates several images into Markdown format with shared settings.
+	// Each image gets its own result and status, so one failed image does not fail the whole batch.
+	TranslateToMarkdownBatch(ctx context.Context, in *TranslateToMarkdownBatchRequest, opts ...grpc.CallOption) (*TranslateToMarkdownBatchResponse, error)
 	// Extracts text from image and translates it into the target language.
 	// The image shows which sentence were detected as square.
 	TranslateTextFromImage(ctx context.Context, in *TranslateTextFromImageRequest, opts ...grpc.CallOption) (*TranslateTextFromImageResponse, error)
+	// Detects texts in the image and returns them grouped into paragraphs, lines and words
+	// with their bounding boxes and styles, as used internally by TranslateToImage.
+	DetectLayout(ctx context.Context, in *DetectLayoutRequest, opts ...grpc.CallOption) (*DetectLayoutResponse, error)
+	// Queues a batch translation and returns right away, for batches that take longer than a request may stay open.
+	// Poll GetTranslationJob until the job reaches a final state to get the result.
+	// Jobs are only visible to the user who submitted them. Jobs of other users are reported as not found.
+	// Finished jobs are deleted along with their results after the retention of the server, 7 days by default.
+	SubmitTranslationJob(ctx context.Context, in *SubmitTranslationJobRequest, opts ...grpc.CallOption) (*TranslationJob, error)
+	// Returns the job, including the results of the images translated so far.
+	// The responses are returned a page at a time, as a whole job exceeds the message size limit of most clients.
+	GetTranslationJob(ctx context.Context, in *GetTranslationJobRequest, opts ...grpc.CallOption) (*TranslationJob, error)
+	// Returns the jobs from newest to oldest, without their results.
+	ListTranslationJobs(ctx context.Context, in *ListTranslationJobsRequest, opts ...grpc.CallOption) (*ListTranslationJobsResponse, error)
+	// Cancels a queued or running job. No more images are started after cancellation,
+	// and the results of the images finished so far are kept.
+	CancelTranslationJob(ctx context.Context, in *CancelTranslationJobRequest, opts ...grpc.CallOption) (*TranslationJob, error)
+	// Uploads a weight of a font family, such as the corporate typeface of a brand, for TranslateToImage to draw with.
+	// Replaces the weight when the family already has it. Both TrueType (.ttf) and OpenType (.otf) fonts are accepted.
+	// Only allowed for admins.
+	UploadFont(ctx context.Context, in *UploadFontRequest, opts ...grpc.CallOption) (*FontFamily, error)
+	// Returns the uploaded font families.
+	ListFonts(ctx context.Context, in *ListFontsRequest, opts ...grpc.CallOption) (*ListFontsResponse, error)
+	// Removes every weight of an uploaded font family. Only allowed for admins.
+	DeleteFont(ctx context.Context, in *DeleteFontRequest, opts ...grpc.CallOption) (*DeleteFontResponse, error)
 	SignIn(ctx context.Context, in *SignInRequest, opts ...grpc.CallOption) (*SignInResponse, error)
 }
 
@@ -61,6 +107,25 @@ func (c *visionExClient) TranslateToImage(ctx context.Context, in *TranslateToIm
 	return out, nil
 }
 
+func (c *visionExClient) TranslateToImageStream(ctx context.Context, in *TranslateToImageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TranslateToImageProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VisionEx_ServiceDesc.Streams[0], VisionEx_TranslateToImageStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TranslateToImageRequest, TranslateToImageProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VisionEx_TranslateToImageStreamClient = grpc.ServerStreamingClient[TranslateToImageProgress]
+
 func (c *visionExClient) TranslateToMarkdown(ctx context.Context, in *TranslateToMarkdownRequest, opts ...grpc.CallOption) (*TranslateToMarkdownResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TranslateToMarkdownResponse)
@@ -71,6 +136,26 @@ func (c *visionExClient) TranslateToMarkdown(ctx context.Context, in *TranslateT
 	return out, nil
 }
 
+func (c *visionExClient) TranslateToImageBatch(ctx context.Context, in *TranslateToImageBatchRequest, opts ...grpc.CallOption) (*TranslateToImageBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TranslateToImageBatchResponse)
+	err := c.cc.Invoke(ctx, VisionEx_TranslateToImageBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *visionExClient) TranslateToMarkdownBatch(ctx context.Context, in *TranslateToMarkdownBatchRequest, opts ...grpc.CallOption) (*TranslateToMarkdownBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TranslateToMarkdownBatchResponse)
+	err := c.cc.Invoke(ctx, VisionEx_TranslateToMarkdownBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *visionExClient) TranslateTextFromImage(ctx context.Context, in *TranslateTextFromImageRequest, opts ...grpc.CallOption) (*TranslateTextFromImageResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TranslateTextFromImageResponse)
@@ -81,6 +166,86 @@ func (c *visionExClient) TranslateTextFromImage(ctx context.Context, in *Transla
 	return out, nil
 }
 
+func (c *visionExClient) DetectLayout(ctx context.Context, in *DetectLayoutRequest, opts ...grpc.CallOption) (*DetectLayoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DetectLayoutResponse)
+	err := c.cc.Invoke(ctx, VisionEx_DetectLayout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *visionExClient) SubmitTranslationJob(ctx context.Context, in *SubmitTranslationJobRequest, opts ...grpc.CallOption) (*TranslationJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TranslationJob)
+	err := c.cc.Invoke(ctx, VisionEx_SubmitTranslationJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *visionExClient) GetTranslationJob(ctx context.Context, in *GetTranslationJobRequest, opts ...grpc.CallOption) (*TranslationJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TranslationJob)
+	err := c.cc.Invoke(ctx, VisionEx_GetTranslationJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *visionExClient) ListTranslationJobs(ctx context.Context, in *ListTranslationJobsRequest, opts ...grpc.CallOption) (*ListTranslationJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTranslationJobsResponse)
+	err := c.cc.Invoke(ctx, VisionEx_ListTranslationJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *visionExClient) CancelTranslationJob(ctx context.Context, in *CancelTranslationJobRequest, opts ...grpc.CallOption) (*TranslationJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TranslationJob)
+	err := c.cc.Invoke(ctx, VisionEx_CancelTranslationJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *visionExClient) UploadFont(ctx context.Context, in *UploadFontRequest, opts ...grpc.CallOption) (*FontFamily, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FontFamily)
+	err := c.cc.Invoke(ctx, VisionEx_UploadFont_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *visionExClient) ListFonts(ctx context.Context, in *ListFontsRequest, opts ...grpc.CallOption) (*ListFontsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFontsResponse)
+	err := c.cc.Invoke(ctx, VisionEx_ListFonts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *visionExClient) DeleteFont(ctx context.Context, in *DeleteFontRequest, opts ...grpc.CallOption) (*DeleteFontResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteFontResponse)
+	err := c.cc.Invoke(ctx, VisionEx_DeleteFont_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *visionExClient) SignIn(ctx context.Context, in *SignInRequest, opts ...grpc.CallOption) (*SignInResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SignInResponse)
@@ -95,17 +260,52 @@ func (c *visionExClient) SignIn(ctx context.Context, in *SignInRequest, opts ...
 // All implementations must embed UnimplementedVisionExServer
 // for forward compatibility.
 type VisionExServer interface {
-	// Translates an image into a PNG format.
-	// Currently only PNG, JPEG, WEBP and non-animated GIF are supported.
+	// Translates an image into the format of `output_encoding`, PNG by default.
+	// Currently only PNG, JPEG, WEBP, non-animated GIF, PDF and TIFF are supported.
 	// The image will be translated into the target language.
+	// PDF and multi-page TIFF inputs return one translated image per page.
 	TranslateToImage(context.Context, *TranslateToImageRequest) (*TranslateToImageResponse, error)
+	// Same as TranslateToImage, but streams progress events while the pipeline runs.
+	// The last message carries the translated image in `result`.
+	TranslateToImageStream(*TranslateToImageRequest, grpc.ServerStreamingServer[TranslateToImageProgress]) error
 	// Translates an image into a Markdown format.
-	// Currently only PNG, JPEG, WEBP and non-animated GIF are supported.
+	// Currently only PNG, JPEG, WEBP, non-animated GIF, PDF and TIFF are supported.
 	// The image will be translated into the target language.
+	// PDF and multi-page TIFF inputs return one Markdown per page.
 	TranslateToMarkdown(context.Context, *TranslateToMarkdownRequest) (*TranslateToMarkdownResponse, error)
+	// Translates several images into the format of `output_encoding`, PNG by default, with shared settings.
+	// Each image gets its own result and status, so one failed image does not fail the whole batch.
+	TranslateToImageBatch(context.Context, *TranslateToImageBatchRequest) (*TranslateToImageBatchResponse, error)
+	// Translates several images into Markdown format with shared settings.
+	// Each image gets its own result and status, so one failed image does not fail the whole batch.
+	TranslateToMarkdownBatch(context.Context, *TranslateToMarkdownBatchRequest) (*TranslateToMarkdownBatchResponse, error)
 	// Extracts text from image and translates it into the target language.
 	// The image shows which sentence were detected as square.
 	TranslateTextFromImage(context.Context, *TranslateTextFromImageRequest) (*TranslateTextFromImageResponse, error)
+	// Detects texts in the image and returns them grouped into paragraphs, lines and words
+	// with their bounding boxes and styles, as used internally by TranslateToImage.
+	DetectLayout(context.Context, *DetectLayoutRequest) (*DetectLayoutResponse, error)
+	// Queues a batch translation and returns right away, for batches that take longer than a request may stay open.
+	// Poll GetTranslationJob until the job reaches a final state to get the result.
+	// Jobs are only visible to the user who submitted them. Jobs of other users are reported as not found.
+	// Finished jobs are deleted along with their results after the retention of the server, 7 days by default.
+	SubmitTranslationJob(context.Context, *SubmitTranslationJobRequest) (*TranslationJob, error)
+	// Returns the job, including the results of the images translated so far.
+	// The responses are returned a page at a time, as a whole job exceeds the message size limit of most clients.
+	GetTranslationJob(context.Context, *GetTranslationJobRequest) (*TranslationJob, error)
+	// Returns the jobs from newest to oldest, without their results.
+	ListTranslationJobs(context.Context, *ListTranslationJobsRequest) (*ListTranslationJobsResponse, error)
+	// Cancels a queued or running job. No more images are started after cancellation,
+	// and the results of the images finished so far are kept.
+	CancelTranslationJob(context.Context, *CancelTranslationJobRequest) (*TranslationJob, error)
+	// Uploads a weight of a font family, such as the corporate typeface of a brand, for TranslateToImage to draw with.
+	// Replaces the weight when the family already has it. Both TrueType (.ttf) and OpenType (.otf) fonts are accepted.
+	// Only allowed for admins.
+	UploadFont(context.Context, *UploadFontRequest) (*FontFamily, error)
+	// Returns the uploaded font families.
+	ListFonts(context.Context, *ListFontsRequest) (*ListFontsResponse, error)
+	// Removes every weight of an uploaded font family. Only allowed for admins.
+	DeleteFont(context.Context, *DeleteFontRequest) (*DeleteFontResponse, error)
 	SignIn(context.Context, *SignInRequest) (*SignInResponse, error)
 	mustEmbedUnimplementedVisionExServer()
 }
@@ -120,12 +320,45 @@ type UnimplementedVisionExServer struct{}
 func (UnimplementedVisionExServer) TranslateToImage(context.Context, *TranslateToImageRequest) (*TranslateToImageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TranslateToImage not implemented")
 }
+func (UnimplementedVisionExServer) TranslateToImageStream(*TranslateToImageRequest, grpc.ServerStreamingServer[TranslateToImageProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method TranslateToImageStream not implemented")
+}
 func (UnimplementedVisionExServer) TranslateToMarkdown(context.Context, *TranslateToMarkdownRequest) (*TranslateToMarkdownResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TranslateToMarkdown not implemented")
 }
+func (UnimplementedVisionExServer) TranslateToImageBatch(context.Context, *TranslateToImageBatchRequest) (*TranslateToImageBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TranslateToImageBatch not implemented")
+}
+func (UnimplementedVisionExServer) TranslateToMarkdownBatch(context.Context, *TranslateToMarkdownBatchRequest) (*TranslateToMarkdownBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TranslateToMarkdownBatch not implemented")
+}
 func (UnimplementedVisionExServer) TranslateTextFromImage(context.Context, *TranslateTextFromImageRequest) (*TranslateTextFromImageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TranslateTextFromImage not implemented")
 }
+func (UnimplementedVisionExServer) DetectLayout(context.Context, *DetectLayoutRequest) (*DetectLayoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DetectLayout not implemented")
+}
+func (UnimplementedVisionExServer) SubmitTranslationJob(context.Context, *SubmitTranslationJobRequest) (*TranslationJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitTranslationJob not implemented")
+}
+func (UnimplementedVisionExServer) GetTranslationJob(context.Context, *GetTranslationJobRequest) (*TranslationJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTranslationJob not implemented")
+}
+func (UnimplementedVisionExServer) ListTranslationJobs(context.Context, *ListTranslationJobsRequest) (*ListTranslationJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTranslationJobs not implemented")
+}
+func (UnimplementedVisionExServer) CancelTranslationJob(context.Context, *CancelTranslationJobRequest) (*TranslationJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTranslationJob not implemented")
+}
+func (UnimplementedVisionExServer) UploadFont(context.Context, *UploadFontRequest) (*FontFamily, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadFont not implemented")
+}
+func (UnimplementedVisionExServer) ListFonts(context.Context, *ListFontsRequest) (*ListFontsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFonts not implemented")
+}
+func (UnimplementedVisionExServer) DeleteFont(context.Context, *DeleteFontRequest) (*DeleteFontResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFont not implemented")
+}
 func (UnimplementedVisionExServer) SignIn(context.Context, *SignInRequest) (*SignInResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignIn not implemented")
 }
@@ -168,6 +401,17 @@ func _VisionEx_TranslateToImage_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VisionEx_TranslateToImageStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TranslateToImageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VisionExServer).TranslateToImageStream(m, &grpc.GenericServerStream[TranslateToImageRequest, TranslateToImageProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VisionEx_TranslateToImageStreamServer = grpc.ServerStreamingServer[TranslateToImageProgress]
+
 func _VisionEx_TranslateToMarkdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TranslateToMarkdownRequest)
 	if err := dec(in); err != nil {
@@ -186,6 +430,42 @@ func _VisionEx_TranslateToMarkdown_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VisionEx_TranslateToImageBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TranslateToImageBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisionExServer).TranslateToImageBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisionEx_TranslateToImageBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisionExServer).TranslateToImageBatch(ctx, req.(*TranslateToImageBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VisionEx_TranslateToMarkdownBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TranslateToMarkdownBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisionExServer).TranslateToMarkdownBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisionEx_TranslateToMarkdownBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisionExServer).TranslateToMarkdownBatch(ctx, req.(*TranslateToMarkdownBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VisionEx_TranslateTextFromImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TranslateTextFromImageRequest)
 	if err := dec(in); err != nil {
@@ -204,6 +484,150 @@ func _VisionEx_TranslateTextFromImage_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VisionEx_DetectLayout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetectLayoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisionExServer).DetectLayout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisionEx_DetectLayout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisionExServer).DetectLayout(ctx, req.(*DetectLayoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VisionEx_SubmitTranslationJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitTranslationJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisionExServer).SubmitTranslationJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisionEx_SubmitTranslationJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisionExServer).SubmitTranslationJob(ctx, req.(*SubmitTranslationJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VisionEx_GetTranslationJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTranslationJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisionExServer).GetTranslationJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisionEx_GetTranslationJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisionExServer).GetTranslationJob(ctx, req.(*GetTranslationJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VisionEx_ListTranslationJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTranslationJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisionExServer).ListTranslationJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisionEx_ListTranslationJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisionExServer).ListTranslationJobs(ctx, req.(*ListTranslationJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VisionEx_CancelTranslationJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTranslationJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisionExServer).CancelTranslationJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisionEx_CancelTranslationJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisionExServer).CancelTranslationJob(ctx, req.(*CancelTranslationJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VisionEx_UploadFont_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadFontRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisionExServer).UploadFont(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisionEx_UploadFont_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisionExServer).UploadFont(ctx, req.(*UploadFontRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VisionEx_ListFonts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFontsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisionExServer).ListFonts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisionEx_ListFonts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisionExServer).ListFonts(ctx, req.(*ListFontsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VisionEx_DeleteFont_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFontRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisionExServer).DeleteFont(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisionEx_DeleteFont_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisionExServer).DeleteFont(ctx, req.(*DeleteFontRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VisionEx_SignIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SignInRequest)
 	if err := dec(in); err != nil {
@@ -237,15 +661,61 @@ var VisionEx_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TranslateToMarkdown",
 			Handler:    _VisionEx_TranslateToMarkdown_Handler,
 		},
+		{
+			MethodName: "TranslateToImageBatch",
+			Handler:    _VisionEx_TranslateToImageBatch_Handler,
+		},
+		{
+			MethodName: "TranslateToMarkdownBatch",
+			Handler:    _VisionEx_TranslateToMarkdownBatch_Handler,
+		},
 		{
 			MethodName: "TranslateTextFromImage",
 			Handler:    _VisionEx_TranslateTextFromImage_Handler,
 		},
+		{
+			MethodName: "DetectLayout",
+			Handler:    _VisionEx_DetectLayout_Handler,
+		},
+		{
+			MethodName: "SubmitTranslationJob",
+			Handler:    _VisionEx_SubmitTranslationJob_Handler,
+		},
+		{
+			MethodName: "GetTranslationJob",
+			Handler:    _VisionEx_GetTranslationJob_Handler,
+		},
+		{
+			MethodName: "ListTranslationJobs",
+			Handler:    _VisionEx_ListTranslationJobs_Handler,
+		},
+		{
+			MethodName: "CancelTranslationJob",
+			Handler:    _VisionEx_CancelTranslationJob_Handler,
+		},
+		{
+			MethodName: "UploadFont",
+			Handler:    _VisionEx_UploadFont_Handler,
+		},
+		{
+			MethodName: "ListFonts",
+			Handler:    _VisionEx_ListFonts_Handler,
+		},
+		{
+			MethodName: "DeleteFont",
+			Handler:    _VisionEx_DeleteFont_Handler,
+		},
 		{
 			MethodName: "SignIn",
 			Handler:    _VisionEx_SignIn_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TranslateToImageStream",
+			Handler:       _VisionEx_TranslateToImageStream_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "grpc/grpc.proto",
 }
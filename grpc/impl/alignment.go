@@ -0,0 +1,191 @@
+package impl
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/fogleman/gg"
+
+	"github.com/visionex-project/visionex/grpc/impl/font"
+	"github.com/visionex-project/visionex/pkg/utils"
+)
+
+const (
+	// Rows whose edges or centers are within this fraction of the row height are considered aligned.
+	ALIGNMENT_TOLERANCE_RATIO = 0.5
+	// Two rows that span the same width may just be left aligned, so more rows are needed to tell justified text.
+	MIN_JUSTIFIED_ROW_COUNT = 3
+	// Justified rows are left aligned instead when the gaps between words would exceed this many times the font size,
+	// as such gaps look broken rather than justified. E.g., a row with only two short words.
+	MAX_JUSTIFIED_GAP_RATIO = 2.0
+)
+
+// Sets the alignment of every line, detected from its rows within the paragraph it belongs to,
+// so that a centered headline over left aligned text stays centered.
+// Lines whose rows fit several alignments, such as rows spanning the whole paragraph, or none of them,
+// follow the alignment of the paragraph instead.
+// Single-row paragraphs are compared with the area covered by all the texts of the page instead,
+// so that a headline centered over the rest of the page stays centered.
+func detectAlignments(paragraphs []paragraphSegment) []paragraphSegment {
+	textArea := combinedPosition(utils.FlatMap(paragraphs, paragraphPositions))
+	return utils.Map(paragraphs, func(paragraph paragraphSegment) paragraphSegment {
+		rows := combinedLinePositions(paragraphPositions(paragraph))
+		paragraphAlignment := detectAlignment(rows, textArea)
+		box, tolerance := alignmentBox(rows, textArea)
+		return paragraphSegment{lines: utils.Map(paragraph.lines, func(line lineSegment) lineSegment {
+			lineRows := combinedLinePositions(utils.Map(line.words, func(word wordSegment) position {
+				return word.position
+			}))
+			line.alignment = paragraphAlignment
+			if alignments := matchingAlignments(lineRows, box, tolerance); len(alignments) == 1 {
+				line.alignment = alignments[0]
+			}
+			return line
+		})}
+	})
+}
+
+func paragraphPositions(paragraph paragraphSegment) []position {
+	return utils.FlatMap(paragraph.lines, func(line lineSegment) []position {
+		return utils.Map(line.words, func(word wordSegment) position {
+			return word.position
+		})
+	})
+}
+
+// Returns the alignment that all the rows share. Rows that are aligned in several ways, such as rows of the same width,
+// are considered left aligned, as it is the most common alignment.
+func detectAlignment(rows []position, textArea position) textAlignment {
+	if len(rows) == 0 {
+		return ALIGNMENT_LEFT
+	}
+	box, tolerance := alignmentBox(rows, textArea)
+	alignments := matchingAlignments(rows, box, tolerance)
+	switch {
+	case len(rows) >= MIN_JUSTIFIED_ROW_COUNT && utils.Contains(alignments, ALIGNMENT_LEFT) &&
+		utils.Contains(matchingAlignments(rows[:len(rows)-1], box, tolerance), ALIGNMENT_RIGHT):
+		return ALIGNMENT_JUSTIFIED
+	case len(alignments) == 0:
+		return ALIGNMENT_LEFT
+	default:
+		return alignments[0]
+	}
+}
+
+// Returns the box that the rows are aligned within, which is the text area for a single row,
+// and how far in pixels the rows may be off from it while still being aligned.
+func alignmentBox(rows []position, textArea position) (position, float64) {
+	totalHeight := 0.0
+	for _, row := range rows {
+		totalHeight += float64(row.bottom - row.top)
+	}
+	tolerance := ALIGNMENT_TOLERANCE_RATIO * totalHeight / float64(max(1, len(rows)))
+	if len(rows) > 1 {
+		return combinedPosition(rows), tolerance
+	}
+	return textArea, tolerance
+}
+
+// Returns the alignments that every row follows within the box, in the order of left, center and right.
+func matchingAlignments(rows []position, box position, tolerance float64) []textAlignment {
+	if len(rows) == 0 {
+		return []textAlignment{}
+	}
+	isAligned := func(offset func(row position) float64) bool {
+		return !utils.Some(rows, func(row position) bool {
+			return math.Abs(offset(row)) > tolerance
+		})
+	}
+	alignments := []textAlignment{}
+	if isAligned(func(row position) float64 { return float64(row.left - box.left) }) {
+		alignments = append(alignments, ALIGNMENT_LEFT)
+	}
+	if isAligned(func(row position) float64 { return float64(row.left+row.right-box.left-box.right) / 2 }) {
+		alignments = append(alignments, ALIGNMENT_CENTER)
+	}
+	if isAligned(func(row position) float64 { return float64(row.right - box.right) }) {
+		alignments = append(alignments, ALIGNMENT_RIGHT)
+	}
+	return alignments
+}
+
+// Moves the words of a row, which are laid out from the left edge of the row, to follow the alignment.
+// The last row of justified text is left aligned.
+func alignRow(drawingContext *gg.Context, targetLanguageFonts *font.FontsByFace, words []wordSegment, row position, alignment textAlignment, isLastRow bool) []wordSegment {
+	if len(words) == 0 {
+		return words
+	}
+	if alignment == ALIGNMENT_JUSTIFIED && !isLastRow {
+		return justifyRow(drawingContext, targetLanguageFonts, words, row)
+	}
+
+	// Trailing spaces are not visible, so they are not counted in the width of the row.
+	lastWord := words[len(words)-1]
+	usedWidth := float64(lastWord.position.left-row.left) +
+		measureText(drawingContext, targetLanguageFonts, lastWord, strings.TrimRightFunc(lastWord.text, unicode.IsSpace))
+	emptyWidth := float64(row.right-row.left) - usedWidth
+	offset := 0.0
+	switch alignment {
+	case ALIGNMENT_CENTER:
+		offset = emptyWidth / 2
+	case ALIGNMENT_RIGHT:
+		offset = emptyWidth
+	}
+	if offset <= 0 {
+		return words
+	}
+
+	return utils.Map(words, func(word wordSegment) wordSegment {
+		word.position.left += int32(offset)
+		word.position.right += int32(offset)
+		return word
+	})
+}
+
+// Spreads the words of a row over its whole width, with the same gap between every word.
+// Rows without spaces, such as Japanese and Chinese text, are spread between characters instead.
+func justifyRow(drawingContext *gg.Context, targetLanguageFonts *font.FontsByFace, words []wordSegment, row position) []wordSegment {
+	tokens := utils.FlatMap(words, func(word wordSegment) []wordSegment {
+		return utils.Map(strings.Fields(word.text), func(text string) wordSegment {
+			word.text = text
+			return word
+		})
+	})
+	if len(tokens) == 1 {
+		tokens = utils.Map([]rune(tokens[0].text), func(char rune) wordSegment {
+			token := tokens[0]
+			token.text = string(char)
+			return token
+		})
+	}
+	if len(tokens) < 2 {
+		return words
+	}
+
+	widths := utils.Map(tokens, func(token wordSegment) float64 {
+		return measureText(drawingContext, targetLanguageFonts, token, token.text)
+	})
+	totalWidth := utils.Reduce(widths, func(total float64, width float64) float64 {
+		return total + width
+	}, 0.0)
+	gap := (float64(row.right-row.left) - totalWidth) / float64(len(tokens)-1)
+	if gap <= 0 || gap > MAX_JUSTIFIED_GAP_RATIO**tokens[0].fontSize {
+		return words
+	}
+
+	left := float64(row.left)
+	for i := range tokens {
+		tokens[i].position.left = int32(left)
+		tokens[i].position.right = int32(left + widths[i])
+		left += widths[i] + gap
+	}
+	return tokens
+}
+
+func measureText(drawingContext *gg.Context, targetLanguageFonts *font.FontsByFace, word wordSegment, text string) float64 {
+	fonts := getFontByStyle(targetLanguageFonts, word.style)
+	drawingContext.SetFontFace(fonts.NewFace(*word.fontSize))
+	width, _ := drawingContext.MeasureString(text)
+	return width
+}
@@ -0,0 +1,73 @@
+package impl
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/visionex-project/visionex/pkg/utils"
+)
+
+// Returns a line of a single word at the position.
+func testLine(left int32, top int32, right int32, bottom int32) lineSegment {
+	return lineSegment{words: []wordSegment{{position: position{left: left, top: top, right: right, bottom: bottom}}}}
+}
+
+func TestDetectAlignments(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []lineSegment
+		expected []textAlignment
+	}{
+		{
+			name: "centered headline over left aligned text",
+			lines: []lineSegment{
+				testLine(150, 0, 250, 20),
+				testLine(0, 30, 400, 50),
+				testLine(0, 60, 380, 80),
+				testLine(0, 90, 200, 110),
+			},
+			// The full width row fits every alignment, so it follows the paragraph.
+			expected: []textAlignment{ALIGNMENT_CENTER, ALIGNMENT_LEFT, ALIGNMENT_LEFT, ALIGNMENT_LEFT},
+		},
+		{
+			name: "right aligned rows",
+			lines: []lineSegment{
+				testLine(100, 0, 400, 20),
+				testLine(0, 30, 400, 50),
+				testLine(250, 60, 400, 80),
+			},
+			expected: []textAlignment{ALIGNMENT_RIGHT, ALIGNMENT_RIGHT, ALIGNMENT_RIGHT},
+		},
+		{
+			name: "justified rows follow the paragraph and the last row is left aligned",
+			lines: []lineSegment{
+				testLine(0, 0, 400, 20),
+				testLine(0, 30, 398, 50),
+				testLine(0, 60, 400, 80),
+				testLine(0, 90, 120, 110),
+			},
+			expected: []textAlignment{ALIGNMENT_JUSTIFIED, ALIGNMENT_JUSTIFIED, ALIGNMENT_JUSTIFIED, ALIGNMENT_LEFT},
+		},
+		{
+			name: "rows that fit no alignment follow the paragraph",
+			lines: []lineSegment{
+				testLine(0, 0, 400, 20),
+				testLine(60, 30, 200, 50),
+				testLine(0, 60, 300, 80),
+			},
+			expected: []textAlignment{ALIGNMENT_LEFT, ALIGNMENT_LEFT, ALIGNMENT_LEFT},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			paragraphs := detectAlignments([]paragraphSegment{{lines: test.lines}})
+			alignments := utils.Map(paragraphs[0].lines, func(line lineSegment) textAlignment {
+				return line.alignment
+			})
+			if !slices.Equal(alignments, test.expected) {
+				t.Errorf("got %v, want %v", alignments, test.expected)
+			}
+		})
+	}
+}
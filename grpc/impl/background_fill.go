@@ -0,0 +1,196 @@
+package impl
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+const (
+	// The surroundings of a masked region are the unmasked pixels within this distance in pixels from it.
+	FILL_RING_WIDTH = 3
+	// A surrounding pixel fits the background when every channel is within this much of it, out of 255,
+	// which allows for the noise of compressed images with a margin above the few levels JPEG adds to flat colors.
+	FILL_COLOR_TOLERANCE = 8
+	// At least this fraction of the surrounding pixels must fit the background, allowing for a few pixels
+	// of a neighboring object or a compression artifact. Dark objects pull the fit off the background,
+	// so only a handful of their pixels are allowed in practice, and larger ones leave the region for inpainting.
+	MIN_FILL_FIT_RATIO = 0.97
+	// Regions with fewer surrounding pixels are left for inpainting, as the background cannot be told from them.
+	MIN_FILL_RING_PIXELS = 16
+)
+
+// A color that changes linearly over the image, such as a flat color or a linear gradient.
+// Each channel of premultiplied RGBA between 0 and 255 is offset + slopeX*(x-origin.x) + slopeY*(y-origin.y).
+type linearBackground struct {
+	origin point
+	offset [4]float64
+	slopeX [4]float64
+	slopeY [4]float64
+}
+
+func (b linearBackground) at(x int, y int) [4]float64 {
+	dx, dy := float64(x)-b.origin.x, float64(y)-b.origin.y
+	channels := [4]float64{}
+	for c := range channels {
+		channels[c] = b.offset[c] + b.slopeX[c]*dx + b.slopeY[c]*dy
+	}
+	return channels
+}
+
+// Fills the masked regions that sit on a flat color or a linear gradient, such as the solid color bands
+// behind most texts of e-commerce images, as a fill is instant and exact while inpainting is slow and may leave smudges.
+// Returns the image with those regions filled, and the mask of the regions left for inpainting,
+// along with whether any are left.
+func fillFlatBackgrounds(originImage image.Image, maskImg *image.RGBA) (*image.RGBA, *image.RGBA, bool) {
+	bounds := originImage.Bounds()
+	filledImage := image.NewRGBA(bounds)
+	draw.Draw(filledImage, bounds, originImage, bounds.Min, draw.Src)
+	remainingMask := image.NewRGBA(maskImg.Bounds())
+	draw.Draw(remainingMask, remainingMask.Bounds(), maskImg, maskImg.Bounds().Min, draw.Src)
+
+	needsInpainting := false
+	for _, region := range maskedRegions(maskImg) {
+		background, ok := fitBackground(filledImage, maskImg, region)
+		if !ok {
+			needsInpainting = true
+			continue
+		}
+		for _, pixel := range region {
+			channels := background.at(pixel.X, pixel.Y)
+			alpha := toColorByte(channels[3])
+			// Premultiplied colors never exceed their alpha.
+			filledImage.SetRGBA(pixel.X, pixel.Y, color.RGBA{
+				R: min(toColorByte(channels[0]), alpha),
+				G: min(toColorByte(channels[1]), alpha),
+				B: min(toColorByte(channels[2]), alpha),
+				A: alpha,
+			})
+			remainingMask.SetRGBA(pixel.X, pixel.Y, color.RGBA{})
+		}
+	}
+	return filledImage, remainingMask, needsInpainting
+}
+
+// Returns the 8-connected regions of the masked pixels.
+func maskedRegions(maskImg *image.RGBA) [][]image.Point {
+	bounds := maskImg.Bounds()
+	visited := make([]bool, bounds.Dx()*bounds.Dy())
+	index := func(p image.Point) int {
+		return (p.Y-bounds.Min.Y)*bounds.Dx() + (p.X - bounds.Min.X)
+	}
+
+	regions := [][]image.Point{}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			start := image.Pt(x, y)
+			if visited[index(start)] || maskImg.RGBAAt(x, y).A == 0 {
+				continue
+			}
+			visited[index(start)] = true
+			region := []image.Point{}
+			stack := []image.Point{start}
+			for len(stack) > 0 {
+				current := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				region = append(region, current)
+				for dy := -1; dy <= 1; dy++ {
+					for dx := -1; dx <= 1; dx++ {
+						neighbor := current.Add(image.Pt(dx, dy))
+						if !neighbor.In(bounds) || visited[index(neighbor)] || maskImg.RGBAAt(neighbor.X, neighbor.Y).A == 0 {
+							continue
+						}
+						visited[index(neighbor)] = true
+						stack = append(stack, neighbor)
+					}
+				}
+			}
+			regions = append(regions, region)
+		}
+	}
+	return regions
+}
+
+// Fits a linear background to the unmasked pixels around the region by least squares,
+// and returns false when they are not close enough to one, such as around photos and patterns.
+func fitBackground(img image.Image, maskImg *image.RGBA, region []image.Point) (linearBackground, bool) {
+	regionBounds := image.Rectangle{Min: region[0], Max: region[0].Add(image.Pt(1, 1))}
+	for _, pixel := range region {
+		regionBounds = regionBounds.Union(image.Rectangle{Min: pixel, Max: pixel.Add(image.Pt(1, 1))})
+	}
+	// Only the bounds of the region are needed to measure the distances, not its colors.
+	ringRegion := &pixelRegion{bounds: regionBounds.Inset(-FILL_RING_WIDTH).Intersect(img.Bounds())}
+	isRegion := make([]bool, ringRegion.bounds.Dx()*ringRegion.bounds.Dy())
+	for _, pixel := range region {
+		isRegion[ringRegion.index(pixel.X, pixel.Y)] = true
+	}
+	distances := ringRegion.distancesTo(isRegion, FILL_RING_WIDTH+1)
+
+	type ringPixel struct {
+		x        float64
+		y        float64
+		channels [4]float64
+	}
+	ring := []ringPixel{}
+	for y := ringRegion.bounds.Min.Y; y < ringRegion.bounds.Max.Y; y++ {
+		for x := ringRegion.bounds.Min.X; x < ringRegion.bounds.Max.X; x++ {
+			if distances[ringRegion.index(x, y)] > FILL_RING_WIDTH || maskImg.RGBAAt(x, y).A != 0 {
+				continue
+			}
+			r, g, b, a := img.At(x, y).RGBA()
+			ring = append(ring, ringPixel{
+				x:        float64(x),
+				y:        float64(y),
+				channels: [4]float64{float64(r >> 8), float64(g >> 8), float64(b >> 8), float64(a >> 8)},
+			})
+		}
+	}
+	if len(ring) < MIN_FILL_RING_PIXELS {
+		return linearBackground{}, false
+	}
+
+	// The coordinates are centered, so that the offset is the mean and the slopes are solved separately from it.
+	background := linearBackground{}
+	for _, pixel := range ring {
+		background.origin.x += pixel.x / float64(len(ring))
+		background.origin.y += pixel.y / float64(len(ring))
+	}
+	var xx, xy, yy float64
+	var xv, yv [4]float64
+	for _, pixel := range ring {
+		dx, dy := pixel.x-background.origin.x, pixel.y-background.origin.y
+		xx += dx * dx
+		xy += dx * dy
+		yy += dy * dy
+		for c := range pixel.channels {
+			background.offset[c] += pixel.channels[c] / float64(len(ring))
+			xv[c] += dx * pixel.channels[c]
+			yv[c] += dy * pixel.channels[c]
+		}
+	}
+	// The slopes are left flat when the ring is a straight line, along which no gradient can be measured.
+	if determinant := xx*yy - xy*xy; determinant > 1e-9*max(1, xx*yy) {
+		for c := range xv {
+			background.slopeX[c] = (xv[c]*yy - yv[c]*xy) / determinant
+			background.slopeY[c] = (yv[c]*xx - xv[c]*xy) / determinant
+		}
+	}
+
+	fitCount := 0
+	for _, pixel := range ring {
+		expected := background.at(int(pixel.x), int(pixel.y))
+		fits := true
+		for c := range pixel.channels {
+			fits = fits && math.Abs(pixel.channels[c]-expected[c]) <= FILL_COLOR_TOLERANCE
+		}
+		if fits {
+			fitCount++
+		}
+	}
+	return background, float64(fitCount) >= MIN_FILL_FIT_RATIO*float64(len(ring))
+}
+
+func toColorByte(value float64) uint8 {
+	return uint8(math.Round(min(255, max(0, value))))
+}
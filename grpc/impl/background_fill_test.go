@@ -0,0 +1,157 @@
+package impl
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math/rand"
+	"testing"
+)
+
+// Returns an image of the background, with a neighboring object painted over it when given.
+func backgroundImage(bounds image.Rectangle, background func(x int, y int) color.RGBA, object image.Rectangle) *image.RGBA {
+	img := image.NewRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			img.SetRGBA(x, y, background(x, y))
+		}
+	}
+	draw.Draw(img, object, &image.Uniform{C: color.RGBA{R: 20, G: 20, B: 20, A: 255}}, image.Point{}, draw.Src)
+	return img
+}
+
+func rectMask(bounds image.Rectangle, masked ...image.Rectangle) *image.RGBA {
+	maskImg := image.NewRGBA(bounds)
+	for _, rect := range masked {
+		draw.Draw(maskImg, rect, image.White, image.Point{}, draw.Src)
+	}
+	return maskImg
+}
+
+func absDiff(a uint8, b uint8) int {
+	return max(int(a)-int(b), int(b)-int(a))
+}
+
+func TestFillFlatBackgrounds(t *testing.T) {
+	bounds := image.Rect(0, 0, 120, 60)
+	masked := image.Rect(30, 20, 90, 40)
+	random := rand.New(rand.NewSource(1))
+	band := func(x int, y int) color.RGBA {
+		return color.RGBA{R: 200, G: 30, B: 60, A: 255}
+	}
+	tests := []struct {
+		name       string
+		background func(x int, y int) color.RGBA
+		// A neighboring object within the ring around the masked region.
+		object          image.Rectangle
+		needsInpainting bool
+	}{
+		{name: "flat band", background: band, needsInpainting: false},
+		{
+			name: "horizontal gradient",
+			background: func(x int, y int) color.RGBA {
+				return color.RGBA{R: uint8(2 * x), G: 100, B: uint8(240 - x), A: 255}
+			},
+			needsInpainting: false,
+		},
+		{
+			// JPEG compression noise of up to 6 levels, within FILL_COLOR_TOLERANCE.
+			name: "compression noise",
+			background: func(x int, y int) color.RGBA {
+				return color.RGBA{R: uint8(200 + random.Intn(13) - 6), G: uint8(30 + random.Intn(13) - 6), B: 60, A: 255}
+			},
+			needsInpainting: false,
+		},
+		{
+			// 4 of the 516 ring pixels, within the 3% MIN_FILL_FIT_RATIO allows for.
+			name:            "small neighboring object",
+			background:      band,
+			object:          image.Rect(masked.Max.X, masked.Min.Y-2, masked.Max.X+2, masked.Min.Y),
+			needsInpainting: false,
+		},
+		{
+			// 36 of the 516 ring pixels, as where the text touches a picture.
+			name:            "large neighboring object",
+			background:      band,
+			object:          image.Rect(masked.Max.X, masked.Min.Y, masked.Max.X+3, masked.Max.Y-8),
+			needsInpainting: true,
+		},
+		{
+			name: "photo",
+			background: func(x int, y int) color.RGBA {
+				return color.RGBA{R: uint8(random.Intn(256)), G: uint8(random.Intn(256)), B: uint8(random.Intn(256)), A: 255}
+			},
+			needsInpainting: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			img := backgroundImage(bounds, test.background, test.object)
+			maskImg := rectMask(bounds, masked)
+
+			filledImage, remainingMask, needsInpainting := fillFlatBackgrounds(img, maskImg)
+			if needsInpainting != test.needsInpainting {
+				t.Fatalf("got needsInpainting %v, want %v", needsInpainting, test.needsInpainting)
+			}
+			for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+				for x := bounds.Min.X; x < bounds.Max.X; x++ {
+					got, origin := filledImage.RGBAAt(x, y), img.RGBAAt(x, y)
+					if needsInpainting || !image.Pt(x, y).In(masked) {
+						if got != origin {
+							t.Fatalf("got %v at (%d, %d), want the original %v", got, x, y, origin)
+						}
+						if remaining := remainingMask.RGBAAt(x, y); remaining != maskImg.RGBAAt(x, y) {
+							t.Fatalf("got the mask %v at (%d, %d), want the original %v", remaining, x, y, maskImg.RGBAAt(x, y))
+						}
+						continue
+					}
+					if remainingMask.RGBAAt(x, y).A != 0 {
+						t.Fatalf("got (%d, %d) left masked, want it filled", x, y)
+					}
+					// The background without the noise and the object, which the fill should be close to.
+					want := test.background(x, y)
+					if test.name == "compression noise" {
+						want = color.RGBA{R: 200, G: 30, B: 60, A: 255}
+					}
+					if absDiff(got.R, want.R) > FILL_COLOR_TOLERANCE || absDiff(got.G, want.G) > FILL_COLOR_TOLERANCE ||
+						absDiff(got.B, want.B) > FILL_COLOR_TOLERANCE || got.A != want.A {
+						t.Fatalf("got %v at (%d, %d), want about %v", got, x, y, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFitBackgroundNeedsEnoughRingPixels(t *testing.T) {
+	bounds := image.Rect(0, 0, 40, 40)
+	img := backgroundImage(bounds, func(x int, y int) color.RGBA {
+		return color.RGBA{R: 200, G: 30, B: 60, A: 255}
+	}, image.Rectangle{})
+	tests := []struct {
+		name   string
+		masked []image.Rectangle
+		fits   bool
+	}{
+		// The ring of a 2x2 corner region is 3x3 pixels beyond it on two sides, 21 pixels.
+		{name: "corner region", masked: []image.Rectangle{image.Rect(0, 0, 2, 2)}, fits: true},
+		// The ring of a 1x1 corner region is 15 pixels, one fewer than MIN_FILL_RING_PIXELS.
+		{name: "pixel in the corner", masked: []image.Rectangle{image.Rect(0, 0, 1, 1)}, fits: false},
+		// All but the last 5 pixels of the bottom row are masked, which are all the ring there is.
+		{name: "region covering the image", masked: []image.Rectangle{image.Rect(0, 0, 40, 39), image.Rect(0, 39, 35, 40)}, fits: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maskImg := rectMask(bounds, test.masked...)
+			regions := maskedRegions(maskImg)
+			if len(regions) != 1 {
+				t.Fatalf("got %d regions, want 1", len(regions))
+			}
+			if _, fits := fitBackground(img, maskImg, regions[0]); fits != test.fits {
+				t.Errorf("got fits %v, want %v", fits, test.fits)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/visionex-project/visionex/grpc"
+	"github.com/visionex-project/visionex/pkg/utils"
+)
+
+func (s *server) DetectLayout(ctx context.Context, request *pb.DetectLayoutRequest) (*pb.DetectLayoutResponse, error) {
+	mimeType, err := detectMimeType(request.GetImage())
+	if err != nil {
+		log.Printf("Failed to decode image: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, codes.InvalidArgument.String())
+	}
+
+	pages, err := s.detectPages(ctx, request.GetImage(), mimeType)
+	if err != nil {
+		log.Printf("Failed to detect document: %v", err)
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+
+	sourceLanguage := detectSourceLanguage(utils.FlatMap(pages, func(page pageSegment) []paragraphSegment {
+		return page.paragraphs
+	}), request.GetSourceLanguage())
+
+	layoutPages := []*pb.LayoutPage{}
+	for _, page := range pages {
+		config, _, err := image.DecodeConfig(bytes.NewReader(page.byteImage))
+		if err != nil {
+			log.Printf("Failed to decode page image: %v", err)
+			return nil, status.Error(codes.Internal, codes.Internal.String())
+		}
+
+		// Unlike TranslateToImage, every line is kept unless a target language is given.
+		paragraphs := page.paragraphs
+		if request.GetTargetLanguage() != pb.Language_LANGUAGE_UNSPECIFIED {
+			paragraphs = filterNonTargetLanguage(paragraphs, sourceLanguage, request.GetTargetLanguage())
+		}
+
+		layoutPages = append(layoutPages, &pb.LayoutPage{
+			Width:      int32(config.Width),
+			Height:     int32(config.Height),
+			Paragraphs: utils.Map(groupedSimilarStyle(paragraphs), toLayoutParagraph),
+		})
+	}
+
+	return &pb.DetectLayoutResponse{Pages: layoutPages, SourceLanguage: sourceLanguage}, nil
+}
+
+func toLayoutParagraph(paragraph paragraphSegment) *pb.LayoutParagraph {
+	words := utils.FlatMap(paragraph.lines, func(line lineSegment) []wordSegment {
+		return line.words
+	})
+	return &pb.LayoutParagraph{
+		BoundingBox: toBoundingBox(combinedPosition(utils.Map(words, func(word wordSegment) position {
+			return word.position
+		}))),
+		Lines: utils.Map(paragraph.lines, toLayoutLine),
+	}
+}
+
+func toLayoutLine(line lineSegment) *pb.LayoutLine {
+	return &pb.LayoutLine{
+		BoundingBox: toBoundingBox(combinedPosition(utils.Map(line.words, func(word wordSegment) position {
+			return word.position
+		}))),
+		Words: utils.Map(line.words, toLayoutWord),
+	}
+}
+
+func toLayoutWord(word wordSegment) *pb.LayoutWord {
+	layoutWord := &pb.LayoutWord{
+		Text:        word.text,
+		BoundingBox: toBoundingBox(word.position),
+	}
+	if word.fontSize != nil {
+		layoutWord.FontSize = *word.fontSize
+	}
+	if word.style != nil {
+		layoutWord.TextColor = &pb.Color{
+			Red:   word.style.textColor.R,
+			Green: word.style.textColor.G,
+			Blue:  word.style.textColor.B,
+		}
+		layoutWord.FontWeight = int32(word.style.fontWeight)
+	}
+	return layoutWord
+}
+
+func toBoundingBox(position position) *pb.BoundingBox {
+	return &pb.BoundingBox{
+		Top:    position.top,
+		Left:   position.left,
+		Bottom: position.bottom,
+		Right:  position.right,
+	}
+}
@@ -0,0 +1,57 @@
+package impl
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/HugoSmits86/nativewebp"
+
+	pb "github.com/visionex-project/visionex/grpc"
+	"github.com/visionex-project/visionex/grpc/impl/webp"
+)
+
+const (
+	// Used for JPEG and lossy WEBP when the request does not specify a quality.
+	DEFAULT_OUTPUT_QUALITY = 90
+)
+
+// Encodes the image as requested and returns the encoded bytes and their MIME type.
+func encodeImage(img image.Image, encoding *pb.OutputEncoding) ([]byte, string, error) {
+	quality := int(encoding.GetQuality())
+	if quality <= 0 {
+		quality = DEFAULT_OUTPUT_QUALITY
+	}
+	quality = min(quality, 100)
+
+	buffer := new(bytes.Buffer)
+	switch encoding.GetFormat() {
+	case pb.OutputFormat_OUTPUT_FORMAT_JPEG:
+		if err := jpeg.Encode(buffer, img, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, "", err
+		}
+		return buffer.Bytes(), "image/jpeg", nil
+	case pb.OutputFormat_OUTPUT_FORMAT_WEBP_LOSSLESS:
+		if err := nativewebp.Encode(buffer, img, nil); err != nil {
+			return nil, "", err
+		}
+		return buffer.Bytes(), "image/webp", nil
+	case pb.OutputFormat_OUTPUT_FORMAT_WEBP_LOSSY:
+		if err := webp.EncodeLossy(buffer, img, quality); err != nil {
+			return nil, "", err
+		}
+		return buffer.Bytes(), "image/webp", nil
+	default:
+		if err := png.Encode(buffer, img); err != nil {
+			return nil, "", err
+		}
+		return buffer.Bytes(), "image/png", nil
+	}
+}
+
+// E.g., "data:image/png;base64,..."
+func toDataUri(content []byte, mimeType string) string {
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(content)
+}
@@ -0,0 +1,45 @@
+package font
+
+import (
+	"bytes"
+	"sync"
+
+	"golang.org/x/image/font/sfnt"
+)
+
+// The bytes every font was parsed from, keyed by the font, so that they can be embedded in SVG and HTML outputs.
+// The parsed fonts keep no way to get them back.
+var fontData sync.Map
+
+// Parses a TrueType or OpenType font, and keeps the bytes for Data.
+func parse(data []byte) (*sfnt.Font, error) {
+	font, err := sfnt.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	fontData.Store(font, data)
+	return font, nil
+}
+
+// Forgets the bytes of a font that is no longer served.
+func forget(font *sfnt.Font) {
+	fontData.Delete(font)
+}
+
+// Returns the bytes the font was parsed from, and whether they are known.
+func Data(font *sfnt.Font) ([]byte, bool) {
+	data, ok := fontData.Load(font)
+	if !ok {
+		return nil, false
+	}
+	return data.([]byte), true
+}
+
+// Returns the MIME type of the font data. E.g., "font/otf"
+func MimeType(data []byte) string {
+	// OpenType fonts with CFF outlines start with "OTTO", while those with TrueType outlines start like TrueType fonts.
+	if bytes.HasPrefix(data, []byte("OTTO")) {
+		return "font/otf"
+	}
+	return "font/ttf"
+}
@@ -0,0 +1,111 @@
+package font
+
+import (
+	"image"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// A font followed by the fonts used for the runes it lacks, in the order they are tried.
+// E.g., the English font followed by the Korean font draws Hangul within English text instead of missing glyph boxes.
+type FontChain []*sfnt.Font
+
+// Returns a face of the given size that draws and measures every rune with the first font of the chain that has it.
+// Runes that no font has are left to the first font, which draws them as missing glyph boxes as before.
+func (c FontChain) NewFace(size float64) font.Face {
+	return &chainFace{
+		fonts:   c,
+		faces:   make([]font.Face, len(c)),
+		options: &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingNone},
+	}
+}
+
+// Returns the fonts of the chain that draw some rune of the text, in the order of the chain.
+// Runes that no font has are drawn by the first font, as by NewFace.
+func (c FontChain) Covering(text string) FontChain {
+	face := &chainFace{fonts: c}
+	used := make([]bool, len(c))
+	for _, r := range text {
+		used[face.fontIndex(r)] = true
+	}
+	covering := FontChain{}
+	for i, chainFont := range c {
+		if used[i] {
+			covering = append(covering, chainFont)
+		}
+	}
+	return covering
+}
+
+type chainFace struct {
+	fonts []*sfnt.Font
+	// Faces are created when they are first needed, as most texts never need the fallback fonts.
+	faces   []font.Face
+	options *opentype.FaceOptions
+	buffer  sfnt.Buffer
+}
+
+// Returns the index of the first font that has the rune, or 0 when none has it.
+func (f *chainFace) fontIndex(r rune) int {
+	for i, chainFont := range f.fonts {
+		// Glyph 0 is the missing glyph of every font.
+		if glyphIndex, err := chainFont.GlyphIndex(&f.buffer, r); err == nil && glyphIndex != 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+func (f *chainFace) face(i int) font.Face {
+	if f.faces[i] == nil {
+		// Never fails, as the options are valid.
+		f.faces[i], _ = opentype.NewFace(f.fonts[i], f.options)
+	}
+	return f.faces[i]
+}
+
+func (f *chainFace) faceFor(r rune) font.Face {
+	return f.face(f.fontIndex(r))
+}
+
+func (f *chainFace) Close() error {
+	for _, face := range f.faces {
+		if face != nil {
+			face.Close()
+		}
+	}
+	return nil
+}
+
+func (f *chainFace) Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	return f.faceFor(r).Glyph(dot, r)
+}
+
+func (f *chainFace) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	return f.faceFor(r).GlyphBounds(r)
+}
+
+func (f *chainFace) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	return f.faceFor(r).GlyphAdvance(r)
+}
+
+// Kerning is only defined between the glyphs of the same font.
+func (f *chainFace) Kern(r0 rune, r1 rune) fixed.Int26_6 {
+	i := f.fontIndex(r0)
+	if i != f.fontIndex(r1) {
+		return 0
+	}
+	return f.face(i).Kern(r0, r1)
+}
+
+// The baseline follows the first font, so that fallback glyphs sit on the same line.
+// The height is the font size rather than the line height of the font,
+// as the text is laid out to fit the height of the original text, which is about the font size.
+func (f *chainFace) Metrics() font.Metrics {
+	metrics := f.face(0).Metrics()
+	metrics.Height = fixed.Int26_6(0.5 + f.options.Size*64)
+	return metrics
+}
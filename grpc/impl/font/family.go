@@ -0,0 +1,105 @@
+package font
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/image/font/sfnt"
+
+	pb "github.com/visionex-project/visionex/grpc"
+	"github.com/visionex-project/visionex/pkg/utils"
+)
+
+var ErrFamilyNotFound = errors.New("font family not found")
+
+// The weights a family can have, from the lightest.
+var familyWeights = []pb.FontWeight{pb.FontWeight_FONT_WEIGHT_REGULAR, pb.FontWeight_FONT_WEIGHT_SEMI_BOLD, pb.FontWeight_FONT_WEIGHT_BOLD}
+
+type Family struct {
+	// E.g., "Acme Sans"
+	Name string
+	// From the lightest. E.g., [FONT_WEIGHT_REGULAR, FONT_WEIGHT_BOLD]
+	Weights []pb.FontWeight
+}
+
+// Returns an error when the data is neither a TrueType nor an OpenType font.
+func Validate(data []byte) error {
+	_, err := sfnt.Parse(data)
+	return err
+}
+
+func (fp *fontProvider) GetFontByFamily(family string) (*FontsByWeight, error) {
+	fp.familyMutex.RLock()
+	defer fp.familyMutex.RUnlock()
+
+	fonts, ok := fp.familyFonts[family]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrFamilyNotFound, family)
+	}
+	regular, semiBold, bold := fonts[pb.FontWeight_FONT_WEIGHT_REGULAR], fonts[pb.FontWeight_FONT_WEIGHT_SEMI_BOLD], fonts[pb.FontWeight_FONT_WEIGHT_BOLD]
+	// Unlike the fonts of the manifest, a family may lack the regular weight, e.g., a display typeface with only a bold weight.
+	if regular == nil {
+		regular = semiBold
+	}
+	if regular == nil {
+		regular = bold
+	}
+	return nearestWeights(regular, semiBold, bold), nil
+}
+
+func (fp *fontProvider) AddFamilyFont(family string, weight pb.FontWeight, data []byte) error {
+	if !slices.Contains(familyWeights, weight) {
+		return fmt.Errorf("unsupported font weight: %s", weight)
+	}
+	font, err := parse(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse the font: %w", err)
+	}
+
+	fp.familyMutex.Lock()
+	defer fp.familyMutex.Unlock()
+
+	if _, ok := fp.familyFonts[family]; !ok {
+		fp.familyFonts[family] = map[pb.FontWeight]*sfnt.Font{}
+	}
+	if replaced, ok := fp.familyFonts[family][weight]; ok {
+		forget(replaced)
+	}
+	fp.familyFonts[family][weight] = font
+	return nil
+}
+
+func (fp *fontProvider) ListFamilies() []Family {
+	fp.familyMutex.RLock()
+	defer fp.familyMutex.RUnlock()
+
+	families := []Family{}
+	for name, fonts := range fp.familyFonts {
+		families = append(families, Family{
+			Name: name,
+			Weights: utils.Filter(familyWeights, func(weight pb.FontWeight) bool {
+				return fonts[weight] != nil
+			}),
+		})
+	}
+	slices.SortFunc(families, func(a Family, b Family) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return families
+}
+
+func (fp *fontProvider) RemoveFamily(family string) error {
+	fp.familyMutex.Lock()
+	defer fp.familyMutex.Unlock()
+
+	if _, ok := fp.familyFonts[family]; !ok {
+		return fmt.Errorf("%w: %s", ErrFamilyNotFound, family)
+	}
+	for _, font := range fp.familyFonts[family] {
+		forget(font)
+	}
+	delete(fp.familyFonts, family)
+	return nil
+}
@@ -1,75 +1,273 @@
 package font
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"slices"
+	"sync"
 
-	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font/sfnt"
 
 	pb "github.com/visionex-project/visionex/grpc"
 )
 
 type FontProvider interface {
 	// Returns the font for the given language.
-	GetFontByLanguage(language pb.Language) *FontsByFace
+	// Returns an error when the fonts for a supported language are not installed.
+	GetFontByLanguage(language pb.Language) (*FontsByFace, error)
+
+	// Returns the fonts of a family added by AddFamilyFont. Weights the family lacks fall back to the nearest weight it has.
+	// Returns ErrFamilyNotFound when the family has not been added.
+	GetFontByFamily(family string) (*FontsByWeight, error)
+	// Adds a weight of a family, replacing the font of the weight when the family already has it.
+	// Returns an error when the data is neither a TrueType nor an OpenType font.
+	AddFamilyFont(family string, weight pb.FontWeight, data []byte) error
+	// Returns every family with the weights it has, sorted by the family name.
+	ListFamilies() []Family
+	// Removes every weight of the family. Returns ErrFamilyNotFound when the family has not been added.
+	RemoveFamily(family string) error
 }
 
 type fontProvider struct {
-	basePath string
-	Japanese FontsByFace
-	Korean   FontsByFace
-	English  FontsByFace
+	// Fonts keyed by the language of the manifest. E.g., "Korean"
+	fontsByLanguage map[string]*FontsByFace
+
+	// Guards familyFonts, which changes while requests are served.
+	familyMutex sync.RWMutex
+	// Fonts added at runtime, such as the corporate typefaces of brands. Keyed by the family name and the weight.
+	familyFonts map[string]map[pb.FontWeight]*sfnt.Font
 }
 
 type FontFace string
 
-// TODO(#7114): Add more FontFaces eg. Serif, Monospaced, Handwriting, Gothic.
-const FontFaceSansSerif FontFace = "SansSerif"
+const (
+	// Gothic in Japanese and Korean. E.g., Noto Sans
+	FontFaceSansSerif FontFace = "SansSerif"
+	// Mincho in Japanese and Myeongjo in Korean. E.g., Noto Serif
+	FontFaceSerif FontFace = "Serif"
+	// E.g., Noto Sans Mono
+	FontFaceMonospace FontFace = "Monospace"
+	// Sans serif with rounded stroke ends. E.g., M PLUS Rounded 1c
+	FontFaceRounded FontFace = "Rounded"
+	// E.g., Caveat
+	FontFaceHandwriting FontFace = "Handwriting"
+)
+
+// Every face but SansSerif is optional, so that only the faces needed for the expected images have to be installed.
+var optionalFaces = []FontFace{FontFaceSerif, FontFaceMonospace, FontFaceRounded, FontFaceHandwriting}
 
+// The fonts of a language. Optional faces are nil when they are not installed.
 type FontsByFace struct {
-	SansSerif FontsByWeight
+	SansSerif   FontsByWeight
+	Serif       *FontsByWeight
+	Monospace   *FontsByWeight
+	Rounded     *FontsByWeight
+	Handwriting *FontsByWeight
+	// Tried in order for the runes that the fonts of the language lack.
+	// The fonts of the other languages come first, followed by the fallback fonts of the manifest.
+	Fallbacks []*FontsByFace
+}
+
+// Returns the fonts of the face, or the sans serif fonts when the face is not installed for the language.
+func (f *FontsByFace) ByFace(face FontFace) FontsByWeight {
+	var fonts *FontsByWeight
+	switch face {
+	case FontFaceSerif:
+		fonts = f.Serif
+	case FontFaceMonospace:
+		fonts = f.Monospace
+	case FontFaceRounded:
+		fonts = f.Rounded
+	case FontFaceHandwriting:
+		fonts = f.Handwriting
+	}
+	if fonts == nil {
+		return f.SansSerif
+	}
+	return *fonts
+}
+
+// Returns the fonts of the language with every face replaced by the family.
+// The fonts of the language follow the family as fallbacks, for the runes the family lacks.
+// E.g., a Latin corporate typeface falls back to the Korean fonts for Hangul.
+func WithFamily(languageFonts *FontsByFace, family *FontsByWeight) *FontsByFace {
+	return &FontsByFace{
+		SansSerif:   *family,
+		Serif:       family,
+		Monospace:   family,
+		Rounded:     family,
+		Handwriting: family,
+		Fallbacks:   append([]*FontsByFace{languageFonts}, languageFonts.Fallbacks...),
+	}
 }
 
+// Both TrueType (.ttf) and OpenType (.otf) fonts are supported.
 type FontsByWeight struct {
-	Regular  *truetype.Font
-	SemiBold *truetype.Font
-	Bold     *truetype.Font
+	Regular  *sfnt.Font
+	SemiBold *sfnt.Font
+	Bold     *sfnt.Font
+	// The italic fonts of the same weights. Nil when not installed, in which case italic text is slanted instead.
+	Italic *FontsByWeight
+}
+
+// The fonts of these languages are shipped with the repository, so the server does not start without them.
+var requiredLanguages = []string{"English", "Korean", "Japanese"}
+
+// The fonts of these languages are too large to ship with the repository, so they are downloaded by
+// grpc/cmd/fonts/download.sh, which the Docker image runs when it is built.
+// Requests in these languages fail until the fonts are downloaded.
+var optionalLanguages = []string{"ChineseSimplified", "ChineseTraditional", "Thai"}
+
+// The name of the manifest file in the base path.
+const MANIFEST_FILE_NAME = "fonts.json"
+
+// Lists the font files to load. Paths are relative to the base path.
+// E.g.,
+//
+//	{
+//	  "languages": {
+//	    "English": {
+//	      "SansSerif": {"regular": "English/SansSerif-Regular.ttf", "bold": "English/SansSerif-Bold.ttf"},
+//	      "Serif": {
+//	        "regular": "English/NotoSerif-Regular.otf",
+//	        "italic": {"regular": "English/NotoSerif-Italic.otf"}
+//	      }
+//	    }
+//	  },
+//	  "fallbacks": ["Fallback/NotoSansSymbols2-Regular.ttf"]
+//	}
+type manifest struct {
+	// Keyed by the language and the face.
+	Languages map[string]map[FontFace]manifestWeights `json:"languages"`
+	// Fonts of symbols that no language font covers, such as emoji and arrows, in the order they are tried.
+	// Every fallback font is used for all the weights and faces.
+	Fallbacks []string `json:"fallbacks"`
+}
+
+// SemiBold, Bold and Italic may be left out.
+type manifestWeights struct {
+	Regular  string `json:"regular"`
+	SemiBold string `json:"semiBold"`
+	Bold     string `json:"bold"`
+	// The italic fonts, which cannot have italic fonts of their own.
+	Italic *manifestWeights `json:"italic"`
 }
 
 func New(basePath string) (FontProvider, error) {
+	manifestBytes, err := os.ReadFile(filepath.Join(basePath, MANIFEST_FILE_NAME))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the font manifest: %w", err)
+	}
+	var fontManifest manifest
+	if err := json.Unmarshal(manifestBytes, &fontManifest); err != nil {
+		return nil, fmt.Errorf("failed to parse the font manifest: %w", err)
+	}
+
 	fp := &fontProvider{
-		basePath: basePath,
+		fontsByLanguage: map[string]*FontsByFace{},
+		familyFonts:     map[string]map[pb.FontWeight]*sfnt.Font{},
+	}
+	languages := slices.Concat(requiredLanguages, optionalLanguages)
+	for language := range fontManifest.Languages {
+		if !slices.Contains(languages, language) {
+			return nil, fmt.Errorf("unknown language in the font manifest: %s", language)
+		}
 	}
 
-	var err error
-	fp.Korean, err = fp.loadFontsByFace(pb.Language_LANGUAGE_KO_KR)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize Korean fonts: %w", err)
+	for _, language := range requiredLanguages {
+		fonts, err := loadFontsByFace(basePath, fontManifest.Languages[language])
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize %s fonts: %w", language, err)
+		}
+		fp.fontsByLanguage[language] = fonts
 	}
 
-	fp.Japanese, err = fp.loadFontsByFace(pb.Language_LANGUAGE_JA_JP)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize Japanese fonts: %w", err)
+	for _, language := range optionalLanguages {
+		fonts, err := loadFontsByFace(basePath, fontManifest.Languages[language])
+		if errors.Is(err, os.ErrNotExist) {
+			log.Printf("Warning: %s fonts are not installed: %v", language, err)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize %s fonts: %w", language, err)
+		}
+		fp.fontsByLanguage[language] = fonts
 	}
 
-	fp.English, err = fp.loadFontsByFace(pb.Language_LANGUAGE_EN_US)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize English fonts: %w", err)
+	symbolFonts := []*FontsByFace{}
+	for _, path := range fontManifest.Fallbacks {
+		font, err := parseFontFile(filepath.Join(basePath, path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize fallback fonts: %w", err)
+		}
+		symbolFonts = append(symbolFonts, &FontsByFace{
+			SansSerif: FontsByWeight{Regular: font, SemiBold: font, Bold: font},
+		})
+	}
+	for _, language := range languages {
+		fonts, ok := fp.fontsByLanguage[language]
+		if !ok {
+			continue
+		}
+		for _, otherLanguage := range languages {
+			if otherFonts, ok := fp.fontsByLanguage[otherLanguage]; ok && otherLanguage != language {
+				fonts.Fallbacks = append(fonts.Fallbacks, otherFonts)
+			}
+		}
+		fonts.Fallbacks = append(fonts.Fallbacks, symbolFonts...)
 	}
 
 	return fp, nil
 }
 
-func (fp *fontProvider) loadFontsByFace(language pb.Language) (FontsByFace, error) {
-	sansSerif, err := fp.loadFontsByWeight(language, FontFaceSansSerif)
+// Returns an error wrapping os.ErrNotExist when the regular sans serif font is not in the manifest or not installed.
+func loadFontsByFace(basePath string, faces map[FontFace]manifestWeights) (*FontsByFace, error) {
+	for face := range faces {
+		if face != FontFaceSansSerif && !slices.Contains(optionalFaces, face) {
+			return nil, fmt.Errorf("unknown font face: %s", face)
+		}
+	}
+	if faces[FontFaceSansSerif].Regular == "" {
+		return nil, fmt.Errorf("%s regular font is not in the manifest: %w", FontFaceSansSerif, os.ErrNotExist)
+	}
+	sansSerif, err := loadFontsByWeight(basePath, FontFaceSansSerif, faces[FontFaceSansSerif])
 	if err != nil {
-		return FontsByFace{}, err
+		return nil, err
 	}
 
-	return FontsByFace{
+	fonts := &FontsByFace{
 		SansSerif: *sansSerif,
-	}, nil
+	}
+	for _, face := range optionalFaces {
+		weights, ok := faces[face]
+		if !ok {
+			continue
+		}
+		fontsByWeight, err := loadFontsByWeight(basePath, face, weights)
+		// The serif faces are downloaded by download.sh, so they may be listed before they are installed.
+		if errors.Is(err, os.ErrNotExist) {
+			log.Printf("Warning: %s fonts are not installed, and SansSerif is drawn instead: %v", face, err)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch face {
+		case FontFaceSerif:
+			fonts.Serif = fontsByWeight
+		case FontFaceMonospace:
+			fonts.Monospace = fontsByWeight
+		case FontFaceRounded:
+			fonts.Rounded = fontsByWeight
+		case FontFaceHandwriting:
+			fonts.Handwriting = fontsByWeight
+		}
+	}
+	return fonts, nil
 }
 
 // Note: We are renaming every font to the name of the font face and documenting the original font source below.
@@ -79,61 +277,129 @@ func (fp *fontProvider) loadFontsByFace(language pb.Language) (FontsByFace, erro
 // Korean Sanserif Font
 // Ref: https://fontesk.com/pretendard-typeface/
 // Note: Original files are .otf format, converted to .ttf using FontForge.
+// OpenType fonts are now loaded as they are, so newly added fonts do not need to be converted.
 
 // English Sanserif Font
 // Ref: https://fonts.google.com/noto/specimen/Noto+Sans
-func (fp *fontProvider) loadFontsByWeight(language pb.Language, face FontFace) (*FontsByWeight, error) {
-	langDirectory := languageDirectory(language)
+// Note: Also covers Vietnamese, Indonesian, Spanish, French and German.
+
+// Simplified Chinese Sanserif Font
+// Ref: https://fonts.google.com/noto/specimen/Noto+Sans+SC
+// Note: The subset OpenType fonts of Noto Sans CJK are used, with Medium as SemiBold.
+
+// Traditional Chinese Sanserif Font
+// Ref: https://fonts.google.com/noto/specimen/Noto+Sans+TC
+
+// Thai Sanserif Font
+// Ref: https://fonts.google.com/noto/specimen/Noto+Sans+Thai
+
+// Serif Fonts
+// Ref: https://fonts.google.com/noto/specimen/Noto+Serif
+// Note: Noto Serif, Noto Serif KR, JP, SC, TC and Noto Serif Thai, downloaded by download.sh like the Chinese and Thai fonts.
+
+// The other optional faces are not shipped with the repository. The following fonts are recommended.
+// Monospace: Noto Sans Mono for English. CJK fonts are already fixed width.
+// Ref: https://fonts.google.com/noto/specimen/Noto+Sans+Mono
+// Rounded: Nunito for English, M PLUS Rounded 1c for Japanese
+// Ref: https://fonts.google.com/specimen/M+PLUS+Rounded+1c
+// Handwriting: Caveat for English, Klee One for Japanese, Gaegu for Korean
+// Ref: https://fonts.google.com/specimen/Caveat
+
+// The italic fonts are not shipped with the repository either, and italic text is slanted without them.
+// Italic: Noto Sans Italic and Noto Serif Italic for English. CJK and Thai fonts have no italics.
+// Ref: https://fonts.google.com/noto/specimen/Noto+Sans
 
-	regular, err := parseFontFile(filepath.Join(fp.basePath, langDirectory, string(face)+"-Regular.ttf"))
+// The fallback fonts are not shipped with the repository either. The following fonts are recommended.
+// Symbols and arrows: Noto Sans Symbols and Noto Sans Symbols 2
+// Ref: https://fonts.google.com/noto/specimen/Noto+Sans+Symbols+2
+// Emoji: Noto Emoji, the monochrome version, as color emoji fonts cannot be drawn
+// Ref: https://fonts.google.com/noto/specimen/Noto+Emoji
+func loadFontsByWeight(basePath string, face FontFace, weights manifestWeights) (*FontsByWeight, error) {
+	regular, err := parseFontFile(filepath.Join(basePath, weights.Regular))
 	if err != nil {
 		return nil, fmt.Errorf("failed to load %s regular font: %w", face, err)
 	}
 
-	semiBold, err := parseFontFile(filepath.Join(fp.basePath, langDirectory, string(face)+"-SemiBold.ttf"))
-	if err != nil {
-		return nil, fmt.Errorf("failed to load %s semiBold font: %w", face, err)
+	var semiBold *sfnt.Font
+	if weights.SemiBold != "" {
+		semiBold, err = parseFontFile(filepath.Join(basePath, weights.SemiBold))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s semiBold font: %w", face, err)
+		}
 	}
 
-	bold, err := parseFontFile(filepath.Join(fp.basePath, langDirectory, string(face)+"-Bold.ttf"))
-	if err != nil {
-		return nil, fmt.Errorf("failed to load %s bold font: %w", face, err)
+	var bold *sfnt.Font
+	if weights.Bold != "" {
+		bold, err = parseFontFile(filepath.Join(basePath, weights.Bold))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s bold font: %w", face, err)
+		}
+	}
+
+	fonts := nearestWeights(regular, semiBold, bold)
+	if weights.Italic != nil {
+		if weights.Italic.Italic != nil {
+			return nil, fmt.Errorf("%s italic fonts cannot have italic fonts", face)
+		}
+		fonts.Italic, err = loadFontsByWeight(basePath, face+" italic", *weights.Italic)
+		if err != nil {
+			return nil, err
+		}
 	}
+	return fonts, nil
+}
 
+// Several fonts lack the semibold or the bold weight, so the nearest available weight is used instead.
+// The regular font is required.
+func nearestWeights(regular *sfnt.Font, semiBold *sfnt.Font, bold *sfnt.Font) *FontsByWeight {
+	if semiBold == nil {
+		semiBold = bold
+	}
+	if bold == nil {
+		bold = semiBold
+	}
+	if semiBold == nil {
+		semiBold, bold = regular, regular
+	}
 	return &FontsByWeight{
 		Regular:  regular,
 		SemiBold: semiBold,
 		Bold:     bold,
-	}, nil
+	}
 }
 
-func (fp *fontProvider) GetFontByLanguage(language pb.Language) *FontsByFace {
-	switch language {
-	case pb.Language_LANGUAGE_KO_KR:
-		return &fp.Korean
-	case pb.Language_LANGUAGE_JA_JP:
-		return &fp.Japanese
-	// Defaults to English for all other languages as it uses Latin alphabet which is widely recognized,
-	// ensuring the service can still function properly even when an unsupported language code is provided.
-	default:
-		return &fp.English
+func (fp *fontProvider) GetFontByLanguage(language pb.Language) (*FontsByFace, error) {
+	key := languageKey(language)
+	fonts, ok := fp.fontsByLanguage[key]
+	if !ok {
+		return nil, fmt.Errorf("%s fonts are not installed", key)
 	}
+	return fonts, nil
 }
 
-func parseFontFile(path string) (*truetype.Font, error) {
+func parseFontFile(path string) (*sfnt.Font, error) {
 	fontBytes, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	return truetype.Parse(fontBytes)
+	return parse(fontBytes)
 }
 
-func languageDirectory(language pb.Language) string {
+func languageKey(language pb.Language) string {
 	switch language {
 	case pb.Language_LANGUAGE_KO_KR:
 		return "Korean"
 	case pb.Language_LANGUAGE_JA_JP:
 		return "Japanese"
+	case pb.Language_LANGUAGE_ZH_CN:
+		return "ChineseSimplified"
+	case pb.Language_LANGUAGE_ZH_TW:
+		return "ChineseTraditional"
+	case pb.Language_LANGUAGE_TH_TH:
+		return "Thai"
+	// Defaults to English for all other languages as it uses Latin alphabet which is widely recognized,
+	// ensuring the service can still function properly even when an unsupported language code is provided.
+	// Vietnamese, Indonesian, Spanish, French and German are written in the Latin alphabet and covered by the English fonts.
 	default:
 		return "English"
 	}
@@ -0,0 +1,396 @@
+package font
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"slices"
+	"sort"
+
+	"golang.org/x/image/font/sfnt"
+)
+
+// The tables subsets keep as they are, as they do not refer to glyphs by their indices.
+// Layout tables such as GSUB and GPOS are dropped, as the texts are laid out before the fonts are embedded.
+var subsetCopiedTables = []string{"OS/2", "name", "cvt ", "fpgm", "prep", "gasp"}
+
+// Returns the font reduced to the glyphs of the runes, so that SVG and HTML outputs embed only the glyphs they draw
+// rather than whole fonts, which are megabytes for CJK. Runes the font lacks are skipped.
+// Both TrueType and CFF outlines are supported, but not CFF2 outlines of variable fonts.
+func Subset(font *sfnt.Font, runes []rune) ([]byte, error) {
+	data, ok := Data(font)
+	if !ok {
+		return nil, errors.New("the font data is unknown")
+	}
+	tables, err := readTables(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range []string{"head", "hhea", "hmtx", "maxp"} {
+		if tables[tag] == nil {
+			return nil, fmt.Errorf("the %q table is missing", tag)
+		}
+	}
+	if len(tables["head"]) < 54 || len(tables["hhea"]) < 36 || len(tables["maxp"]) < 6 {
+		return nil, errors.New("the font header is malformed")
+	}
+
+	s := &subsetter{
+		tables:     tables,
+		numGlyphs:  int(binary.BigEndian.Uint16(tables["maxp"][4:])),
+		newIndices: map[sfnt.GlyphIndex]uint16{},
+	}
+	// The first glyph is drawn for missing characters, so every font starts with it.
+	s.add(0)
+	runes = slices.Clone(runes)
+	slices.Sort(runes)
+	runeGlyphs := map[rune]uint16{}
+	buffer := &sfnt.Buffer{}
+	for _, r := range slices.Compact(runes) {
+		index, err := font.GlyphIndex(buffer, r)
+		if err != nil || index == 0 || int(index) >= s.numGlyphs {
+			continue
+		}
+		runeGlyphs[r] = s.add(index)
+	}
+
+	subset := map[string][]byte{}
+	for _, tag := range subsetCopiedTables {
+		if table, ok := tables[tag]; ok {
+			subset[tag] = table
+		}
+	}
+	// The outlines go first, as composite glyphs add the glyphs they are made of.
+	switch {
+	case tables["glyf"] != nil && tables["loca"] != nil:
+		subset["glyf"], subset["loca"], err = s.subsetGlyf()
+	case tables["CFF "] != nil:
+		subset["CFF "], err = s.subsetCFF()
+	default:
+		err = errors.New("the outlines are neither TrueType nor CFF")
+	}
+	if err != nil {
+		return nil, err
+	}
+	subset["hhea"], subset["hmtx"], err = s.subsetHmtx()
+	if err != nil {
+		return nil, err
+	}
+
+	maxp := slices.Clone(tables["maxp"])
+	binary.BigEndian.PutUint16(maxp[4:], uint16(len(s.glyphs)))
+	subset["maxp"] = maxp
+	head := slices.Clone(tables["head"])
+	// The loca table of the subset always has long offsets.
+	if subset["loca"] != nil {
+		binary.BigEndian.PutUint16(head[50:], 1)
+	}
+	subset["head"] = head
+	subset["post"] = subsetPost(tables["post"])
+	subset["cmap"] = subsetCmap(runeGlyphs)
+	return writeFont(data[:4], subset), nil
+}
+
+type subsetter struct {
+	tables    map[string][]byte
+	numGlyphs int
+	// The glyphs kept by their indices in the font, in the order of their indices in the subset.
+	glyphs []sfnt.GlyphIndex
+	// The indices in the subset keyed by the indices in the font.
+	newIndices map[sfnt.GlyphIndex]uint16
+}
+
+// Keeps the glyph, and returns its index in the subset.
+func (s *subsetter) add(index sfnt.GlyphIndex) uint16 {
+	if newIndex, ok := s.newIndices[index]; ok {
+		return newIndex
+	}
+	newIndex := uint16(len(s.glyphs))
+	s.glyphs = append(s.glyphs, index)
+	s.newIndices[index] = newIndex
+	return newIndex
+}
+
+// Returns the glyf and loca tables of the glyphs kept, along with the glyphs composite glyphs are made of.
+func (s *subsetter) subsetGlyf() ([]byte, []byte, error) {
+	glyf, loca := s.tables["glyf"], s.tables["loca"]
+	longOffsets := binary.BigEndian.Uint16(s.tables["head"][50:]) == 1
+	glyphData := func(index sfnt.GlyphIndex) ([]byte, error) {
+		var start, end int
+		if longOffsets && 4*int(index)+8 <= len(loca) {
+			start, end = int(binary.BigEndian.Uint32(loca[4*index:])), int(binary.BigEndian.Uint32(loca[4*index+4:]))
+		} else if !longOffsets && 2*int(index)+4 <= len(loca) {
+			start, end = 2*int(binary.BigEndian.Uint16(loca[2*index:])), 2*int(binary.BigEndian.Uint16(loca[2*index+2:]))
+		} else {
+			return nil, fmt.Errorf("glyph %d is missing from the loca table", index)
+		}
+		if start > end || end > len(glyf) {
+			return nil, fmt.Errorf("glyph %d is out of the glyf table", index)
+		}
+		return glyf[start:end], nil
+	}
+
+	newGlyf := []byte{}
+	offsets := []uint32{}
+	// Composite glyphs append their components to the glyphs while they are copied.
+	for i := 0; i < len(s.glyphs); i++ {
+		data, err := glyphData(s.glyphs[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		data = slices.Clone(data)
+		if len(data) >= 10 && int16(binary.BigEndian.Uint16(data)) < 0 {
+			if err := s.remapComponents(data); err != nil {
+				return nil, nil, fmt.Errorf("glyph %d: %w", s.glyphs[i], err)
+			}
+		}
+		offsets = append(offsets, uint32(len(newGlyf)))
+		newGlyf = append(newGlyf, data...)
+		// Glyphs are aligned to 4 bytes, as recommended by the specification.
+		for len(newGlyf)%4 != 0 {
+			newGlyf = append(newGlyf, 0)
+		}
+	}
+	offsets = append(offsets, uint32(len(newGlyf)))
+
+	newLoca := make([]byte, 4*len(offsets))
+	for i, offset := range offsets {
+		binary.BigEndian.PutUint32(newLoca[4*i:], offset)
+	}
+	return newGlyf, newLoca, nil
+}
+
+// Flags of the components of composite glyphs.
+const (
+	ARG_1_AND_2_ARE_WORDS    = 0x0001
+	WE_HAVE_A_SCALE          = 0x0008
+	MORE_COMPONENTS          = 0x0020
+	WE_HAVE_AN_X_AND_Y_SCALE = 0x0040
+	WE_HAVE_A_TWO_BY_TWO     = 0x0080
+)
+
+// Rewrites the glyph indices of the components of a composite glyph to the indices in the subset,
+// keeping the components.
+func (s *subsetter) remapComponents(data []byte) error {
+	for offset := 10; ; {
+		if offset+4 > len(data) {
+			return errors.New("the components are truncated")
+		}
+		flags := binary.BigEndian.Uint16(data[offset:])
+		component := sfnt.GlyphIndex(binary.BigEndian.Uint16(data[offset+2:]))
+		if int(component) >= s.numGlyphs {
+			return fmt.Errorf("component %d is out of the font", component)
+		}
+		binary.BigEndian.PutUint16(data[offset+2:], s.add(component))
+
+		offset += 4
+		if flags&ARG_1_AND_2_ARE_WORDS != 0 {
+			offset += 4
+		} else {
+			offset += 2
+		}
+		switch {
+		case flags&WE_HAVE_A_SCALE != 0:
+			offset += 2
+		case flags&WE_HAVE_AN_X_AND_Y_SCALE != 0:
+			offset += 4
+		case flags&WE_HAVE_A_TWO_BY_TWO != 0:
+			offset += 8
+		}
+		if flags&MORE_COMPONENTS == 0 {
+			return nil
+		}
+	}
+}
+
+// Returns the hhea and hmtx tables with the metrics of every glyph kept.
+func (s *subsetter) subsetHmtx() ([]byte, []byte, error) {
+	hhea, hmtx := s.tables["hhea"], s.tables["hmtx"]
+	// Glyphs after the first numberOfHMetrics share the advance of the last of them, and have only their side bearings.
+	numberOfHMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	if numberOfHMetrics == 0 || len(hmtx) < 4*numberOfHMetrics {
+		return nil, nil, errors.New("the hmtx table is malformed")
+	}
+
+	newHmtx := make([]byte, 4*len(s.glyphs))
+	for i, index := range s.glyphs {
+		metric := newHmtx[4*i:]
+		if int(index) < numberOfHMetrics {
+			copy(metric[:4], hmtx[4*index:])
+			continue
+		}
+		copy(metric[:2], hmtx[4*(numberOfHMetrics-1):])
+		if bearing := 4*numberOfHMetrics + 2*(int(index)-numberOfHMetrics); bearing+2 <= len(hmtx) {
+			copy(metric[2:4], hmtx[bearing:])
+		}
+	}
+	newHhea := slices.Clone(hhea)
+	binary.BigEndian.PutUint16(newHhea[34:], uint16(len(s.glyphs)))
+	return newHhea, newHmtx, nil
+}
+
+// Returns the post table without glyph names, which are only needed for printing to PostScript.
+func subsetPost(post []byte) []byte {
+	newPost := make([]byte, 32)
+	if len(post) >= 32 {
+		copy(newPost, post[:32])
+	}
+	binary.BigEndian.PutUint32(newPost, 0x00030000)
+	return newPost
+}
+
+// Returns the cmap table mapping the runes to the glyphs of the subset, with a format 12 subtable for every rune
+// and a format 4 subtable for those of the Basic Multilingual Plane, which older renderers look up.
+func subsetCmap(runeGlyphs map[rune]uint16) []byte {
+	// Runs of runes drawn by consecutive glyphs.
+	type group struct {
+		start rune
+		end   rune
+		glyph uint16
+	}
+	runes := make([]rune, 0, len(runeGlyphs))
+	for r := range runeGlyphs {
+		runes = append(runes, r)
+	}
+	slices.Sort(runes)
+	groups := []group{}
+	for _, r := range runes {
+		if last := len(groups) - 1; last >= 0 && groups[last].end+1 == r && int(groups[last].glyph)+int(r-groups[last].start) == int(runeGlyphs[r]) {
+			groups[last].end = r
+			continue
+		}
+		groups = append(groups, group{start: r, end: r, glyph: runeGlyphs[r]})
+	}
+
+	format12 := make([]byte, 16+12*len(groups))
+	binary.BigEndian.PutUint16(format12, 12)
+	binary.BigEndian.PutUint32(format12[4:], uint32(len(format12)))
+	binary.BigEndian.PutUint32(format12[12:], uint32(len(groups)))
+	for i, g := range groups {
+		binary.BigEndian.PutUint32(format12[16+12*i:], uint32(g.start))
+		binary.BigEndian.PutUint32(format12[20+12*i:], uint32(g.end))
+		binary.BigEndian.PutUint32(format12[24+12*i:], uint32(g.glyph))
+	}
+
+	// The last segment maps 0xFFFF to the missing glyph, as the format requires.
+	segments := slices.DeleteFunc(slices.Clone(groups), func(g group) bool {
+		return g.end >= 0xFFFF
+	})
+	segments = append(segments, group{start: 0xFFFF, end: 0xFFFF, glyph: 0})
+	segCount := len(segments)
+	subtables := [][]byte{format12}
+	platformEncodings := []uint16{10}
+	if length := 16 + 8*segCount; length <= 0xFFFF {
+		format4 := make([]byte, length)
+		searchRange := 2 << (bits.Len(uint(segCount)) - 1)
+		binary.BigEndian.PutUint16(format4, 4)
+		binary.BigEndian.PutUint16(format4[2:], uint16(length))
+		binary.BigEndian.PutUint16(format4[6:], uint16(2*segCount))
+		binary.BigEndian.PutUint16(format4[8:], uint16(searchRange))
+		binary.BigEndian.PutUint16(format4[10:], uint16(bits.Len(uint(segCount))-1))
+		binary.BigEndian.PutUint16(format4[12:], uint16(2*segCount-searchRange))
+		endCodes := format4[14:]
+		startCodes := endCodes[2*segCount+2:]
+		idDeltas := startCodes[2*segCount:]
+		// The idRangeOffsets that follow are all 0, as each segment maps to consecutive glyphs by its idDelta.
+		for i, segment := range segments {
+			binary.BigEndian.PutUint16(endCodes[2*i:], uint16(segment.end))
+			binary.BigEndian.PutUint16(startCodes[2*i:], uint16(segment.start))
+			binary.BigEndian.PutUint16(idDeltas[2*i:], segment.glyph-uint16(segment.start))
+		}
+		subtables = [][]byte{format4, format12}
+		platformEncodings = []uint16{1, 10}
+	}
+
+	// Both subtables are for the Windows platform, with the Unicode BMP and the full Unicode encodings.
+	cmap := make([]byte, 4+8*len(subtables))
+	binary.BigEndian.PutUint16(cmap[2:], uint16(len(subtables)))
+	for i, subtable := range subtables {
+		binary.BigEndian.PutUint16(cmap[4+8*i:], 3)
+		binary.BigEndian.PutUint16(cmap[6+8*i:], platformEncodings[i])
+		binary.BigEndian.PutUint32(cmap[8+8*i:], uint32(len(cmap)))
+		cmap = append(cmap, subtable...)
+	}
+	return cmap
+}
+
+// Returns the tables of a TrueType or OpenType font keyed by their tags.
+func readTables(data []byte) (map[string][]byte, error) {
+	if len(data) < 12 {
+		return nil, errors.New("the font is truncated")
+	}
+	switch string(data[:4]) {
+	case "\x00\x01\x00\x00", "true", "OTTO":
+	default:
+		return nil, fmt.Errorf("unsupported font format %q", data[:4])
+	}
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	if len(data) < 12+16*numTables {
+		return nil, errors.New("the table directory is truncated")
+	}
+	tables := map[string][]byte{}
+	for i := 0; i < numTables; i++ {
+		record := data[12+16*i:]
+		offset, length := int(binary.BigEndian.Uint32(record[8:])), int(binary.BigEndian.Uint32(record[12:]))
+		if offset+length > len(data) {
+			return nil, fmt.Errorf("the %q table is out of the font", record[:4])
+		}
+		tables[string(record[:4])] = data[offset : offset+length]
+	}
+	return tables, nil
+}
+
+// Returns the font of the tables, with the same version as the original font.
+func writeFont(sfntVersion []byte, tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	numTables := len(tags)
+	entrySelector := bits.Len(uint(numTables)) - 1
+	searchRange := 16 << entrySelector
+	font := make([]byte, 12+16*numTables)
+	copy(font, sfntVersion)
+	binary.BigEndian.PutUint16(font[4:], uint16(numTables))
+	binary.BigEndian.PutUint16(font[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(font[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(font[10:], uint16(16*numTables-searchRange))
+
+	headOffset := -1
+	for i, tag := range tags {
+		table := tables[tag]
+		if tag == "head" {
+			headOffset = len(font)
+			// The checksum of the head table is computed with the adjustment of the whole font set to 0.
+			table = slices.Clone(table)
+			binary.BigEndian.PutUint32(table[8:], 0)
+		}
+		record := font[12+16*i:]
+		copy(record, tag)
+		binary.BigEndian.PutUint32(record[4:], checksum(table))
+		binary.BigEndian.PutUint32(record[8:], uint32(len(font)))
+		binary.BigEndian.PutUint32(record[12:], uint32(len(table)))
+		font = append(font, table...)
+		for len(font)%4 != 0 {
+			font = append(font, 0)
+		}
+	}
+	if headOffset >= 0 {
+		binary.BigEndian.PutUint32(font[headOffset+8:], 0xB1B0AFBA-checksum(font))
+	}
+	return font
+}
+
+// Returns the sum of the data as big-endian 32-bit integers, padded with zeros.
+func checksum(data []byte) uint32 {
+	sum := uint32(0)
+	for i := 0; i < len(data); i += 4 {
+		word := [4]byte{}
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
@@ -0,0 +1,487 @@
+package font
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Operators of the CFF DICTs that refer to other data of the table. Two-byte operators start with 12.
+const (
+	CFF_CHARSET_OPERATOR      = 15
+	CFF_ENCODING_OPERATOR     = 16
+	CFF_CHAR_STRINGS_OPERATOR = 17
+	CFF_PRIVATE_OPERATOR      = 18
+	CFF_SUBRS_OPERATOR        = 19
+	CFF_ROS_OPERATOR          = 12<<8 | 30
+	CFF_FD_ARRAY_OPERATOR     = 12<<8 | 36
+	CFF_FD_SELECT_OPERATOR    = 12<<8 | 37
+)
+
+// Returns the CFF table with the charstrings of the glyphs kept.
+// The subroutines, which charstrings call by their indices, are kept as they are.
+// The table is laid out again, with every offset written in five bytes, so that the sizes of the DICTs
+// do not depend on the offsets they hold.
+func (s *subsetter) subsetCFF() ([]byte, error) {
+	cff := s.tables["CFF "]
+	if len(cff) < 4 {
+		return nil, errors.New("the CFF table is truncated")
+	}
+	headerSize := int(cff[2])
+	_, nameEnd, err := readCFFIndex(cff, headerSize)
+	if err != nil {
+		return nil, err
+	}
+	topDicts, topDictEnd, err := readCFFIndex(cff, nameEnd)
+	if err != nil {
+		return nil, err
+	}
+	if len(topDicts) != 1 {
+		return nil, errors.New("CFF tables of several fonts are not supported")
+	}
+	_, stringEnd, err := readCFFIndex(cff, topDictEnd)
+	if err != nil {
+		return nil, err
+	}
+	_, globalSubrEnd, err := readCFFIndex(cff, stringEnd)
+	if err != nil {
+		return nil, err
+	}
+	topDict, err := readCFFDict(topDicts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	charStringsEntry, ok := findCFFDictEntry(topDict, CFF_CHAR_STRINGS_OPERATOR)
+	if !ok || len(charStringsEntry.values) != 1 {
+		return nil, errors.New("the charstrings are missing")
+	}
+	charStrings, _, err := readCFFIndex(cff, charStringsEntry.values[0])
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range s.glyphs {
+		if int(index) >= len(charStrings) {
+			return nil, fmt.Errorf("glyph %d is missing from the charstrings", index)
+		}
+	}
+	charsetOffset := 0
+	if entry, ok := findCFFDictEntry(topDict, CFF_CHARSET_OPERATOR); ok && len(entry.values) == 1 {
+		charsetOffset = entry.values[0]
+	}
+	names, err := readCFFCharset(cff, charsetOffset, len(charStrings))
+	if err != nil {
+		return nil, err
+	}
+
+	// The charset of the subset, which names each glyph but the first, in format 0.
+	newCharset := []byte{0}
+	newCharStrings := [][]byte{}
+	for i, index := range s.glyphs {
+		if i > 0 {
+			newCharset = binary.BigEndian.AppendUint16(newCharset, names[index])
+		}
+		newCharStrings = append(newCharStrings, charStrings[index])
+	}
+	newCharStringsIndex := writeCFFIndex(newCharStrings)
+
+	// CID-keyed fonts select one of several font DICTs for each glyph, each with its own private DICT.
+	_, isCIDKeyed := findCFFDictEntry(topDict, CFF_ROS_OPERATOR)
+	var newFDSelect []byte
+	var fontDicts [][]cffDictEntry
+	// The private DICTs followed by their local subroutines, for the top DICT or for each font DICT.
+	privates := []cffPrivate{}
+	if isCIDKeyed {
+		fdArrayEntry, ok := findCFFDictEntry(topDict, CFF_FD_ARRAY_OPERATOR)
+		fdSelectEntry, ok2 := findCFFDictEntry(topDict, CFF_FD_SELECT_OPERATOR)
+		if !ok || !ok2 || len(fdArrayEntry.values) != 1 || len(fdSelectEntry.values) != 1 {
+			return nil, errors.New("the font DICTs of the CID-keyed font are missing")
+		}
+		fdSelect, err := readCFFFDSelect(cff, fdSelectEntry.values[0], len(charStrings))
+		if err != nil {
+			return nil, err
+		}
+		// The FDSelect of the subset, in format 0.
+		newFDSelect = []byte{0}
+		for _, index := range s.glyphs {
+			newFDSelect = append(newFDSelect, fdSelect[index])
+		}
+		fdArray, _, err := readCFFIndex(cff, fdArrayEntry.values[0])
+		if err != nil {
+			return nil, err
+		}
+		for _, fontDictData := range fdArray {
+			fontDict, err := readCFFDict(fontDictData)
+			if err != nil {
+				return nil, err
+			}
+			private, err := readCFFPrivate(cff, fontDict)
+			if err != nil {
+				return nil, err
+			}
+			fontDicts = append(fontDicts, fontDict)
+			privates = append(privates, private)
+		}
+	} else {
+		private, err := readCFFPrivate(cff, topDict)
+		if err != nil {
+			return nil, err
+		}
+		privates = append(privates, private)
+	}
+
+	// Lays out the table as the header and the INDEXes up to the global subroutines, followed by the charset,
+	// the FDSelect, the charstrings, the font DICTs, and the private DICTs.
+	newTopDict := func(charsetOffset int, fdSelectOffset int, charStringsOffset int, fdArrayOffset int, privateOffset int) []byte {
+		entries := []cffDictEntry{}
+		for _, entry := range topDict {
+			switch entry.operator {
+			// The encoding maps character codes of PostScript, which the cmap table maps in OpenType fonts.
+			case CFF_ENCODING_OPERATOR, CFF_CHARSET_OPERATOR, CFF_CHAR_STRINGS_OPERATOR, CFF_PRIVATE_OPERATOR, CFF_FD_ARRAY_OPERATOR, CFF_FD_SELECT_OPERATOR:
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		entries = append(entries,
+			cffDictEntry{operator: CFF_CHARSET_OPERATOR, operands: cffOffsetOperands(charsetOffset)},
+			cffDictEntry{operator: CFF_CHAR_STRINGS_OPERATOR, operands: cffOffsetOperands(charStringsOffset)},
+		)
+		if isCIDKeyed {
+			entries = append(entries,
+				cffDictEntry{operator: CFF_FD_SELECT_OPERATOR, operands: cffOffsetOperands(fdSelectOffset)},
+				cffDictEntry{operator: CFF_FD_ARRAY_OPERATOR, operands: cffOffsetOperands(fdArrayOffset)},
+			)
+		} else {
+			entries = append(entries, cffDictEntry{operator: CFF_PRIVATE_OPERATOR, operands: cffOffsetOperands(len(privates[0].dict), privateOffset)})
+		}
+		return writeCFFIndex([][]byte{writeCFFDict(entries)})
+	}
+	newFDArray := func(privateOffset int) []byte {
+		fontDictData := [][]byte{}
+		for i, fontDict := range fontDicts {
+			entries := []cffDictEntry{}
+			for _, entry := range fontDict {
+				if entry.operator != CFF_PRIVATE_OPERATOR {
+					entries = append(entries, entry)
+				}
+			}
+			entries = append(entries, cffDictEntry{operator: CFF_PRIVATE_OPERATOR, operands: cffOffsetOperands(len(privates[i].dict), privateOffset)})
+			fontDictData = append(fontDictData, writeCFFDict(entries))
+			privateOffset += len(privates[i].dict) + len(privates[i].subrs)
+		}
+		return writeCFFIndex(fontDictData)
+	}
+
+	charsetStart := nameEnd + len(newTopDict(0, 0, 0, 0, 0)) + (globalSubrEnd - topDictEnd)
+	fdSelectStart := charsetStart + len(newCharset)
+	charStringsStart := fdSelectStart + len(newFDSelect)
+	fdArrayStart := charStringsStart + len(newCharStringsIndex)
+	privateStart := fdArrayStart
+	if isCIDKeyed {
+		privateStart += len(newFDArray(0))
+	}
+
+	newCFF := append([]byte{}, cff[:nameEnd]...)
+	newCFF = append(newCFF, newTopDict(charsetStart, fdSelectStart, charStringsStart, fdArrayStart, privateStart)...)
+	newCFF = append(newCFF, cff[topDictEnd:globalSubrEnd]...)
+	newCFF = append(newCFF, newCharset...)
+	newCFF = append(newCFF, newFDSelect...)
+	newCFF = append(newCFF, newCharStringsIndex...)
+	if isCIDKeyed {
+		newCFF = append(newCFF, newFDArray(privateStart)...)
+	}
+	for _, private := range privates {
+		newCFF = append(newCFF, private.dict...)
+		newCFF = append(newCFF, private.subrs...)
+	}
+	return newCFF, nil
+}
+
+// An entry of a CFF DICT, as its operator and its encoded operands.
+type cffDictEntry struct {
+	operator int
+	operands []byte
+	// The integer operands, with real numbers as 0, as the entries referring to other data only have integers.
+	values []int
+}
+
+func findCFFDictEntry(entries []cffDictEntry, operator int) (cffDictEntry, bool) {
+	for _, entry := range entries {
+		if entry.operator == operator {
+			return entry, true
+		}
+	}
+	return cffDictEntry{}, false
+}
+
+func readCFFDict(data []byte) ([]cffDictEntry, error) {
+	entries := []cffDictEntry{}
+	operandStart := 0
+	values := []int{}
+	for i := 0; i < len(data); {
+		b0 := data[i]
+		switch {
+		case b0 <= 21:
+			operatorStart := i
+			operator := int(b0)
+			i++
+			if b0 == 12 {
+				if i >= len(data) {
+					return nil, errors.New("a CFF DICT operator is truncated")
+				}
+				operator = 12<<8 | int(data[i])
+				i++
+			}
+			entries = append(entries, cffDictEntry{operator: operator, operands: data[operandStart:operatorStart], values: values})
+			operandStart = i
+			values = []int{}
+		case b0 == 28 && i+3 <= len(data):
+			values = append(values, int(int16(binary.BigEndian.Uint16(data[i+1:]))))
+			i += 3
+		case b0 == 29 && i+5 <= len(data):
+			values = append(values, int(int32(binary.BigEndian.Uint32(data[i+1:]))))
+			i += 5
+		case b0 == 30:
+			// Real numbers are packed in nibbles, and end with the nibble 0xF.
+			for i++; i < len(data); i++ {
+				if data[i]&0x0F == 0x0F || data[i]>>4 == 0x0F {
+					break
+				}
+			}
+			i++
+			values = append(values, 0)
+		case b0 >= 32 && b0 <= 246:
+			values = append(values, int(b0)-139)
+			i++
+		case b0 >= 247 && b0 <= 250 && i+2 <= len(data):
+			values = append(values, (int(b0)-247)*256+int(data[i+1])+108)
+			i += 2
+		case b0 >= 251 && b0 <= 254 && i+2 <= len(data):
+			values = append(values, -(int(b0)-251)*256-int(data[i+1])-108)
+			i += 2
+		default:
+			return nil, fmt.Errorf("invalid CFF DICT operand %d", b0)
+		}
+	}
+	return entries, nil
+}
+
+func writeCFFDict(entries []cffDictEntry) []byte {
+	data := []byte{}
+	for _, entry := range entries {
+		data = append(data, entry.operands...)
+		if entry.operator>>8 == 12 {
+			data = append(data, 12, byte(entry.operator))
+		} else {
+			data = append(data, byte(entry.operator))
+		}
+	}
+	return data
+}
+
+// Encodes the integers in five bytes each, whatever their values.
+func cffOffsetOperands(values ...int) []byte {
+	operands := []byte{}
+	for _, value := range values {
+		operands = append(operands, 29)
+		operands = binary.BigEndian.AppendUint32(operands, uint32(value))
+	}
+	return operands
+}
+
+// Returns the items of the CFF INDEX at the offset, and the offset where the INDEX ends.
+func readCFFIndex(cff []byte, offset int) ([][]byte, int, error) {
+	if offset < 0 || offset+2 > len(cff) {
+		return nil, 0, errors.New("a CFF INDEX is out of the table")
+	}
+	count := int(binary.BigEndian.Uint16(cff[offset:]))
+	if count == 0 {
+		return nil, offset + 2, nil
+	}
+	if offset+3 > len(cff) {
+		return nil, 0, errors.New("a CFF INDEX is truncated")
+	}
+	offSize := int(cff[offset+2])
+	offsetsStart := offset + 3
+	// Offsets start from 1, relative to the byte before the data.
+	dataStart := offsetsStart + (count+1)*offSize - 1
+	if offSize < 1 || offSize > 4 || dataStart >= len(cff) {
+		return nil, 0, errors.New("a CFF INDEX is truncated")
+	}
+	itemOffset := func(i int) int {
+		value := 0
+		for _, b := range cff[offsetsStart+i*offSize : offsetsStart+(i+1)*offSize] {
+			value = value<<8 | int(b)
+		}
+		return dataStart + value
+	}
+
+	items := make([][]byte, count)
+	for i := range items {
+		start, end := itemOffset(i), itemOffset(i+1)
+		if start > end || end > len(cff) {
+			return nil, 0, errors.New("a CFF INDEX item is out of the table")
+		}
+		items[i] = cff[start:end]
+	}
+	return items, itemOffset(count), nil
+}
+
+func writeCFFIndex(items [][]byte) []byte {
+	if len(items) == 0 {
+		return []byte{0, 0}
+	}
+	lastOffset := 1
+	for _, item := range items {
+		lastOffset += len(item)
+	}
+	offSize := 1
+	for lastOffset >= 1<<(8*offSize) {
+		offSize++
+	}
+
+	index := binary.BigEndian.AppendUint16(nil, uint16(len(items)))
+	index = append(index, byte(offSize))
+	appendOffset := func(offset int) {
+		for shift := 8 * (offSize - 1); shift >= 0; shift -= 8 {
+			index = append(index, byte(offset>>shift))
+		}
+	}
+	offset := 1
+	appendOffset(offset)
+	for _, item := range items {
+		offset += len(item)
+		appendOffset(offset)
+	}
+	for _, item := range items {
+		index = append(index, item...)
+	}
+	return index
+}
+
+// Returns the string ID of every glyph, or its CID in CID-keyed fonts, from the charset at the offset.
+func readCFFCharset(cff []byte, offset int, numGlyphs int) ([]uint16, error) {
+	names := make([]uint16, numGlyphs)
+	switch offset {
+	case 0:
+		// The predefined ISOAdobe charset names the glyphs by their indices.
+		for i := range names {
+			names[i] = uint16(i)
+		}
+		return names, nil
+	case 1, 2:
+		return nil, errors.New("the predefined Expert charsets are not supported")
+	}
+	if offset >= len(cff) {
+		return nil, errors.New("the CFF charset is out of the table")
+	}
+
+	format := cff[offset]
+	position := offset + 1
+	for i := 1; i < numGlyphs; {
+		switch format {
+		case 0:
+			if position+2 > len(cff) {
+				return nil, errors.New("the CFF charset is truncated")
+			}
+			names[i] = binary.BigEndian.Uint16(cff[position:])
+			position += 2
+			i++
+		case 1, 2:
+			rangeSize := 3
+			if format == 2 {
+				rangeSize = 4
+			}
+			if position+rangeSize > len(cff) {
+				return nil, errors.New("the CFF charset is truncated")
+			}
+			first := binary.BigEndian.Uint16(cff[position:])
+			left := int(cff[position+2])
+			if format == 2 {
+				left = int(binary.BigEndian.Uint16(cff[position+2:]))
+			}
+			position += rangeSize
+			for j := 0; j <= left && i < numGlyphs; j++ {
+				names[i] = first + uint16(j)
+				i++
+			}
+		default:
+			return nil, fmt.Errorf("unsupported CFF charset format %d", format)
+		}
+	}
+	return names, nil
+}
+
+// Returns the index of the font DICT of every glyph from the FDSelect at the offset.
+func readCFFFDSelect(cff []byte, offset int, numGlyphs int) ([]byte, error) {
+	if offset < 0 || offset >= len(cff) {
+		return nil, errors.New("the CFF FDSelect is out of the table")
+	}
+	switch cff[offset] {
+	case 0:
+		if offset+1+numGlyphs > len(cff) {
+			return nil, errors.New("the CFF FDSelect is truncated")
+		}
+		return cff[offset+1 : offset+1+numGlyphs], nil
+	case 3:
+		if offset+3 > len(cff) {
+			return nil, errors.New("the CFF FDSelect is truncated")
+		}
+		rangeCount := int(binary.BigEndian.Uint16(cff[offset+1:]))
+		ranges := cff[offset+3:]
+		// The ranges are followed by the end of the last range.
+		if len(ranges) < 3*rangeCount+2 {
+			return nil, errors.New("the CFF FDSelect is truncated")
+		}
+		fds := make([]byte, numGlyphs)
+		for i := 0; i < rangeCount; i++ {
+			first, end := int(binary.BigEndian.Uint16(ranges[3*i:])), int(binary.BigEndian.Uint16(ranges[3*i+3:]))
+			for index := first; index < min(end, numGlyphs); index++ {
+				fds[index] = ranges[3*i+2]
+			}
+		}
+		return fds, nil
+	default:
+		return nil, fmt.Errorf("unsupported CFF FDSelect format %d", cff[offset])
+	}
+}
+
+// A private DICT with the local subroutines that follow it.
+type cffPrivate struct {
+	dict  []byte
+	subrs []byte
+}
+
+// Returns the private DICT that the top DICT or a font DICT refers to, with its local subroutines moved right after it.
+func readCFFPrivate(cff []byte, dict []cffDictEntry) (cffPrivate, error) {
+	entry, ok := findCFFDictEntry(dict, CFF_PRIVATE_OPERATOR)
+	if !ok || len(entry.values) != 2 {
+		return cffPrivate{}, errors.New("the CFF private DICT is missing")
+	}
+	size, offset := entry.values[0], entry.values[1]
+	if size < 0 || offset < 0 || offset+size > len(cff) {
+		return cffPrivate{}, errors.New("the CFF private DICT is out of the table")
+	}
+	private, err := readCFFDict(cff[offset : offset+size])
+	if err != nil {
+		return cffPrivate{}, err
+	}
+
+	subrs := []byte{}
+	for i, privateEntry := range private {
+		if privateEntry.operator != CFF_SUBRS_OPERATOR || len(privateEntry.values) != 1 {
+			continue
+		}
+		// The offset of the local subroutines is relative to the private DICT.
+		subrsOffset := offset + privateEntry.values[0]
+		_, subrsEnd, err := readCFFIndex(cff, subrsOffset)
+		if err != nil {
+			return cffPrivate{}, err
+		}
+		subrs = cff[subrsOffset:subrsEnd]
+		// The operand takes five bytes whatever its value, so the DICT is measured with any offset.
+		private[i].operands = cffOffsetOperands(0)
+		private[i].operands = cffOffsetOperands(len(writeCFFDict(private)))
+	}
+	return cffPrivate{dict: writeCFFDict(private), subrs: subrs}, nil
+}
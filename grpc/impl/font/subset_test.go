@@ -0,0 +1,84 @@
+package font
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	xfont "golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestSubset(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		text string
+		// Runes the font lacks, which the subset lacks as well.
+		missing string
+	}{
+		// Accented letters are composite glyphs, made of the glyphs of the letters and the accents.
+		{name: "Latin", path: "../../cmd/fonts/English/SansSerif-Bold.ttf", text: "Café Ångström, naïve!", missing: "予約"},
+		{name: "Japanese", path: "../../cmd/fonts/Japanese/SansSerif-Regular.ttf", text: "予約確認書、コーヒー。ABC", missing: "예약"},
+		{name: "Korean", path: "../../cmd/fonts/Korean/SansSerif-Regular.ttf", text: "예약 확인서 ABC", missing: "ไทย"},
+		// CFF outlines with two font DICTs, whose glyphs "Q" and "中" are drawn by the local subroutines of the second.
+		// "0" is left out, so that the glyphs of the second font DICT move in the subset.
+		{name: "CID-keyed CFF", path: "testdata/CIDTest.otf", text: "1Q中", missing: "A"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := os.ReadFile(test.path)
+			if err != nil {
+				t.Fatalf("failed to read the font: %v", err)
+			}
+			original, err := parse(data)
+			if err != nil {
+				t.Fatalf("failed to parse the font: %v", err)
+			}
+			defer forget(original)
+
+			subsetData, err := Subset(original, []rune(test.text+test.missing))
+			if err != nil {
+				t.Fatalf("failed to subset the font: %v", err)
+			}
+			// The fonts of megabytes shrink to a small fraction, unlike the test font of a few glyphs.
+			if len(data) > 100*1024 && len(subsetData) > len(data)/50 {
+				t.Errorf("got %d bytes, want a small fraction of the %d bytes of the font", len(subsetData), len(data))
+			}
+			subset, err := sfnt.Parse(subsetData)
+			if err != nil {
+				t.Fatalf("failed to parse the subset: %v", err)
+			}
+
+			originalBuffer, subsetBuffer := &sfnt.Buffer{}, &sfnt.Buffer{}
+			for _, r := range test.text {
+				originalIndex, _ := original.GlyphIndex(originalBuffer, r)
+				subsetIndex, err := subset.GlyphIndex(subsetBuffer, r)
+				if err != nil || subsetIndex == 0 {
+					t.Errorf("%q: got glyph %d and error %v, want the glyph kept", r, subsetIndex, err)
+					continue
+				}
+				originalAdvance, _ := original.GlyphAdvance(originalBuffer, originalIndex, fixed.I(100), xfont.HintingNone)
+				subsetAdvance, _ := subset.GlyphAdvance(subsetBuffer, subsetIndex, fixed.I(100), xfont.HintingNone)
+				if subsetAdvance != originalAdvance {
+					t.Errorf("%q: got advance %v, want %v", r, subsetAdvance, originalAdvance)
+				}
+				// The buffers are reused by the next call, so the segments are copied.
+				originalSegments, _ := original.LoadGlyph(originalBuffer, originalIndex, fixed.I(100), nil)
+				originalSegments = slices.Clone(originalSegments)
+				subsetSegments, err := subset.LoadGlyph(subsetBuffer, subsetIndex, fixed.I(100), nil)
+				if err != nil || !slices.Equal(subsetSegments, originalSegments) {
+					t.Errorf("%q: got an outline of %d segments and error %v, want the %d segments of the font",
+						r, len(subsetSegments), err, len(originalSegments))
+				}
+			}
+			for _, r := range test.missing {
+				if subsetIndex, _ := subset.GlyphIndex(subsetBuffer, r); subsetIndex != 0 {
+					t.Errorf("%q: got glyph %d, want the rune the font lacks to stay missing", r, subsetIndex)
+				}
+			}
+		})
+	}
+}
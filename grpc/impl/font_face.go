@@ -0,0 +1,154 @@
+package impl
+
+import (
+	"image"
+	"strings"
+
+	"github.com/lucasb-eyer/go-colorful"
+
+	"github.com/visionex-project/visionex/grpc/impl/font"
+	"github.com/visionex-project/visionex/pkg/utils"
+)
+
+const (
+	// Strokes of smaller text are only a pixel or two wide, which is too few to compare their widths.
+	MIN_STROKE_ANALYSIS_FONT_SIZE = 16
+	// Serif faces such as Times New Roman and Mincho have vertical strokes at least this many times wider
+	// than their horizontal strokes, while sans serif faces have strokes of about the same width.
+	SERIF_STROKE_CONTRAST = 1.5
+	// Runs narrower than this many pixels are anti-aliasing noise rather than strokes.
+	MIN_STROKE_WIDTH = 0.5
+	// Fewer runs than this are too few to tell the typical stroke width.
+	MIN_STROKE_RUN_COUNT = 10
+)
+
+// Keywords of the font type from Document AI, checked in order. E.g., "SANS_SERIF" is sans serif, not serif.
+var fontTypeKeywords = []struct {
+	keyword string
+	face    font.FontFace
+}{
+	{"SANS", font.FontFaceSansSerif},
+	{"GOTHIC", font.FontFaceSansSerif},
+	{"MONO", font.FontFaceMonospace},
+	{"ROUND", font.FontFaceRounded},
+	{"HAND", font.FontFaceHandwriting},
+	{"SCRIPT", font.FontFaceHandwriting},
+	{"SERIF", font.FontFaceSerif},
+	{"MINCHO", font.FontFaceSerif},
+	{"MYEONGJO", font.FontFaceSerif},
+}
+
+// Returns the face from the style info of Document AI, or empty when it does not tell the face.
+// E.g., "SERIF" -> Serif
+func fontFaceFromStyleInfo(fontType string, handwritten bool) font.FontFace {
+	if handwritten {
+		return font.FontFaceHandwriting
+	}
+	normalizedFontType := strings.ToUpper(strings.ReplaceAll(fontType, " ", "_"))
+	for _, fontTypeKeyword := range fontTypeKeywords {
+		if strings.Contains(normalizedFontType, fontTypeKeyword.keyword) {
+			return fontTypeKeyword.face
+		}
+	}
+	return ""
+}
+
+// Picks the face of every word whose face Document AI did not tell, by analyzing its strokes in the original image.
+// The strokes only tell serif text from sans serif text, so they are not analyzed when the target language
+// has no serif fonts installed, as the text would be drawn in sans serif anyway.
+// The styles are copied, as they are shared between words.
+func detectFontFaces(originImage image.Image, paragraphs []paragraphSegment, targetLanguageFonts *font.FontsByFace) []paragraphSegment {
+	if targetLanguageFonts.Serif == nil {
+		return paragraphs
+	}
+	return utils.Map(paragraphs, func(paragraph paragraphSegment) paragraphSegment {
+		paragraph.lines = utils.Map(paragraph.lines, func(line lineSegment) lineSegment {
+			line.words = utils.Map(line.words, func(word wordSegment) wordSegment {
+				if word.style.fontFace != "" {
+					return word
+				}
+				faceStyle := *word.style
+				faceStyle.fontFace = classifyStrokes(originImage, word)
+				word.style = &faceStyle
+				return word
+			})
+			return line
+		})
+		return paragraph
+	})
+}
+
+// Tells serif text from sans serif text by the contrast between the widths of vertical and horizontal strokes.
+// The runs of text pixels along rows cross vertical strokes, and those along columns cross horizontal strokes,
+// so their typical widths are the widths of each kind of stroke. Anti-aliased pixels count partly.
+func classifyStrokes(originImage image.Image, word wordSegment) font.FontFace {
+	fontSize := float64(word.style.height)
+	if word.fontSize != nil && *word.fontSize > 0 {
+		fontSize = *word.fontSize
+	}
+	box := image.Rect(int(word.position.left), int(word.position.top), int(word.position.right), int(word.position.bottom)).
+		Intersect(originImage.Bounds())
+	if fontSize < MIN_STROKE_ANALYSIS_FONT_SIZE || box.Empty() {
+		return font.FontFaceSansSerif
+	}
+
+	pixels := newPixelRegion(originImage, box)
+	// How much of each pixel is covered by the text, judging from how close its color is to the text color.
+	backgroundColor, _ := dominantColor(utils.Filter(pixels.colors, func(color colorful.Color) bool {
+		return color.DistanceRgb(word.style.textColor) >= PIXEL_COLOR_DISTANCE_THRESHOLD
+	}))
+	contrast := backgroundColor.DistanceRgb(word.style.textColor)
+	if contrast < PIXEL_COLOR_DISTANCE_THRESHOLD {
+		return font.FontFaceSansSerif
+	}
+	coverage := func(x int, y int) float64 {
+		return max(0, 1-pixels.at(x, y).DistanceRgb(word.style.textColor)/contrast)
+	}
+
+	// Runs longer than half the font size run along a stroke rather than across it.
+	maxRunWidth := fontSize / 2
+	horizontalRuns := []float64{}
+	for y := box.Min.Y; y < box.Max.Y; y++ {
+		horizontalRuns = appendRuns(horizontalRuns, box.Min.X, box.Max.X, maxRunWidth, func(x int) float64 {
+			return coverage(x, y)
+		})
+	}
+	verticalRuns := []float64{}
+	for x := box.Min.X; x < box.Max.X; x++ {
+		verticalRuns = appendRuns(verticalRuns, box.Min.Y, box.Max.Y, maxRunWidth, func(y int) float64 {
+			return coverage(x, y)
+		})
+	}
+	if len(horizontalRuns) < MIN_STROKE_RUN_COUNT || len(verticalRuns) < MIN_STROKE_RUN_COUNT {
+		return font.FontFaceSansSerif
+	}
+
+	verticalStrokeWidth := median(horizontalRuns)
+	horizontalStrokeWidth := median(verticalRuns)
+	if verticalStrokeWidth >= SERIF_STROKE_CONTRAST*horizontalStrokeWidth {
+		return font.FontFaceSerif
+	}
+	return font.FontFaceSansSerif
+}
+
+// Appends the widths of the runs of text between start and end, leaving out runs wider than maxWidth.
+// The width of a run is the sum of the coverages of its pixels.
+func appendRuns(runs []float64, start int, end int, maxWidth float64, coverage func(i int) float64) []float64 {
+	width := 0.0
+	for i := start; i <= end; i++ {
+		if i < end && coverage(i) > 0 {
+			width += coverage(i)
+			continue
+		}
+		// Runs of only faint pixels are noise rather than strokes.
+		if width >= MIN_STROKE_WIDTH && width <= maxWidth {
+			runs = append(runs, width)
+		}
+		width = 0
+	}
+	return runs
+}
+
+func median(values []float64) float64 {
+	return utils.Sort(values)[len(values)/2]
+}
@@ -0,0 +1,84 @@
+package impl
+
+import (
+	"image"
+	"image/draw"
+	"testing"
+
+	"github.com/lucasb-eyer/go-colorful"
+
+	"github.com/visionex-project/visionex/grpc/impl/font"
+)
+
+// Draws black "H" shaped glyphs on a white image, whose vertical and horizontal strokes have the given widths,
+// and returns the image along with the word that boxes them.
+// The bars are taller and the crossbars wider than half the font size, so only the strokes are crossed by short runs.
+func strokeGlyphs(glyphCount int, verticalStrokeWidth int, horizontalStrokeWidth int, crossbarWidth int, fontSize float64) (image.Image, wordSegment) {
+	const left, top, height, gap = 20, 20, 30, 10
+	glyphWidth := 2*verticalStrokeWidth + crossbarWidth
+	img := image.NewRGBA(image.Rect(0, 0, 2*left+glyphCount*(glyphWidth+gap), 2*top+height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	fill := func(rect image.Rectangle) {
+		draw.Draw(img, rect, image.Black, image.Point{}, draw.Src)
+	}
+	for i := 0; i < glyphCount; i++ {
+		glyphLeft := left + i*(glyphWidth+gap)
+		fill(image.Rect(glyphLeft, top, glyphLeft+verticalStrokeWidth, top+height))
+		fill(image.Rect(glyphLeft+verticalStrokeWidth+crossbarWidth, top, glyphLeft+glyphWidth, top+height))
+		middle := top + height/2
+		fill(image.Rect(glyphLeft+verticalStrokeWidth, middle, glyphLeft+verticalStrokeWidth+crossbarWidth, middle+horizontalStrokeWidth))
+	}
+
+	size := fontSize
+	return img, wordSegment{
+		position: position{left: left, top: top, right: int32(img.Bounds().Dx() - left), bottom: top + height},
+		fontSize: &size,
+		style:    &style{textColor: colorful.Color{}, height: height},
+	}
+}
+
+func TestClassifyStrokes(t *testing.T) {
+	tests := []struct {
+		name                  string
+		glyphCount            int
+		verticalStrokeWidth   int
+		horizontalStrokeWidth int
+		crossbarWidth         int
+		fontSize              float64
+		expected              font.FontFace
+	}{
+		{name: "even strokes", glyphCount: 5, verticalStrokeWidth: 4, horizontalStrokeWidth: 4, crossbarWidth: 20, fontSize: 40, expected: font.FontFaceSansSerif},
+		{name: "high contrast strokes", glyphCount: 5, verticalStrokeWidth: 8, horizontalStrokeWidth: 2, crossbarWidth: 20, fontSize: 40, expected: font.FontFaceSerif},
+		// 6 / 4 is SERIF_STROKE_CONTRAST itself, and 5 / 4 is below it.
+		{name: "contrast at the threshold", glyphCount: 5, verticalStrokeWidth: 6, horizontalStrokeWidth: 4, crossbarWidth: 20, fontSize: 40, expected: font.FontFaceSerif},
+		{name: "contrast below the threshold", glyphCount: 5, verticalStrokeWidth: 5, horizontalStrokeWidth: 4, crossbarWidth: 20, fontSize: 40, expected: font.FontFaceSansSerif},
+		// A single crossbar of 9 pixels gives 9 vertical runs, one fewer than MIN_STROKE_RUN_COUNT.
+		{name: "too few runs", glyphCount: 1, verticalStrokeWidth: 8, horizontalStrokeWidth: 2, crossbarWidth: MIN_STROKE_RUN_COUNT - 1, fontSize: 40, expected: font.FontFaceSansSerif},
+		{name: "just enough runs", glyphCount: 1, verticalStrokeWidth: 8, horizontalStrokeWidth: 2, crossbarWidth: MIN_STROKE_RUN_COUNT, fontSize: 40, expected: font.FontFaceSerif},
+		{name: "text too small", glyphCount: 5, verticalStrokeWidth: 8, horizontalStrokeWidth: 2, crossbarWidth: 20, fontSize: MIN_STROKE_ANALYSIS_FONT_SIZE - 1, expected: font.FontFaceSansSerif},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			img, word := strokeGlyphs(test.glyphCount, test.verticalStrokeWidth, test.horizontalStrokeWidth, test.crossbarWidth, test.fontSize)
+			if face := classifyStrokes(img, word); face != test.expected {
+				t.Errorf("got %q, want %q", face, test.expected)
+			}
+		})
+	}
+}
+
+func TestDetectFontFacesNeedsSerifFonts(t *testing.T) {
+	img, word := strokeGlyphs(5, 8, 2, 20, 40)
+	paragraphs := []paragraphSegment{{lines: []lineSegment{{words: []wordSegment{word}}}}}
+	serif := &font.FontsByWeight{}
+
+	detected := detectFontFaces(img, paragraphs, &font.FontsByFace{Serif: serif})
+	if face := detected[0].lines[0].words[0].style.fontFace; face != font.FontFaceSerif {
+		t.Errorf("got %q with serif fonts, want %q", face, font.FontFaceSerif)
+	}
+	detected = detectFontFaces(img, paragraphs, &font.FontsByFace{})
+	if face := detected[0].lines[0].words[0].style.fontFace; face != "" {
+		t.Errorf("got %q without serif fonts, want the face left undetected", face)
+	}
+}
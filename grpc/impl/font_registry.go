@@ -0,0 +1,201 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"regexp"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/visionex-project/visionex/grpc"
+	"github.com/visionex-project/visionex/grpc/impl/font"
+	"github.com/visionex-project/visionex/pkg/utils"
+)
+
+// Family names are used in the object names of the font bucket, so slashes and other special characters are not allowed.
+var fontFamilyNamePattern = regexp.MustCompile(`^[\p{L}\p{N}_-]+( [\p{L}\p{N}_-]+)*$`)
+
+const (
+	// The maximum length of a font family name in characters.
+	MAX_FONT_FAMILY_NAME_LENGTH = 64
+)
+
+// Loads the fonts uploaded by UploadFont from the font bucket, so that they survive restarts.
+// Does nothing when the font bucket is not configured. Objects that are not valid fonts are logged and skipped,
+// so that a broken upload does not keep the server from starting.
+func (s *server) LoadUploadedFonts(ctx context.Context) error {
+	if s.storage.FontBucket == "" {
+		return nil
+	}
+	objectNames, err := s.storage.Client.ListObjects(ctx, s.storage.FontBucket, "")
+	if err != nil {
+		return fmt.Errorf("failed to list the uploaded fonts: %w", err)
+	}
+	for _, objectName := range objectNames {
+		s.loadUploadedFont(ctx, objectName)
+	}
+	return nil
+}
+
+// Returns the fonts of the family, loading the family from the font bucket when it is not loaded yet,
+// e.g., when another instance of the server uploaded it after this one started.
+// Returns font.ErrFamilyNotFound when the family is not uploaded.
+func (s *server) getFontFamily(ctx context.Context, family string) (*font.FontsByWeight, error) {
+	fonts, err := s.fontProvider.GetFontByFamily(family)
+	if !errors.Is(err, font.ErrFamilyNotFound) || s.storage.FontBucket == "" || validateFontFamilyName(family) != nil {
+		return fonts, err
+	}
+	objectNames, err := s.storage.Client.ListObjects(ctx, s.storage.FontBucket, family+"/")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the fonts of %s: %w", family, err)
+	}
+	for _, objectName := range objectNames {
+		s.loadUploadedFont(ctx, objectName)
+	}
+	return s.fontProvider.GetFontByFamily(family)
+}
+
+// Adds the font of an object in the font bucket to the font provider. Logs and skips the object when it is not a valid font.
+func (s *server) loadUploadedFont(ctx context.Context, objectName string) {
+	family, weight, ok := parseFontObjectName(objectName)
+	if !ok {
+		log.Printf("Warning: skipping unknown object in the font bucket: %s", objectName)
+		return
+	}
+	data, err := s.storage.Client.ReadBytes(ctx, s.storage.FontBucket, objectName)
+	if err != nil {
+		log.Printf("Failed to read the uploaded font %s: %v", objectName, err)
+		return
+	}
+	if err := s.fontProvider.AddFamilyFont(family, weight, data); err != nil {
+		log.Printf("Failed to load the uploaded font %s: %v", objectName, err)
+	}
+}
+
+func (s *server) UploadFont(ctx context.Context, request *pb.UploadFontRequest) (*pb.FontFamily, error) {
+	if err := validateFontFamilyName(request.GetFamily()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if request.GetWeight() == pb.FontWeight_FONT_WEIGHT_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "font weight is required")
+	}
+	if err := font.Validate(request.GetContent()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "content is neither a TrueType nor an OpenType font: %v", err)
+	}
+	if s.storage.FontBucket == "" {
+		return nil, status.Error(codes.FailedPrecondition, "font storage is not configured")
+	}
+
+	// Saved first, so that a font is never served without surviving restarts.
+	objectName := fontObjectName(request.GetFamily(), request.GetWeight())
+	if err := s.storage.Client.SaveBytes(ctx, s.storage.FontBucket, objectName, request.GetContent()); err != nil {
+		log.Printf("Failed to save font: %v", err)
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+	if err := s.fontProvider.AddFamilyFont(request.GetFamily(), request.GetWeight(), request.GetContent()); err != nil {
+		log.Printf("Failed to add font: %v", err)
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+
+	family, _ := utils.Find(s.fontProvider.ListFamilies(), func(family font.Family) bool {
+		return family.Name == request.GetFamily()
+	})
+	return toFontFamily(family), nil
+}
+
+func (s *server) ListFonts(ctx context.Context, request *pb.ListFontsRequest) (*pb.ListFontsResponse, error) {
+	return &pb.ListFontsResponse{
+		Families: utils.Map(s.fontProvider.ListFamilies(), toFontFamily),
+	}, nil
+}
+
+func (s *server) DeleteFont(ctx context.Context, request *pb.DeleteFontRequest) (*pb.DeleteFontResponse, error) {
+	_, err := s.getFontFamily(ctx, request.GetFamily())
+	if errors.Is(err, font.ErrFamilyNotFound) {
+		return nil, status.Errorf(codes.NotFound, "font family %q is not uploaded", request.GetFamily())
+	}
+	if err != nil {
+		log.Printf("Failed to get font family: %v", err)
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+	if s.storage.FontBucket == "" {
+		return nil, status.Error(codes.FailedPrecondition, "font storage is not configured")
+	}
+
+	objectNames, err := s.storage.Client.ListObjects(ctx, s.storage.FontBucket, request.GetFamily()+"/")
+	if err != nil {
+		log.Printf("Failed to list fonts: %v", err)
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+	for _, objectName := range objectNames {
+		if err := s.storage.Client.DeleteObject(ctx, s.storage.FontBucket, objectName); err != nil {
+			log.Printf("Failed to delete font: %v", err)
+			return nil, status.Error(codes.Internal, codes.Internal.String())
+		}
+	}
+	if err := s.fontProvider.RemoveFamily(request.GetFamily()); err != nil {
+		log.Printf("Failed to remove font family: %v", err)
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+	return &pb.DeleteFontResponse{}, nil
+}
+
+// Returns the fonts to draw the translated texts with. When a family is requested, it replaces the fonts
+// of the target language, which are still used for the characters the family lacks.
+func (s *server) fontsForRequest(ctx context.Context, targetLanguage pb.Language, family string) (*font.FontsByFace, error) {
+	targetLanguageFonts, err := s.fontProvider.GetFontByLanguage(targetLanguage)
+	if err != nil {
+		log.Printf("Failed to get fonts: %v", err)
+		return nil, status.Errorf(codes.FailedPrecondition, "fonts for %s are not installed", targetLanguage.String())
+	}
+	if family == "" {
+		return targetLanguageFonts, nil
+	}
+
+	familyFonts, err := s.getFontFamily(ctx, family)
+	if errors.Is(err, font.ErrFamilyNotFound) {
+		return nil, status.Errorf(codes.NotFound, "font family %q is not uploaded", family)
+	}
+	if err != nil {
+		log.Printf("Failed to get font family: %v", err)
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+	return font.WithFamily(targetLanguageFonts, familyFonts), nil
+}
+
+func validateFontFamilyName(family string) error {
+	if len([]rune(family)) > MAX_FONT_FAMILY_NAME_LENGTH {
+		return fmt.Errorf("font family names must be at most %d characters", MAX_FONT_FAMILY_NAME_LENGTH)
+	}
+	if !fontFamilyNamePattern.MatchString(family) {
+		return fmt.Errorf("font family names must consist of letters, digits, single spaces, hyphens and underscores: %q", family)
+	}
+	return nil
+}
+
+// E.g., "Acme Sans/FONT_WEIGHT_BOLD"
+func fontObjectName(family string, weight pb.FontWeight) string {
+	return family + "/" + weight.String()
+}
+
+func parseFontObjectName(objectName string) (string, pb.FontWeight, bool) {
+	directory, fileName := path.Split(objectName)
+	family := strings.TrimSuffix(directory, "/")
+	weight, ok := pb.FontWeight_value[fileName]
+	if !ok || weight == int32(pb.FontWeight_FONT_WEIGHT_UNSPECIFIED) || validateFontFamilyName(family) != nil {
+		return "", pb.FontWeight_FONT_WEIGHT_UNSPECIFIED, false
+	}
+	return family, pb.FontWeight(weight), true
+}
+
+func toFontFamily(family font.Family) *pb.FontFamily {
+	return &pb.FontFamily{
+		Name:    family.Name,
+		Weights: family.Weights,
+	}
+}
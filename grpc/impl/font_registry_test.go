@@ -0,0 +1,172 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	pb "github.com/visionex-project/visionex/grpc"
+	"github.com/visionex-project/visionex/grpc/impl/font"
+)
+
+// Keeps the objects of every bucket in memory. Reading an object whose data is "unreadable" fails.
+type memoryStorageClient struct {
+	objects map[string][]byte
+}
+
+func (c *memoryStorageClient) SaveBytes(ctx context.Context, bucketName string, objectName string, data []byte) error {
+	c.objects[objectName] = data
+	return nil
+}
+
+func (c *memoryStorageClient) ReadBytes(ctx context.Context, bucketName string, objectName string) ([]byte, error) {
+	data, ok := c.objects[objectName]
+	if !ok || string(data) == "unreadable" {
+		return nil, fmt.Errorf("failed to read %s", objectName)
+	}
+	return data, nil
+}
+
+func (c *memoryStorageClient) ListObjects(ctx context.Context, bucketName string, prefix string) ([]string, error) {
+	objectNames := []string{}
+	for objectName := range c.objects {
+		if strings.HasPrefix(objectName, prefix) {
+			objectNames = append(objectNames, objectName)
+		}
+	}
+	slices.Sort(objectNames)
+	return objectNames, nil
+}
+
+func (c *memoryStorageClient) DeleteObject(ctx context.Context, bucketName string, objectName string) error {
+	delete(c.objects, objectName)
+	return nil
+}
+
+// Records the added fonts by the family without parsing them. Adding a font whose data is "not a font" fails.
+type memoryFontProvider struct {
+	font.FontProvider
+	families map[string][]pb.FontWeight
+}
+
+func (fp *memoryFontProvider) GetFontByFamily(family string) (*font.FontsByWeight, error) {
+	if _, ok := fp.families[family]; !ok {
+		return nil, fmt.Errorf("%w: %s", font.ErrFamilyNotFound, family)
+	}
+	return &font.FontsByWeight{}, nil
+}
+
+func (fp *memoryFontProvider) AddFamilyFont(family string, weight pb.FontWeight, data []byte) error {
+	if string(data) == "not a font" {
+		return errors.New("failed to parse the font")
+	}
+	fp.families[family] = append(fp.families[family], weight)
+	return nil
+}
+
+func fontRegistryTestServer(objects map[string][]byte) (*server, *memoryFontProvider) {
+	fontProvider := &memoryFontProvider{families: map[string][]pb.FontWeight{}}
+	return &server{
+		storage:      Storage{Client: &memoryStorageClient{objects: objects}, FontBucket: "fonts"},
+		fontProvider: fontProvider,
+	}, fontProvider
+}
+
+func TestLoadUploadedFontsSkipsBadObjects(t *testing.T) {
+	s, fontProvider := fontRegistryTestServer(map[string][]byte{
+		"Acme Sans/FONT_WEIGHT_REGULAR": []byte("font"),
+		"Acme Sans/FONT_WEIGHT_BOLD":    []byte("unreadable"),
+		"Acme Serif/FONT_WEIGHT_BOLD":   []byte("not a font"),
+		"Acme Serif/FONT_WEIGHT_LIGHT":  []byte("font"),
+		"Brand/FONT_WEIGHT_SEMI_BOLD":   []byte("font"),
+		"README":                        []byte("font"),
+	})
+
+	if err := s.LoadUploadedFonts(context.Background()); err != nil {
+		t.Fatalf("failed to load the uploaded fonts: %v", err)
+	}
+	want := map[string][]pb.FontWeight{
+		"Acme Sans": {pb.FontWeight_FONT_WEIGHT_REGULAR},
+		"Brand":     {pb.FontWeight_FONT_WEIGHT_SEMI_BOLD},
+	}
+	if fmt.Sprint(fontProvider.families) != fmt.Sprint(want) {
+		t.Errorf("got %v, want %v", fontProvider.families, want)
+	}
+}
+
+func TestGetFontFamilyLoadsFromBucket(t *testing.T) {
+	ctx := context.Background()
+	s, fontProvider := fontRegistryTestServer(map[string][]byte{
+		"Acme Sans/FONT_WEIGHT_REGULAR": []byte("font"),
+		"Acme Sans/FONT_WEIGHT_BOLD":    []byte("font"),
+		// Shares the prefix of "Acme Sans" without being in its directory.
+		"Acme Sans Mono/FONT_WEIGHT_REGULAR": []byte("font"),
+	})
+
+	if _, err := s.getFontFamily(ctx, "Acme Sans"); err != nil {
+		t.Fatalf("failed to get the family uploaded by another instance: %v", err)
+	}
+	want := map[string][]pb.FontWeight{"Acme Sans": {pb.FontWeight_FONT_WEIGHT_BOLD, pb.FontWeight_FONT_WEIGHT_REGULAR}}
+	if fmt.Sprint(fontProvider.families) != fmt.Sprint(want) {
+		t.Errorf("got %v, want %v", fontProvider.families, want)
+	}
+	for _, family := range []string{"Unknown", "../Acme Sans"} {
+		if _, err := s.getFontFamily(ctx, family); !errors.Is(err, font.ErrFamilyNotFound) {
+			t.Errorf("got %v for %q, want ErrFamilyNotFound", err, family)
+		}
+	}
+}
+
+func TestValidateFontFamilyName(t *testing.T) {
+	tests := []struct {
+		family  string
+		wantErr bool
+	}{
+		{family: "Acme Sans", wantErr: false},
+		{family: "Acme_Sans-2", wantErr: false},
+		{family: "나눔고딕", wantErr: false},
+		{family: strings.Repeat("가", MAX_FONT_FAMILY_NAME_LENGTH), wantErr: false},
+		{family: strings.Repeat("가", MAX_FONT_FAMILY_NAME_LENGTH+1), wantErr: true},
+		{family: "", wantErr: true},
+		{family: "Acme  Sans", wantErr: true},
+		{family: " Acme", wantErr: true},
+		{family: "Acme ", wantErr: true},
+		{family: "Acme/Sans", wantErr: true},
+		{family: "..", wantErr: true},
+		{family: "Acme.Sans", wantErr: true},
+	}
+
+	for _, test := range tests {
+		if err := validateFontFamilyName(test.family); (err != nil) != test.wantErr {
+			t.Errorf("got %v for %q, want an error: %v", err, test.family, test.wantErr)
+		}
+	}
+}
+
+func TestParseFontObjectName(t *testing.T) {
+	tests := []struct {
+		objectName string
+		wantFamily string
+		wantWeight pb.FontWeight
+		wantOk     bool
+	}{
+		{objectName: "Acme Sans/FONT_WEIGHT_BOLD", wantFamily: "Acme Sans", wantWeight: pb.FontWeight_FONT_WEIGHT_BOLD, wantOk: true},
+		{objectName: "나눔고딕/FONT_WEIGHT_REGULAR", wantFamily: "나눔고딕", wantWeight: pb.FontWeight_FONT_WEIGHT_REGULAR, wantOk: true},
+		{objectName: "Acme Sans/FONT_WEIGHT_UNSPECIFIED"},
+		{objectName: "Acme Sans/FONT_WEIGHT_HEAVY"},
+		{objectName: "Acme Sans/"},
+		{objectName: "FONT_WEIGHT_BOLD"},
+		{objectName: "/FONT_WEIGHT_BOLD"},
+		{objectName: "Acme/Sans/FONT_WEIGHT_BOLD"},
+	}
+
+	for _, test := range tests {
+		family, weight, ok := parseFontObjectName(test.objectName)
+		if family != test.wantFamily || weight != test.wantWeight || ok != test.wantOk {
+			t.Errorf("got %q, %v, %v for %q, want %q, %v, %v", family, weight, ok, test.objectName, test.wantFamily, test.wantWeight, test.wantOk)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package glossary
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	pb "github.com/visionex-project/visionex/grpc"
+)
+
+const (
+	// The maximum number of terms and do-not-translate terms together.
+	// Every term is added to each translation prompt, so a large glossary slows down every request.
+	MAX_TERM_COUNT = 200
+	// The maximum length of a single term in characters.
+	MAX_TERM_LENGTH = 100
+)
+
+// Returns an error describing the first invalid term, so it can be returned to the client as is.
+func Validate(glossary *pb.Glossary) error {
+	if len(glossary.GetTerms())+len(glossary.GetDoNotTranslate()) > MAX_TERM_COUNT {
+		return fmt.Errorf("at most %d glossary terms are allowed", MAX_TERM_COUNT)
+	}
+	for _, term := range glossary.GetTerms() {
+		if err := validateTerm(term.GetSource()); err != nil {
+			return err
+		}
+		if err := validateTerm(term.GetTarget()); err != nil {
+			return err
+		}
+	}
+	for _, term := range glossary.GetDoNotTranslate() {
+		if err := validateTerm(term); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateTerm(term string) error {
+	if strings.TrimSpace(term) == "" {
+		return errors.New("glossary terms must not be empty")
+	}
+	if len([]rune(term)) > MAX_TERM_LENGTH {
+		return fmt.Errorf("glossary terms must be at most %d characters: %q", MAX_TERM_LENGTH, term)
+	}
+	return nil
+}
+
+// Returns the instructions to append to a translation prompt, or an empty string for an empty glossary.
+// E.g.,
+// Use the following glossary. Always translate the term on the left into the term on the right:
+// - 롯데월드 => Lotte World
+// Keep the following terms exactly as they are, without translating or transliterating them:
+// - VisionEx
+func Instructions(glossary *pb.Glossary) string {
+	var builder strings.Builder
+	if len(glossary.GetTerms()) > 0 {
+		builder.WriteString("Use the following glossary. Always translate the term on the left into the term on the right:\n")
+		for _, term := range glossary.GetTerms() {
+			builder.WriteString("- " + term.GetSource() + " => " + term.GetTarget() + "\n")
+		}
+	}
+	if len(glossary.GetDoNotTranslate()) > 0 {
+		builder.WriteString("Keep the following terms exactly as they are, without translating or transliterating them:\n")
+		for _, term := range glossary.GetDoNotTranslate() {
+			builder.WriteString("- " + term + "\n")
+		}
+	}
+	return builder.String()
+}
+
+// Returns a violation for every glossary term that appears in the source text
+// while its expected term does not appear in the translated text.
+//
+// Whitespace is ignored on both sides, because OCR and the translation often split a term into several words.
+// Source terms are matched case-insensitively, but expected terms must match exactly as brand names are case-sensitive.
+// Only the presence of the term is checked, so a term that appears twice but is translated once is not reported.
+// The page index of the violations is left for the caller to set.
+func Check(glossary *pb.Glossary, sourceText string, translatedText string) []*pb.GlossaryViolation {
+	normalizedSource := strings.ToLower(removeSpaces(sourceText))
+	normalizedTranslation := removeSpaces(translatedText)
+
+	violations := []*pb.GlossaryViolation{}
+	check := func(sourceTerm string, expectedTerm string) {
+		if !strings.Contains(normalizedSource, strings.ToLower(removeSpaces(sourceTerm))) {
+			return
+		}
+		if strings.Contains(normalizedTranslation, removeSpaces(expectedTerm)) {
+			return
+		}
+		violations = append(violations, &pb.GlossaryViolation{
+			SourceTerm:     sourceTerm,
+			ExpectedTerm:   expectedTerm,
+			SourceText:     sourceText,
+			TranslatedText: translatedText,
+		})
+	}
+	for _, term := range glossary.GetTerms() {
+		check(term.GetSource(), term.GetTarget())
+	}
+	for _, term := range glossary.GetDoNotTranslate() {
+		check(term, term)
+	}
+	return violations
+}
+
+func removeSpaces(text string) string {
+	return strings.Map(func(char rune) rune {
+		if unicode.IsSpace(char) {
+			return -1
+		}
+		return char
+	}, text)
+}
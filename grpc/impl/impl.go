@@ -2,6 +2,7 @@ package impl
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -12,6 +13,7 @@ import (
 	"github.com/visionex-project/visionex/grpc/impl/documentai"
 	"github.com/visionex-project/visionex/grpc/impl/font"
 	"github.com/visionex-project/visionex/grpc/impl/genai"
+	"github.com/visionex-project/visionex/grpc/impl/job"
 	"github.com/visionex-project/visionex/grpc/impl/lama"
 	implOpenai "github.com/visionex-project/visionex/grpc/impl/openai"
 	"github.com/visionex-project/visionex/grpc/impl/storage"
@@ -49,6 +51,15 @@ type server struct {
 
 	// Used to delay the next request when the external API fails.
 	backoffDuration time.Duration
+
+	// Persists the jobs of SubmitTranslationJob.
+	jobStore job.Store
+	// IDs of the jobs waiting for a worker.
+	jobQueue chan string
+	// Guards the read-modify-write of jobs and jobCancels.
+	jobMutex sync.Mutex
+	// Cancels the context of each running job. Keyed by the job ID.
+	jobCancels map[string]context.CancelFunc
 }
 
 type Storage struct {
@@ -60,6 +71,10 @@ type Storage struct {
 
 	// The bucket name for storing related image to markdown processing.
 	ToMarkdownBucket string
+
+	// The bucket name for storing the fonts uploaded by UploadFont.
+	// Fonts cannot be uploaded when it is empty.
+	FontBucket string
 }
 
 type DocumentaiSpec struct {
@@ -98,6 +113,7 @@ func New(
 	storage Storage,
 	fontProvider font.FontProvider,
 	backoffDuration time.Duration,
+	jobStore job.Store,
 ) *server {
 	return &server{
 		authClient:        authClient,
@@ -112,6 +128,9 @@ func New(
 		storage:           storage,
 		fontProvider:      fontProvider,
 		backoffDuration:   backoffDuration,
+		jobStore:          jobStore,
+		jobQueue:          make(chan string, MAX_QUEUED_JOBS),
+		jobCancels:        map[string]context.CancelFunc{},
 	}
 }
 
@@ -0,0 +1,284 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/visionex-project/visionex/grpc"
+)
+
+const (
+	// E.g., "3f2b9c0e8d7a6b5c4f3e2d1c0b9a8f7e.job.binpb"
+	JOB_FILE_SUFFIX = ".job.binpb"
+	// The request is stored separately, because it holds the images and never changes after submission.
+	// E.g., "3f2b9c0e8d7a6b5c4f3e2d1c0b9a8f7e.request.binpb"
+	REQUEST_FILE_SUFFIX = ".request.binpb"
+	// The result of each image is stored separately, as it holds the translated images.
+	// E.g., "3f2b9c0e8d7a6b5c4f3e2d1c0b9a8f7e.result-12.binpb"
+	RESULT_FILE_FORMAT = "%s.result-%d.binpb"
+	// Matches every result file of the job.
+	RESULT_FILE_PATTERN = "%s.result-*.binpb"
+)
+
+// Stores every job as binary protobuf files in a single local directory.
+// Suitable for a single server instance. Use a shared store when running several instances.
+type fileStore struct {
+	directory string
+	// The fields of every job that List, ListUnfinished and DeleteFinishedBefore look at, by the job ID,
+	// so that they only read the job files they return.
+	index map[string]indexEntry
+	// Guards the job files and the index, so that a reader never sees a half written job.
+	mutex sync.RWMutex
+}
+
+type indexEntry struct {
+	id                     string
+	ownerEmail             string
+	state                  pb.JobState
+	createTimeMilliseconds int64
+	updateTimeMilliseconds int64
+}
+
+func newIndexEntry(job *pb.TranslationJob) indexEntry {
+	return indexEntry{
+		id:                     job.GetId(),
+		ownerEmail:             job.GetOwnerEmail(),
+		state:                  job.GetState(),
+		createTimeMilliseconds: job.GetCreateTimeMilliseconds(),
+		updateTimeMilliseconds: job.GetUpdateTimeMilliseconds(),
+	}
+}
+
+// Reads every job once to build the index. Unreadable job files are logged and left out,
+// so that a single corrupt file does not keep the server from starting.
+func NewFileStore(directory string) (Store, error) {
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create job directory: %w", err)
+	}
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job directory: %w", err)
+	}
+
+	s := &fileStore{directory: directory, index: map[string]indexEntry{}}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), JOB_FILE_SUFFIX) {
+			continue
+		}
+		job := &pb.TranslationJob{}
+		if err := s.readMessage(entry.Name(), job); err != nil {
+			log.Printf("Failed to read job file %s, skipping it: %v", entry.Name(), err)
+			continue
+		}
+		s.index[job.GetId()] = newIndexEntry(job)
+	}
+	return s, nil
+}
+
+func (s *fileStore) Create(ctx context.Context, job *pb.TranslationJob, request *pb.SubmitTranslationJobRequest) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// The request is written first, so that a job file always has its request.
+	if err := s.writeMessage(job.GetId()+REQUEST_FILE_SUFFIX, request); err != nil {
+		return err
+	}
+	if err := s.writeMessage(job.GetId()+JOB_FILE_SUFFIX, job); err != nil {
+		return err
+	}
+	s.index[job.GetId()] = newIndexEntry(job)
+	return nil
+}
+
+func (s *fileStore) Update(ctx context.Context, job *pb.TranslationJob) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.index[job.GetId()]; !ok {
+		return ErrNotFound
+	}
+	if err := s.writeMessage(job.GetId()+JOB_FILE_SUFFIX, job); err != nil {
+		return err
+	}
+	s.index[job.GetId()] = newIndexEntry(job)
+	return nil
+}
+
+func (s *fileStore) Get(ctx context.Context, id string) (*pb.TranslationJob, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	job := &pb.TranslationJob{}
+	if err := s.readMessage(id+JOB_FILE_SUFFIX, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (s *fileStore) GetRequest(ctx context.Context, id string) (*pb.SubmitTranslationJobRequest, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	request := &pb.SubmitTranslationJobRequest{}
+	if err := s.readMessage(id+REQUEST_FILE_SUFFIX, request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *fileStore) List(ctx context.Context, ownerEmail string, pageToken string, pageSize int) ([]*pb.TranslationJob, string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	entries := s.sortedEntries(func(entry indexEntry) bool {
+		return entry.ownerEmail == ownerEmail
+	})
+	start := 0
+	if pageToken != "" {
+		start = sort.Search(len(entries), func(i int) bool {
+			return entries[i].id == pageToken || !isNewer(entries[i], s.index[pageToken])
+		})
+		if start == len(entries) || entries[start].id != pageToken {
+			return nil, "", ErrInvalidPageToken
+		}
+		start++
+	}
+	end := min(start+pageSize, len(entries))
+
+	nextPageToken := ""
+	if end < len(entries) {
+		nextPageToken = entries[end-1].id
+	}
+	return s.readJobs(entries[start:end]), nextPageToken, nil
+}
+
+func (s *fileStore) ListUnfinished(ctx context.Context) ([]*pb.TranslationJob, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.readJobs(s.sortedEntries(func(entry indexEntry) bool {
+		return !IsFinished(entry.state)
+	})), nil
+}
+
+func (s *fileStore) DeleteFinishedBefore(ctx context.Context, before time.Time) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	deletedCount := 0
+	for id, entry := range s.index {
+		if !IsFinished(entry.state) || entry.updateTimeMilliseconds >= before.UnixMilli() {
+			continue
+		}
+		// The job file is removed first, so that a job is never left without its request or results.
+		if err := os.Remove(s.path(id + JOB_FILE_SUFFIX)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return deletedCount, fmt.Errorf("failed to delete job %s: %w", id, err)
+		}
+		delete(s.index, id)
+		deletedCount++
+
+		resultPaths, err := filepath.Glob(s.path(fmt.Sprintf(RESULT_FILE_PATTERN, id)))
+		if err != nil {
+			return deletedCount, fmt.Errorf("failed to find results of job %s: %w", id, err)
+		}
+		for _, path := range append(resultPaths, s.path(id+REQUEST_FILE_SUFFIX)) {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return deletedCount, fmt.Errorf("failed to delete %s: %w", filepath.Base(path), err)
+			}
+		}
+	}
+	return deletedCount, nil
+}
+
+// Returns the index entries that match, from newest to oldest.
+func (s *fileStore) sortedEntries(matches func(entry indexEntry) bool) []indexEntry {
+	entries := []indexEntry{}
+	for _, entry := range s.index {
+		if matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return isNewer(entries[i], entries[j])
+	})
+	return entries
+}
+
+// Jobs created at the same millisecond are ordered by their IDs, so that the order is the same on every call.
+func isNewer(a indexEntry, b indexEntry) bool {
+	if a.createTimeMilliseconds != b.createTimeMilliseconds {
+		return a.createTimeMilliseconds > b.createTimeMilliseconds
+	}
+	return a.id > b.id
+}
+
+// Reads the jobs of the entries in order. Unreadable job files are logged and left out,
+// so that one corrupt job does not fail every listing it would be part of.
+func (s *fileStore) readJobs(entries []indexEntry) []*pb.TranslationJob {
+	jobs := []*pb.TranslationJob{}
+	for _, entry := range entries {
+		job := &pb.TranslationJob{}
+		if err := s.readMessage(entry.id+JOB_FILE_SUFFIX, job); err != nil {
+			log.Printf("Failed to read job %s, skipping it: %v", entry.id, err)
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// Not guarded by the mutex, as each result is written once by the worker that translated its image,
+// and the rename in writeMessage already keeps readers from seeing a half written result.
+// Holding the mutex would block every other job while the images are written.
+func (s *fileStore) SaveResult(ctx context.Context, id string, index int, result proto.Message) error {
+	return s.writeMessage(fmt.Sprintf(RESULT_FILE_FORMAT, id, index), result)
+}
+
+func (s *fileStore) GetResult(ctx context.Context, id string, index int, result proto.Message) error {
+	return s.readMessage(fmt.Sprintf(RESULT_FILE_FORMAT, id, index), result)
+}
+
+// Keeps only the base name, so that an ID from the client such as "../config" cannot leave the directory.
+func (s *fileStore) path(name string) string {
+	return filepath.Join(s.directory, filepath.Base(name))
+}
+
+// Writes to a temporary file first and renames it, so that a crash never leaves a partially written file.
+func (s *fileStore) writeMessage(name string, message proto.Message) error {
+	content, err := proto.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", name, err)
+	}
+	temporaryPath := s.path(name) + ".tmp"
+	if err := os.WriteFile(temporaryPath, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := os.Rename(temporaryPath, s.path(name)); err != nil {
+		return fmt.Errorf("failed to rename %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s *fileStore) readMessage(name string, message proto.Message) error {
+	content, err := os.ReadFile(s.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if err := proto.Unmarshal(content, message); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", name, err)
+	}
+	return nil
+}
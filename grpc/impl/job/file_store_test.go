@@ -0,0 +1,262 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/visionex-project/visionex/grpc"
+)
+
+func newTestStore(t *testing.T, directory string) *fileStore {
+	t.Helper()
+	store, err := NewFileStore(directory)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	return store.(*fileStore)
+}
+
+func testJob(id string, ownerEmail string, state pb.JobState, createTimeMilliseconds int64) *pb.TranslationJob {
+	return &pb.TranslationJob{
+		Id:                     id,
+		OwnerEmail:             ownerEmail,
+		State:                  state,
+		CreateTimeMilliseconds: createTimeMilliseconds,
+		UpdateTimeMilliseconds: createTimeMilliseconds,
+	}
+}
+
+func ids(jobs []*pb.TranslationJob) []string {
+	result := []string{}
+	for _, job := range jobs {
+		result = append(result, job.GetId())
+	}
+	return result
+}
+
+func TestFileStoreCreateUpdateGet(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, t.TempDir())
+	job := testJob("a1", "user@yanolja.com", pb.JobState_JOB_STATE_QUEUED, 1000)
+	request := &pb.SubmitTranslationJobRequest{Request: &pb.SubmitTranslationJobRequest_ToMarkdown{
+		ToMarkdown: &pb.TranslateToMarkdownBatchRequest{},
+	}}
+
+	if err := store.Update(ctx, job); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got %v updating a job before it is created, want ErrNotFound", err)
+	}
+	if err := store.Create(ctx, job, request); err != nil {
+		t.Fatalf("failed to create: %v", err)
+	}
+	job.State = pb.JobState_JOB_STATE_RUNNING
+	job.CompletedImageCount = 3
+	if err := store.Update(ctx, job); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+
+	got, err := store.Get(ctx, "a1")
+	if err != nil {
+		t.Fatalf("failed to get: %v", err)
+	}
+	if !proto.Equal(got, job) {
+		t.Errorf("got %v, want %v", got, job)
+	}
+	gotRequest, err := store.GetRequest(ctx, "a1")
+	if err != nil {
+		t.Fatalf("failed to get request: %v", err)
+	}
+	if !proto.Equal(gotRequest, request) {
+		t.Errorf("got request %v, want %v", gotRequest, request)
+	}
+	if _, err := store.Get(ctx, "unknown"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got %v for an unknown job, want ErrNotFound", err)
+	}
+
+	// A new store reads the jobs from the directory.
+	reopened := newTestStore(t, store.directory)
+	if unfinished, err := reopened.ListUnfinished(ctx); err != nil || len(unfinished) != 1 || !proto.Equal(unfinished[0], job) {
+		t.Errorf("got %v, %v after reopening, want %v", unfinished, err, job)
+	}
+}
+
+func TestFileStoreList(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, t.TempDir())
+	jobs := []*pb.TranslationJob{
+		testJob("a1", "user@yanolja.com", pb.JobState_JOB_STATE_SUCCEEDED, 1000),
+		testJob("a3", "user@yanolja.com", pb.JobState_JOB_STATE_RUNNING, 3000),
+		// Created at the same millisecond as a3, so ordered by the ID.
+		testJob("a4", "user@yanolja.com", pb.JobState_JOB_STATE_QUEUED, 3000),
+		testJob("a2", "user@yanolja.com", pb.JobState_JOB_STATE_FAILED, 2000),
+		testJob("b1", "other@yanolja.com", pb.JobState_JOB_STATE_QUEUED, 4000),
+	}
+	for _, job := range jobs {
+		if err := store.Create(ctx, job, &pb.SubmitTranslationJobRequest{}); err != nil {
+			t.Fatalf("failed to create %s: %v", job.GetId(), err)
+		}
+	}
+
+	tests := []struct {
+		name              string
+		ownerEmail        string
+		pageToken         string
+		pageSize          int
+		wantIds           []string
+		wantNextPageToken string
+	}{
+		{name: "first page", ownerEmail: "user@yanolja.com", pageSize: 2, wantIds: []string{"a4", "a3"}, wantNextPageToken: "a3"},
+		{name: "last page", ownerEmail: "user@yanolja.com", pageToken: "a3", pageSize: 2, wantIds: []string{"a2", "a1"}},
+		{name: "whole list", ownerEmail: "user@yanolja.com", pageSize: 10, wantIds: []string{"a4", "a3", "a2", "a1"}},
+		{name: "other owner", ownerEmail: "other@yanolja.com", pageSize: 10, wantIds: []string{"b1"}},
+		{name: "no jobs", ownerEmail: "", pageSize: 10, wantIds: []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, nextPageToken, err := store.List(ctx, test.ownerEmail, test.pageToken, test.pageSize)
+			if err != nil {
+				t.Fatalf("failed to list: %v", err)
+			}
+			if fmt.Sprint(ids(got)) != fmt.Sprint(test.wantIds) {
+				t.Errorf("got %v, want %v", ids(got), test.wantIds)
+			}
+			if nextPageToken != test.wantNextPageToken {
+				t.Errorf("got the next page token %q, want %q", nextPageToken, test.wantNextPageToken)
+			}
+		})
+	}
+
+	for _, pageToken := range []string{"unknown", "b1"} {
+		if _, _, err := store.List(ctx, "user@yanolja.com", pageToken, 2); !errors.Is(err, ErrInvalidPageToken) {
+			t.Errorf("got %v for the page token %q, want ErrInvalidPageToken", err, pageToken)
+		}
+	}
+
+	unfinished, err := store.ListUnfinished(ctx)
+	if err != nil {
+		t.Fatalf("failed to list unfinished jobs: %v", err)
+	}
+	if got, want := fmt.Sprint(ids(unfinished)), fmt.Sprint([]string{"b1", "a4", "a3"}); got != want {
+		t.Errorf("got the unfinished jobs %v, want %v", got, want)
+	}
+}
+
+func TestFileStoreSkipsUnreadableJobs(t *testing.T) {
+	ctx := context.Background()
+	directory := t.TempDir()
+	store := newTestStore(t, directory)
+	for _, job := range []*pb.TranslationJob{
+		testJob("a1", "user@yanolja.com", pb.JobState_JOB_STATE_QUEUED, 1000),
+		testJob("a2", "user@yanolja.com", pb.JobState_JOB_STATE_QUEUED, 2000),
+	} {
+		if err := store.Create(ctx, job, &pb.SubmitTranslationJobRequest{}); err != nil {
+			t.Fatalf("failed to create %s: %v", job.GetId(), err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(directory, "corrupt"+JOB_FILE_SUFFIX), []byte("not a job"), 0o644); err != nil {
+		t.Fatalf("failed to write the corrupt job: %v", err)
+	}
+
+	reopened := newTestStore(t, directory)
+	unfinished, err := reopened.ListUnfinished(ctx)
+	if err != nil {
+		t.Fatalf("failed to list unfinished jobs: %v", err)
+	}
+	if got, want := fmt.Sprint(ids(unfinished)), fmt.Sprint([]string{"a2", "a1"}); got != want {
+		t.Errorf("got %v, want %v without the corrupt job", got, want)
+	}
+
+	// A job corrupted after the store was created is left out of the listings as well.
+	if err := os.WriteFile(filepath.Join(directory, "a2"+JOB_FILE_SUFFIX), []byte("not a job"), 0o644); err != nil {
+		t.Fatalf("failed to corrupt the job: %v", err)
+	}
+	jobs, _, err := reopened.List(ctx, "user@yanolja.com", "", 10)
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if got, want := fmt.Sprint(ids(jobs)), fmt.Sprint([]string{"a1"}); got != want {
+		t.Errorf("got %v, want %v without the corrupt job", got, want)
+	}
+}
+
+func TestFileStoreDeleteFinishedBefore(t *testing.T) {
+	ctx := context.Background()
+	directory := t.TempDir()
+	store := newTestStore(t, directory)
+	jobs := []*pb.TranslationJob{
+		testJob("old", "user@yanolja.com", pb.JobState_JOB_STATE_SUCCEEDED, 1000),
+		testJob("recent", "user@yanolja.com", pb.JobState_JOB_STATE_CANCELLED, 5000),
+		// Jobs still running are kept however old they are.
+		testJob("running", "user@yanolja.com", pb.JobState_JOB_STATE_RUNNING, 1000),
+	}
+	for _, job := range jobs {
+		if err := store.Create(ctx, job, &pb.SubmitTranslationJobRequest{}); err != nil {
+			t.Fatalf("failed to create %s: %v", job.GetId(), err)
+		}
+		for i := 0; i < 2; i++ {
+			if err := store.SaveResult(ctx, job.GetId(), i, &pb.TranslateToMarkdownBatchResult{}); err != nil {
+				t.Fatalf("failed to save result %d of %s: %v", i, job.GetId(), err)
+			}
+		}
+	}
+
+	deletedCount, err := store.DeleteFinishedBefore(ctx, time.UnixMilli(3000))
+	if err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if deletedCount != 1 {
+		t.Errorf("got %d deleted jobs, want 1", deletedCount)
+	}
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		t.Fatalf("failed to read the directory: %v", err)
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "old.") {
+			t.Errorf("got %s left, want every file of the deleted job removed", entry.Name())
+		}
+	}
+	if got := len(entries); got != 8 {
+		t.Errorf("got %d files, want the 8 files of the two kept jobs", got)
+	}
+	if _, err := store.Get(ctx, "old"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got %v getting the deleted job, want ErrNotFound", err)
+	}
+	if err := store.Update(ctx, jobs[0]); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got %v updating the deleted job, want ErrNotFound", err)
+	}
+	listed, _, err := store.List(ctx, "user@yanolja.com", "", 10)
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if got, want := fmt.Sprint(ids(listed)), fmt.Sprint([]string{"recent", "running"}); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFileStorePath(t *testing.T) {
+	store := &fileStore{directory: filepath.Join("data", "jobs")}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "a1" + JOB_FILE_SUFFIX, want: filepath.Join("data", "jobs", "a1"+JOB_FILE_SUFFIX)},
+		{name: "../config" + JOB_FILE_SUFFIX, want: filepath.Join("data", "jobs", "config"+JOB_FILE_SUFFIX)},
+		{name: "../../etc/passwd", want: filepath.Join("data", "jobs", "passwd")},
+		{name: "/etc/passwd", want: filepath.Join("data", "jobs", "passwd")},
+	}
+
+	for _, test := range tests {
+		if got := store.path(test.name); got != test.want {
+			t.Errorf("got %q for %q, want %q", got, test.name, test.want)
+		}
+	}
+}
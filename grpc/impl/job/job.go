@@ -0,0 +1,53 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/visionex-project/visionex/grpc"
+)
+
+var (
+	ErrNotFound         = errors.New("job not found")
+	ErrInvalidPageToken = errors.New("invalid page token")
+)
+
+// Store persists translation jobs, so that they survive server restarts.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Saves a new job along with the request it was submitted with.
+	Create(ctx context.Context, job *pb.TranslationJob, request *pb.SubmitTranslationJobRequest) error
+	// Overwrites an existing job. The request is kept as is.
+	Update(ctx context.Context, job *pb.TranslationJob) error
+	// Returns ErrNotFound when there is no job with the ID.
+	Get(ctx context.Context, id string) (*pb.TranslationJob, error)
+	// Returns ErrNotFound when there is no job with the ID.
+	GetRequest(ctx context.Context, id string) (*pb.SubmitTranslationJobRequest, error)
+	// Returns up to pageSize jobs of the owner from newest to oldest, after the job with the ID of pageToken,
+	// and the page token of the next page, which is empty on the last page.
+	// Returns ErrInvalidPageToken when the owner has no job with the ID.
+	List(ctx context.Context, ownerEmail string, pageToken string, pageSize int) ([]*pb.TranslationJob, string, error)
+	// Returns the jobs of every owner that are not finished, from newest to oldest.
+	ListUnfinished(ctx context.Context) ([]*pb.TranslationJob, error)
+	// Deletes the jobs that finished before the time, along with their requests and results.
+	// Returns the number of deleted jobs.
+	DeleteFinishedBefore(ctx context.Context, before time.Time) (int, error)
+	// Saves the result of the image at the index of the job, which holds the translated images.
+	// Results are kept apart from the job, so that updating and listing jobs never reads or writes the images.
+	SaveResult(ctx context.Context, id string, index int, result proto.Message) error
+	// Reads the result saved by SaveResult into result. Returns ErrNotFound when it is not saved.
+	GetResult(ctx context.Context, id string, index int, result proto.Message) error
+}
+
+// Returns whether the job will not change anymore.
+func IsFinished(state pb.JobState) bool {
+	switch state {
+	case pb.JobState_JOB_STATE_SUCCEEDED, pb.JobState_JOB_STATE_FAILED, pb.JobState_JOB_STATE_CANCELLED:
+		return true
+	default:
+		return false
+	}
+}
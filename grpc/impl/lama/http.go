@@ -0,0 +1,249 @@
+package lama
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+const (
+	// Used when Options.Timeout is not set. Large images take tens of seconds on a CPU deployment of LaMa.
+	DEFAULT_LAMA_TIMEOUT = 60 * time.Second
+	// Used when Options.MaxImageSize is not set. LaMa runs out of memory on a typical GPU above about this size.
+	DEFAULT_LAMA_MAX_IMAGE_SIZE = 2048
+	// Tiles are sent with this many pixels of the surrounding image on each side, so that the inpainted regions
+	// match their neighbors across the edges of the tiles. Only the pixels inside the margin are kept.
+	LAMA_TILE_MARGIN = 128
+	// At most this many bytes of an error response are logged.
+	MAX_LAMA_ERROR_BODY_SIZE = 1024
+)
+
+// Options of HttpLamaClient. The zero value of each field falls back to a default.
+type Options struct {
+	// The timeout of each attempt of a request. Defaults to DEFAULT_LAMA_TIMEOUT.
+	Timeout time.Duration
+	// The number of retries after a request fails. Requests rejected by the service as invalid are not retried.
+	MaxRetries int
+	// The header to authenticate with, e.g., "Authorization". Defaults to "Authorization".
+	AuthHeader string
+	// The value of AuthHeader, e.g., "Bearer <token>". The header is not sent when empty.
+	AuthToken string
+	// The maximum width and height in pixels of the images sent to the service.
+	// Larger images are inpainted in overlapping tiles. Defaults to DEFAULT_LAMA_MAX_IMAGE_SIZE.
+	MaxImageSize int
+}
+
+// HttpLamaClient inpaints with a self-hosted LaMa service over HTTP.
+// The image and the mask are posted as the "image" and "mask" files of a multipart form, both in PNG,
+// and the service responds with the inpainted image in the body.
+// The mask is white where the image is inpainted and black elsewhere.
+// Ref: https://github.com/advimman/lama
+type HttpLamaClient struct {
+	client  *http.Client
+	url     string
+	options Options
+	// Returns the waits between the attempts of a request, which tests shorten.
+	newBackOff func() backoff.BackOff
+}
+
+// New returns a client of the LaMa service at lamaURL, e.g., "http://localhost:8082/inpaint".
+func New(lamaURL string, options Options) LamaClient {
+	if options.Timeout <= 0 {
+		options.Timeout = DEFAULT_LAMA_TIMEOUT
+	}
+	if options.MaxRetries < 0 {
+		options.MaxRetries = 0
+	}
+	if options.AuthHeader == "" {
+		options.AuthHeader = "Authorization"
+	}
+	if options.MaxImageSize <= 0 {
+		options.MaxImageSize = DEFAULT_LAMA_MAX_IMAGE_SIZE
+	}
+	return &HttpLamaClient{
+		client:  &http.Client{Timeout: options.Timeout},
+		url:     lamaURL,
+		options: options,
+		newBackOff: func() backoff.BackOff {
+			return backoff.NewExponentialBackOff()
+		},
+	}
+}
+
+// CreateMaskImage returns the original image with the pixels where the mask is white inpainted by the service.
+// Images larger than Options.MaxImageSize are sent in tiles, and tiles without masked pixels are not sent at all.
+func (h *HttpLamaClient) CreateMaskImage(originImage image.Image, maskImg image.Image) (image.Image, error) {
+	bounds := originImage.Bounds()
+	result := image.NewRGBA(bounds)
+	draw.Draw(result, bounds, originImage, bounds.Min, draw.Src)
+	mask := binaryMask(maskImg, bounds)
+
+	for _, tile := range imageTiles(bounds, h.options.MaxImageSize) {
+		if !hasMaskedPixel(mask, tile.core) {
+			continue
+		}
+		inpainted, err := h.inpaint(cropImage(originImage, tile.context), cropImage(mask, tile.context))
+		if err != nil {
+			return nil, fmt.Errorf("failed to inpaint %v: %w", tile.context, err)
+		}
+		offset := tile.core.Min.Sub(tile.context.Min)
+		draw.Draw(result, tile.core, inpainted, inpainted.Bounds().Min.Add(offset), draw.Src)
+	}
+	return result, nil
+}
+
+// Sends an image and its mask of the same size, whose bounds start at the origin, and returns the inpainted image.
+func (h *HttpLamaClient) inpaint(img image.Image, mask image.Image) (image.Image, error) {
+	body, contentType, err := multipartBody(img, mask)
+	if err != nil {
+		return nil, err
+	}
+
+	inpainted, err := backoff.RetryNotifyWithData(func() (image.Image, error) {
+		return h.post(body, contentType)
+	}, backoff.WithMaxRetries(h.newBackOff(), uint64(h.options.MaxRetries)), func(err error, wait time.Duration) {
+		log.Printf("Retrying LaMa request in %v: %v", wait, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Some services pad the image to a multiple of 8 pixels, which is cut off here.
+	size := img.Bounds().Size()
+	if inpaintedSize := inpainted.Bounds().Size(); inpaintedSize.X < size.X || inpaintedSize.Y < size.Y {
+		return nil, fmt.Errorf("LaMa service responded with a %v image for a %v image", inpaintedSize, size)
+	}
+	return inpainted, nil
+}
+
+func (h *HttpLamaClient) post(body []byte, contentType string) (image.Image, error) {
+	request, err := http.NewRequest(http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, backoff.Permanent(fmt.Errorf("failed to create LaMa request: %w", err))
+	}
+	request.Header.Set("Content-Type", contentType)
+	if h.options.AuthToken != "" {
+		request.Header.Set(h.options.AuthHeader, h.options.AuthToken)
+	}
+
+	response, err := h.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send LaMa request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, MAX_LAMA_ERROR_BODY_SIZE))
+		err := fmt.Errorf("LaMa service responded with %s: %s", response.Status, message)
+		// Client errors are not resolved by retrying, except for rate limits.
+		if response.StatusCode >= 400 && response.StatusCode < 500 && response.StatusCode != http.StatusTooManyRequests {
+			return nil, backoff.Permanent(err)
+		}
+		return nil, err
+	}
+
+	inpainted, _, err := image.Decode(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode LaMa response: %w", err)
+	}
+	return inpainted, nil
+}
+
+func multipartBody(img image.Image, mask image.Image) ([]byte, string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, file := range []struct {
+		name  string
+		image image.Image
+	}{{"image", img}, {"mask", mask}} {
+		part, err := writer.CreateFormFile(file.name, file.name+".png")
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create %s part: %w", file.name, err)
+		}
+		if err := png.Encode(part, file.image); err != nil {
+			return nil, "", fmt.Errorf("failed to encode %s: %w", file.name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to close multipart body: %w", err)
+	}
+	return body.Bytes(), writer.FormDataContentType(), nil
+}
+
+// Returns the mask as opaque white and black with the bounds of the image,
+// as services read the mask by its brightness and ignore its alpha.
+func binaryMask(maskImg image.Image, bounds image.Rectangle) *image.Gray {
+	maskBounds := maskImg.Bounds()
+	mask := image.NewGray(bounds)
+	rgbaMask, isRGBA := maskImg.(*image.RGBA)
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			var masked bool
+			if isRGBA {
+				masked = isMasked(rgbaMask.RGBAAt(maskBounds.Min.X+x, maskBounds.Min.Y+y))
+			} else {
+				masked = isMasked(maskImg.At(maskBounds.Min.X+x, maskBounds.Min.Y+y))
+			}
+			if masked {
+				mask.Pix[mask.PixOffset(bounds.Min.X+x, bounds.Min.Y+y)] = 0xff
+			}
+		}
+	}
+	return mask
+}
+
+func hasMaskedPixel(mask *image.Gray, rect image.Rectangle) bool {
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			if mask.GrayAt(x, y).Y != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Returns the part of the image within rect as a new image whose bounds start at the origin.
+func cropImage(img image.Image, rect image.Rectangle) image.Image {
+	cropped := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(cropped, cropped.Bounds(), img, rect.Min, draw.Src)
+	return cropped
+}
+
+// A part of an image to inpaint separately.
+type imageTile struct {
+	// The pixels taken from the inpainted tile.
+	core image.Rectangle
+	// The pixels sent to the service, which are the core and its margin within the image.
+	context image.Rectangle
+}
+
+// Covers the bounds with tiles whose contexts are at most maxSize wide and tall.
+// A single tile is returned when the bounds fit.
+func imageTiles(bounds image.Rectangle, maxSize int) []imageTile {
+	if bounds.Dx() <= maxSize && bounds.Dy() <= maxSize {
+		return []imageTile{{core: bounds, context: bounds}}
+	}
+	// Small limits keep at least half of each tile as the core.
+	margin := min(LAMA_TILE_MARGIN, maxSize/4)
+	step := maxSize - 2*margin
+
+	tiles := []imageTile{}
+	for top := bounds.Min.Y; top < bounds.Max.Y; top += step {
+		for left := bounds.Min.X; left < bounds.Max.X; left += step {
+			core := image.Rect(left, top, left+step, top+step).Intersect(bounds)
+			tiles = append(tiles, imageTile{core: core, context: core.Inset(-margin).Intersect(bounds)})
+		}
+	}
+	return tiles
+}
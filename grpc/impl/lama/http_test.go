@@ -0,0 +1,394 @@
+package lama
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// The color the test service paints the masked pixels with.
+var inpaintedColor = color.RGBA{R: 255, A: 255}
+
+// Returns an image whose pixels differ from each other, so that a tile stitched at a wrong offset shows.
+func gradientImage(bounds image.Rectangle) *image.RGBA {
+	img := image.NewRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			img.SetRGBA(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: uint8(x ^ y), A: 255})
+		}
+	}
+	return img
+}
+
+// Reads the image and the mask posted by the client.
+func readInpaintRequest(t *testing.T, request *http.Request) (image.Image, image.Image) {
+	t.Helper()
+	if err := request.ParseMultipartForm(32 << 20); err != nil {
+		t.Errorf("failed to parse the multipart form: %v", err)
+		return nil, nil
+	}
+	images := []image.Image{}
+	for _, name := range []string{"image", "mask"} {
+		file, header, err := request.FormFile(name)
+		if err != nil {
+			t.Errorf("failed to read the %s part: %v", name, err)
+			return nil, nil
+		}
+		defer file.Close()
+		if header.Filename != name+".png" {
+			t.Errorf("got file name %q for the %s part, want %q", header.Filename, name, name+".png")
+		}
+		decoded, err := png.Decode(file)
+		if err != nil {
+			t.Errorf("failed to decode the %s part as PNG: %v", name, err)
+			return nil, nil
+		}
+		images = append(images, decoded)
+	}
+	return images[0], images[1]
+}
+
+// Responds with the posted image with the masked pixels painted with inpaintedColor, like a service that inpaints them.
+func inpaintHandler(t *testing.T, received func(img image.Image, mask image.Image)) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		img, mask := readInpaintRequest(t, request)
+		if img == nil {
+			http.Error(writer, "invalid request", http.StatusBadRequest)
+			return
+		}
+		if received != nil {
+			received(img, mask)
+		}
+		inpainted := image.NewRGBA(img.Bounds())
+		draw.Draw(inpainted, inpainted.Bounds(), img, img.Bounds().Min, draw.Src)
+		for y := mask.Bounds().Min.Y; y < mask.Bounds().Max.Y; y++ {
+			for x := mask.Bounds().Min.X; x < mask.Bounds().Max.X; x++ {
+				if r, _, _, _ := mask.At(x, y).RGBA(); r != 0 {
+					inpainted.SetRGBA(x, y, inpaintedColor)
+				}
+			}
+		}
+		writer.Header().Set("Content-Type", "image/png")
+		png.Encode(writer, inpainted)
+	}
+}
+
+// Returns a client of the server that retries without waiting.
+func newTestClient(server *httptest.Server, options Options) *HttpLamaClient {
+	client := New(server.URL, options).(*HttpLamaClient)
+	client.newBackOff = func() backoff.BackOff {
+		return &backoff.ZeroBackOff{}
+	}
+	return client
+}
+
+func TestHttpLamaClientPostsImageAndMask(t *testing.T) {
+	bounds := image.Rect(0, 0, 40, 30)
+	img := gradientImage(bounds)
+	masked := image.Rect(10, 5, 20, 15)
+	// The mask is white where inpainted, and transparent elsewhere, as translateToImage draws it.
+	mask := image.NewRGBA(bounds)
+	draw.Draw(mask, masked, image.White, image.Point{}, draw.Src)
+
+	var receivedImage, receivedMask image.Image
+	server := httptest.NewServer(inpaintHandler(t, func(img image.Image, mask image.Image) {
+		receivedImage, receivedMask = img, mask
+	}))
+	defer server.Close()
+
+	inpainted, err := newTestClient(server, Options{}).CreateMaskImage(img, mask)
+	if err != nil {
+		t.Fatalf("failed to inpaint: %v", err)
+	}
+	if receivedImage == nil {
+		t.Fatalf("got no request")
+	}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if got, want := color.RGBAModel.Convert(receivedImage.At(x, y)), img.At(x, y); got != want {
+				t.Fatalf("got %v at (%d, %d) in the posted image, want %v", got, x, y, want)
+			}
+			// Services read the mask by its brightness, so it is opaque black where not inpainted.
+			wantMask, wantInpainted := color.Gray{}, img.At(x, y)
+			if image.Pt(x, y).In(masked) {
+				wantMask, wantInpainted = color.Gray{Y: 0xff}, inpaintedColor
+			}
+			if got := color.GrayModel.Convert(receivedMask.At(x, y)); got != wantMask {
+				t.Fatalf("got %v at (%d, %d) in the posted mask, want %v", got, x, y, wantMask)
+			}
+			if _, _, _, a := receivedMask.At(x, y).RGBA(); a != 0xffff {
+				t.Fatalf("got alpha %d at (%d, %d) in the posted mask, want opaque", a, x, y)
+			}
+			if got := inpainted.At(x, y); got != wantInpainted {
+				t.Fatalf("got %v at (%d, %d) in the inpainted image, want %v", got, x, y, wantInpainted)
+			}
+		}
+	}
+}
+
+func TestHttpLamaClientAuthHeader(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       Options
+		header        string
+		expectedValue string
+	}{
+		{name: "default header", options: Options{AuthToken: "Bearer secret"}, header: "Authorization", expectedValue: "Bearer secret"},
+		{name: "custom header", options: Options{AuthHeader: "X-Api-Key", AuthToken: "secret"}, header: "X-Api-Key", expectedValue: "secret"},
+		{name: "no token", options: Options{AuthHeader: "X-Api-Key"}, header: "X-Api-Key", expectedValue: ""},
+		{name: "no token with the default header", options: Options{}, header: "Authorization", expectedValue: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var values []string
+			handler := inpaintHandler(t, nil)
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				values = request.Header.Values(test.header)
+				handler(writer, request)
+			}))
+			defer server.Close()
+
+			bounds := image.Rect(0, 0, 8, 8)
+			if _, err := newTestClient(server, test.options).CreateMaskImage(gradientImage(bounds), image.NewUniform(color.White)); err != nil {
+				t.Fatalf("failed to inpaint: %v", err)
+			}
+			if test.expectedValue == "" {
+				if len(values) != 0 {
+					t.Errorf("got %s %v, want the header not sent", test.header, values)
+				}
+				return
+			}
+			if len(values) != 1 || values[0] != test.expectedValue {
+				t.Errorf("got %s %v, want %q", test.header, values, test.expectedValue)
+			}
+		})
+	}
+}
+
+func TestHttpLamaClientRetries(t *testing.T) {
+	const maxRetries = 2
+	tests := []struct {
+		name string
+		// The statuses of the attempts, after which the service succeeds.
+		statuses         []int
+		expectedAttempts int
+		expectError      bool
+	}{
+		{name: "succeeds", statuses: nil, expectedAttempts: 1},
+		{name: "bad request is not retried", statuses: []int{http.StatusBadRequest, http.StatusBadRequest}, expectedAttempts: 1, expectError: true},
+		{name: "unauthorized is not retried", statuses: []int{http.StatusUnauthorized, http.StatusUnauthorized}, expectedAttempts: 1, expectError: true},
+		{name: "server error is retried", statuses: []int{http.StatusInternalServerError}, expectedAttempts: 2},
+		{name: "rate limit is retried", statuses: []int{http.StatusTooManyRequests, http.StatusTooManyRequests}, expectedAttempts: 3},
+		{
+			name:             "retries run out",
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			expectedAttempts: maxRetries + 1,
+			expectError:      true,
+		},
+		{
+			name:             "rate limits run out",
+			statuses:         []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests},
+			expectedAttempts: maxRetries + 1,
+			expectError:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attempts := atomic.Int32{}
+			handler := inpaintHandler(t, nil)
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				attempt := int(attempts.Add(1))
+				if attempt <= len(test.statuses) {
+					http.Error(writer, "failed", test.statuses[attempt-1])
+					return
+				}
+				handler(writer, request)
+			}))
+			defer server.Close()
+
+			bounds := image.Rect(0, 0, 8, 8)
+			_, err := newTestClient(server, Options{MaxRetries: maxRetries}).CreateMaskImage(gradientImage(bounds), image.NewUniform(color.White))
+			if test.expectError && err == nil {
+				t.Errorf("got no error, want the request to fail")
+			}
+			if !test.expectError && err != nil {
+				t.Errorf("failed to inpaint: %v", err)
+			}
+			if got := int(attempts.Load()); got != test.expectedAttempts {
+				t.Errorf("got %d attempts, want %d", got, test.expectedAttempts)
+			}
+		})
+	}
+}
+
+func TestHttpLamaClientTimeout(t *testing.T) {
+	const timeout = 50 * time.Millisecond
+	// Closed when the test ends, so that the hanging handler returns before the server closes.
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		select {
+		case <-request.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	bounds := image.Rect(0, 0, 8, 8)
+	start := time.Now()
+	_, err := newTestClient(server, Options{Timeout: timeout}).CreateMaskImage(gradientImage(bounds), image.NewUniform(color.White))
+	if err == nil {
+		t.Fatalf("got no error, want the request to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 20*timeout {
+		t.Errorf("took %v, want the request to give up after about %v", elapsed, timeout)
+	}
+}
+
+func TestHttpLamaClientRejectsSmallerResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		png.Encode(writer, image.NewRGBA(image.Rect(0, 0, 4, 4)))
+	}))
+	defer server.Close()
+
+	bounds := image.Rect(0, 0, 8, 8)
+	_, err := newTestClient(server, Options{}).CreateMaskImage(gradientImage(bounds), image.NewUniform(color.White))
+	if err == nil || !strings.Contains(err.Error(), "image for a") {
+		t.Errorf("got error %v, want the smaller image rejected", err)
+	}
+}
+
+func TestHttpLamaClientAcceptsPaddedResponse(t *testing.T) {
+	bounds := image.Rect(0, 0, 10, 6)
+	img := gradientImage(bounds)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		// Pads the image to a multiple of 8 pixels, and paints every pixel as inpainted.
+		padded := image.NewRGBA(image.Rect(0, 0, 16, 8))
+		draw.Draw(padded, padded.Bounds(), &image.Uniform{C: inpaintedColor}, image.Point{}, draw.Src)
+		png.Encode(writer, padded)
+	}))
+	defer server.Close()
+
+	inpainted, err := newTestClient(server, Options{}).CreateMaskImage(img, image.NewUniform(color.White))
+	if err != nil {
+		t.Fatalf("failed to inpaint: %v", err)
+	}
+	if inpainted.Bounds() != bounds {
+		t.Errorf("got bounds %v, want the padding cut off to %v", inpainted.Bounds(), bounds)
+	}
+}
+
+func TestHttpLamaClientTiles(t *testing.T) {
+	const maxImageSize = 64
+	// The margin is a quarter of the size, leaving cores of 32 pixels starting at multiples of 32.
+	bounds := image.Rect(0, 0, 200, 100)
+	img := gradientImage(bounds)
+	mask := image.NewRGBA(bounds)
+	maskedRects := []image.Rectangle{
+		// Within the core of a single tile.
+		image.Rect(40, 40, 50, 50),
+		// Across the cores of two tiles.
+		image.Rect(100, 70, 140, 80),
+	}
+	for _, rect := range maskedRects {
+		draw.Draw(mask, rect, image.White, image.Point{}, draw.Src)
+	}
+
+	mutex := sync.Mutex{}
+	receivedSizes := []image.Point{}
+	server := httptest.NewServer(inpaintHandler(t, func(img image.Image, mask image.Image) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		receivedSizes = append(receivedSizes, img.Bounds().Size())
+	}))
+	defer server.Close()
+
+	inpainted, err := newTestClient(server, Options{MaxImageSize: maxImageSize}).CreateMaskImage(img, mask)
+	if err != nil {
+		t.Fatalf("failed to inpaint: %v", err)
+	}
+	// Tiles whose cores have no masked pixels are not sent.
+	if len(receivedSizes) != 3 {
+		t.Errorf("got %d requests, want one for each tile with masked pixels in its core", len(receivedSizes))
+	}
+	for _, size := range receivedSizes {
+		if size.X > maxImageSize || size.Y > maxImageSize {
+			t.Errorf("got a %v tile, want at most %d pixels wide and tall", size, maxImageSize)
+		}
+	}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			want := img.At(x, y)
+			for _, rect := range maskedRects {
+				if image.Pt(x, y).In(rect) {
+					want = inpaintedColor
+				}
+			}
+			if got := inpainted.At(x, y); got != want {
+				t.Fatalf("got %v at (%d, %d), want %v", got, x, y, want)
+			}
+		}
+	}
+}
+
+func TestImageTiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		bounds   image.Rectangle
+		maxSize  int
+		expected []imageTile
+	}{
+		{
+			name:     "fits",
+			bounds:   image.Rect(0, 0, 64, 40),
+			maxSize:  64,
+			expected: []imageTile{{core: image.Rect(0, 0, 64, 40), context: image.Rect(0, 0, 64, 40)}},
+		},
+		{
+			name:    "too wide",
+			bounds:  image.Rect(0, 0, 80, 20),
+			maxSize: 64,
+			expected: []imageTile{
+				{core: image.Rect(0, 0, 32, 20), context: image.Rect(0, 0, 48, 20)},
+				{core: image.Rect(32, 0, 64, 20), context: image.Rect(16, 0, 80, 20)},
+				{core: image.Rect(64, 0, 80, 20), context: image.Rect(48, 0, 80, 20)},
+			},
+		},
+		{
+			name:    "bounds not at the origin",
+			bounds:  image.Rect(10, 10, 90, 30),
+			maxSize: 64,
+			expected: []imageTile{
+				{core: image.Rect(10, 10, 42, 30), context: image.Rect(10, 10, 58, 30)},
+				{core: image.Rect(42, 10, 74, 30), context: image.Rect(26, 10, 90, 30)},
+				{core: image.Rect(74, 10, 90, 30), context: image.Rect(58, 10, 90, 30)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tiles := imageTiles(test.bounds, test.maxSize)
+			if len(tiles) != len(test.expected) {
+				t.Fatalf("got %v, want %v", tiles, test.expected)
+			}
+			for i := range tiles {
+				if tiles[i] != test.expected[i] {
+					t.Errorf("got tile %d %+v, want %+v", i, tiles[i], test.expected[i])
+				}
+			}
+		})
+	}
+}
@@ -2,65 +2,14 @@ package lama
 
 import (
 	"image"
-	"image/draw"
-	"log"
 )
 
 type LamaClient interface {
 	CreateMaskImage(originImage image.Image, maskImg image.Image) (image.Image, error)
 }
 
-// MockLamaClient provides a placeholder implementation
-// In production, this can be replaced with:
-// 1. A hosted LaMa service (e.g., on Google Cloud Run)
-// 2. A different image inpainting service (e.g., OpenAI's DALL-E, Stability AI)
-// 3. A local LaMa model deployment
-type MockLamaClient struct{}
-
-func New(lamaHost string, zeusApiKey string, lamaApiKey string) LamaClient {
-	// For now, return a mock implementation
-	// TODO: Replace with actual LaMa service implementation
-	log.Printf("Warning: Using mock LaMa client. Replace with actual implementation for production use.")
-	return &MockLamaClient{}
-}
-
-// CreateMaskImage creates a mock implementation that simply returns the original image
-// In a real implementation, this would:
-// 1. Send the original image and mask to a LaMa service
-// 2. Receive the inpainted image back
-// 3. Return the processed image
-func (l *MockLamaClient) CreateMaskImage(originImage image.Image, maskImg image.Image) (image.Image, error) {
-	// Create a copy of the original image
-	bounds := originImage.Bounds()
-	result := image.NewRGBA(bounds)
-	draw.Draw(result, bounds, originImage, bounds.Min, draw.Src)
-
-	log.Printf("Mock LaMa: Returning original image (inpainting disabled)")
-	return result, nil
-}
-
 // Alternative implementations that could be used:
 
-// GoogleCloudLamaClient - Example implementation using Google Cloud Run
-/*
-type GoogleCloudLamaClient struct {
-	client  *http.Client
-	baseURL string
-}
-
-func NewGoogleCloudLama(baseURL string) LamaClient {
-	return &GoogleCloudLamaClient{
-		client:  &http.Client{Timeout: 30 * time.Second},
-		baseURL: baseURL,
-	}
-}
-
-func (g *GoogleCloudLamaClient) CreateMaskImage(originImage image.Image, maskImg image.Image) (image.Image, error) {
-	// Implementation for Google Cloud Run hosted LaMa service
-	// This would make HTTP requests to your hosted LaMa service
-}
-*/
-
 // OpenAILamaClient - Example implementation using OpenAI's inpainting
 /*
 type OpenAILamaClient struct {
@@ -0,0 +1,373 @@
+package lama
+
+import (
+	"container/heap"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"slices"
+)
+
+const (
+	// Pixels within this distance in pixels are blended into each inpainted pixel.
+	// Larger values blur more, and smaller values leave streaks across wide masks.
+	INPAINT_RADIUS = 5
+)
+
+// The state of a pixel while the masked region is filled from its boundary inwards.
+type pixelFlag uint8
+
+const (
+	// Outside the mask, or already inpainted.
+	pixelKnown pixelFlag = iota
+	// On the front that moves inwards. Already inpainted, but its distance may still decrease.
+	pixelBand
+	// Masked, and not reached by the front yet.
+	pixelInside
+)
+
+// LocalLamaClient inpaints in-process on the CPU with the fast marching method of Telea,
+// so that texts are removed without an external service.
+// Each masked pixel is filled with a weighted average of the known pixels around it, from the boundary of the mask inwards.
+// It works best for the flat and gradient backgrounds behind most texts, and blurs detailed textures.
+// Ref: https://doi.org/10.1080/10867651.2004.10487596
+type LocalLamaClient struct{}
+
+func NewLocal() LamaClient {
+	return &LocalLamaClient{}
+}
+
+// CreateMaskImage returns the original image with the pixels where the mask is white inpainted.
+// Only the masked regions and the pixels around them are inpainted, each in a window of the image,
+// so that the memory used grows with the masks rather than with the image.
+func (l *LocalLamaClient) CreateMaskImage(originImage image.Image, maskImg image.Image) (image.Image, error) {
+	bounds := originImage.Bounds()
+	result := image.NewRGBA(bounds)
+	draw.Draw(result, bounds, originImage, bounds.Min, draw.Src)
+	mask := binaryMask(maskImg, bounds)
+
+	for _, window := range inpaintWindows(mask) {
+		newInpainter(result, mask, window).run()
+	}
+	return result, nil
+}
+
+// Returns the windows to inpaint separately, which are the bounding boxes of the masked regions grown by
+// the pixels the inpainting reads around them. Windows that overlap are merged, so that no pixel read
+// by one window is inpainted by another, and inpainting each window gives the same image as inpainting the whole image.
+func inpaintWindows(mask *image.Gray) []image.Rectangle {
+	bounds := mask.Bounds()
+	visited := make([]bool, len(mask.Pix))
+	windows := []image.Rectangle{}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			i := mask.PixOffset(x, y)
+			if mask.Pix[i] == 0 || visited[i] {
+				continue
+			}
+			visited[i] = true
+			region := image.Rect(x, y, x+1, y+1)
+			stack := []image.Point{{X: x, Y: y}}
+			for len(stack) > 0 {
+				current := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				region = region.Union(image.Rectangle{Min: current, Max: current.Add(image.Pt(1, 1))})
+				for _, offset := range []image.Point{{X: -1}, {X: 1}, {Y: -1}, {Y: 1}} {
+					neighbor := current.Add(offset)
+					if !neighbor.In(bounds) {
+						continue
+					}
+					if j := mask.PixOffset(neighbor.X, neighbor.Y); mask.Pix[j] != 0 && !visited[j] {
+						visited[j] = true
+						stack = append(stack, neighbor)
+					}
+				}
+			}
+			// Inpainted pixels blend the pixels within INPAINT_RADIUS, and the distances are solved from the adjacent pixels.
+			windows = append(windows, region.Inset(-(INPAINT_RADIUS + 1)).Intersect(bounds))
+		}
+	}
+
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(windows); i++ {
+			for j := i + 1; j < len(windows); {
+				if !windows[i].Overlaps(windows[j]) {
+					j++
+					continue
+				}
+				windows[i] = windows[i].Union(windows[j])
+				windows = slices.Delete(windows, j, j+1)
+				merged = true
+			}
+		}
+	}
+	return windows
+}
+
+// Inpaints the masked pixels within a window of an image in place.
+// The colors are read and written in the image itself, so each pixel of the window only adds a flag and a distance.
+// Coordinates are relative to the window.
+type inpainter struct {
+	img    *image.RGBA
+	window image.Rectangle
+	width  int
+	height int
+	flags  []pixelFlag
+	// The distance from the boundary of the mask. Zero outside the mask.
+	distances []float32
+	front     *pixelQueue
+}
+
+func newInpainter(img *image.RGBA, mask *image.Gray, window image.Rectangle) *inpainter {
+	p := &inpainter{
+		img:    img,
+		window: window,
+		width:  window.Dx(),
+		height: window.Dy(),
+		front:  &pixelQueue{},
+	}
+	size := p.width * p.height
+	p.flags = make([]pixelFlag, size)
+	p.distances = make([]float32, size)
+
+	for y := 0; y < p.height; y++ {
+		for x := 0; x < p.width; x++ {
+			if mask.Pix[mask.PixOffset(window.Min.X+x, window.Min.Y+y)] != 0 {
+				i := p.index(x, y)
+				p.flags[i] = pixelInside
+				p.distances[i] = float32(math.Inf(1))
+			}
+		}
+	}
+
+	// The front starts at the known pixels next to the mask.
+	for y := 0; y < p.height; y++ {
+		for x := 0; x < p.width; x++ {
+			i := p.index(x, y)
+			if p.flags[i] != pixelKnown {
+				continue
+			}
+			for _, neighbor := range p.neighbors(x, y) {
+				if p.flags[p.index(neighbor.X, neighbor.Y)] == pixelInside {
+					p.flags[i] = pixelBand
+					heap.Push(p.front, queuedPixel{x: int32(x), y: int32(y), distance: 0})
+					break
+				}
+			}
+		}
+	}
+	return p
+}
+
+// White pixels of the mask are inpainted, while transparent and black pixels are kept.
+// Generic so that the pixels of RGBA masks are read without converting each to a color.Color.
+func isMasked[C color.Color](c C) bool {
+	r, g, b, a := c.RGBA()
+	return a > 0x7fff && (r+g+b)/3 > 0x7fff
+}
+
+func (p *inpainter) index(x int, y int) int {
+	return y*p.width + x
+}
+
+// Returns the 4-connected neighbors within the image.
+func (p *inpainter) neighbors(x int, y int) []image.Point {
+	neighbors := make([]image.Point, 0, 4)
+	for _, offset := range []image.Point{{X: -1}, {X: 1}, {Y: -1}, {Y: 1}} {
+		nx, ny := x+offset.X, y+offset.Y
+		if nx >= 0 && nx < p.width && ny >= 0 && ny < p.height {
+			neighbors = append(neighbors, image.Pt(nx, ny))
+		}
+	}
+	return neighbors
+}
+
+// Moves the front inwards from the nearest pixels, inpainting each masked pixel as the front reaches it.
+func (p *inpainter) run() {
+	for p.front.Len() > 0 {
+		current := heap.Pop(p.front).(queuedPixel)
+		i := p.index(int(current.x), int(current.y))
+		if p.flags[i] == pixelKnown || current.distance > p.distances[i] {
+			continue
+		}
+		p.flags[i] = pixelKnown
+
+		for _, neighbor := range p.neighbors(int(current.x), int(current.y)) {
+			j := p.index(neighbor.X, neighbor.Y)
+			if p.flags[j] == pixelKnown {
+				continue
+			}
+			distance := float32(min(
+				p.solve(neighbor.X-1, neighbor.Y, neighbor.X, neighbor.Y-1),
+				p.solve(neighbor.X+1, neighbor.Y, neighbor.X, neighbor.Y-1),
+				p.solve(neighbor.X-1, neighbor.Y, neighbor.X, neighbor.Y+1),
+				p.solve(neighbor.X+1, neighbor.Y, neighbor.X, neighbor.Y+1),
+			))
+			if p.flags[j] == pixelInside {
+				p.flags[j] = pixelBand
+				p.distances[j] = distance
+				p.inpaint(neighbor.X, neighbor.Y)
+			} else if distance >= p.distances[j] {
+				continue
+			}
+			p.distances[j] = distance
+			heap.Push(p.front, queuedPixel{x: int32(neighbor.X), y: int32(neighbor.Y), distance: distance})
+		}
+	}
+}
+
+// Solves the eikonal equation |∇T| = 1 at the pixel between two diagonal neighbors,
+// which gives the distance of the pixel from the boundary of the mask.
+func (p *inpainter) solve(x1 int, y1 int, x2 int, y2 int) float64 {
+	distance := func(x int, y int) (float64, bool) {
+		if x < 0 || x >= p.width || y < 0 || y >= p.height {
+			return 0, false
+		}
+		i := p.index(x, y)
+		if p.flags[i] == pixelInside {
+			return 0, false
+		}
+		return float64(p.distances[i]), true
+	}
+
+	t1, ok1 := distance(x1, y1)
+	t2, ok2 := distance(x2, y2)
+	switch {
+	case ok1 && ok2:
+		if r := 2 - (t1-t2)*(t1-t2); r > 0 {
+			s := (t1 + t2 - math.Sqrt(r)) / 2
+			if s >= t1 && s >= t2 {
+				return s
+			}
+			s += math.Sqrt(r)
+			if s >= t1 && s >= t2 {
+				return s
+			}
+		}
+		return min(t1, t2) + 1
+	case ok1:
+		return t1 + 1
+	case ok2:
+		return t2 + 1
+	default:
+		return math.Inf(1)
+	}
+}
+
+// Fills the pixel with the average of the known pixels around it, weighted towards the pixels
+// that are closer, in the direction the front moves in, and at about the same distance from the boundary.
+func (p *inpainter) inpaint(x int, y int) {
+	i := p.index(x, y)
+	gradientX, gradientY := p.gradient(x, y)
+
+	sum := [4]float64{}
+	totalWeight := 0.0
+	for ny := max(0, y-INPAINT_RADIUS); ny <= min(p.height-1, y+INPAINT_RADIUS); ny++ {
+		for nx := max(0, x-INPAINT_RADIUS); nx <= min(p.width-1, x+INPAINT_RADIUS); nx++ {
+			j := p.index(nx, ny)
+			dx, dy := float64(x-nx), float64(y-ny)
+			lengthSquared := dx*dx + dy*dy
+			if p.flags[j] == pixelInside || lengthSquared == 0 || lengthSquared > INPAINT_RADIUS*INPAINT_RADIUS {
+				continue
+			}
+
+			length := math.Sqrt(lengthSquared)
+			direction := math.Abs(dx*gradientX+dy*gradientY) / length
+			if direction == 0 {
+				direction = 1e-6
+			}
+			distance := 1 / lengthSquared
+			level := 1 / (1 + math.Abs(float64(p.distances[j]-p.distances[i])))
+			weight := direction * distance * level
+
+			for channel, value := range p.pixel(nx, ny) {
+				sum[channel] += weight * float64(value)
+			}
+			totalWeight += weight
+		}
+	}
+	if totalWeight == 0 {
+		return
+	}
+	pixel := p.pixel(x, y)
+	for channel := range sum {
+		pixel[channel] = toByte(sum[channel] / totalWeight)
+	}
+}
+
+// Returns the premultiplied RGBA channels of the pixel in the image, which are written in place.
+func (p *inpainter) pixel(x int, y int) []uint8 {
+	offset := p.img.PixOffset(p.window.Min.X+x, p.window.Min.Y+y)
+	return p.img.Pix[offset : offset+4 : offset+4]
+}
+
+// Returns the unit gradient of the distance at the pixel, which points the way the front moves.
+// Zero when the neighbors are not reached yet.
+func (p *inpainter) gradient(x int, y int) (float64, float64) {
+	i := p.index(x, y)
+	along := func(dx int, dy int) float64 {
+		reached := func(nx int, ny int) bool {
+			return nx >= 0 && nx < p.width && ny >= 0 && ny < p.height && p.flags[p.index(nx, ny)] != pixelInside
+		}
+		next, previous := reached(x+dx, y+dy), reached(x-dx, y-dy)
+		switch {
+		case next && previous:
+			return float64(p.distances[p.index(x+dx, y+dy)]-p.distances[p.index(x-dx, y-dy)]) / 2
+		case next:
+			return float64(p.distances[p.index(x+dx, y+dy)] - p.distances[i])
+		case previous:
+			return float64(p.distances[i] - p.distances[p.index(x-dx, y-dy)])
+		default:
+			return 0
+		}
+	}
+
+	gradientX, gradientY := along(1, 0), along(0, 1)
+	length := math.Hypot(gradientX, gradientY)
+	if length == 0 || math.IsInf(length, 0) || math.IsNaN(length) {
+		return 0, 0
+	}
+	return gradientX / length, gradientY / length
+}
+
+func toByte(value float64) uint8 {
+	return uint8(math.Round(min(255, max(0, value))))
+}
+
+// A pixel on the front, ordered by its distance from the boundary of the mask.
+type queuedPixel struct {
+	x        int32
+	y        int32
+	distance float32
+}
+
+// A min-heap of the pixels on the front. Pixels are pushed again when their distance decreases,
+// and the stale entries are skipped when popped.
+type pixelQueue []queuedPixel
+
+func (q pixelQueue) Len() int { return len(q) }
+func (q pixelQueue) Less(i, j int) bool {
+	// Pixels at the same distance are ordered by their position, so that the order in which a region is inpainted
+	// does not depend on the other regions in the queue.
+	if q[i].distance != q[j].distance {
+		return q[i].distance < q[j].distance
+	}
+	if q[i].y != q[j].y {
+		return q[i].y < q[j].y
+	}
+	return q[i].x < q[j].x
+}
+func (q pixelQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *pixelQueue) Push(x any) {
+	*q = append(*q, x.(queuedPixel))
+}
+
+func (q *pixelQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
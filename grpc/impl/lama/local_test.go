@@ -0,0 +1,115 @@
+package lama
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"slices"
+	"testing"
+)
+
+// Returns a mask of the bounds, white within the rectangles.
+func maskOf(bounds image.Rectangle, rects ...image.Rectangle) *image.RGBA {
+	mask := image.NewRGBA(bounds)
+	for _, rect := range rects {
+		draw.Draw(mask, rect, image.White, image.Point{}, draw.Src)
+	}
+	return mask
+}
+
+func TestInpaintWindows(t *testing.T) {
+	bounds := image.Rect(0, 0, 200, 100)
+	margin := INPAINT_RADIUS + 1
+	tests := []struct {
+		name     string
+		rects    []image.Rectangle
+		expected []image.Rectangle
+	}{
+		{name: "no mask", expected: []image.Rectangle{}},
+		{
+			name:     "apart",
+			rects:    []image.Rectangle{image.Rect(20, 20, 40, 30), image.Rect(120, 60, 150, 70)},
+			expected: []image.Rectangle{image.Rect(20, 20, 40, 30).Inset(-margin), image.Rect(120, 60, 150, 70).Inset(-margin)},
+		},
+		{
+			name:     "close enough to read each other",
+			rects:    []image.Rectangle{image.Rect(20, 20, 40, 30), image.Rect(40+2*margin-1, 20, 80, 30)},
+			expected: []image.Rectangle{image.Rect(20, 20, 80, 30).Inset(-margin)},
+		},
+		{
+			name:     "just far enough apart",
+			rects:    []image.Rectangle{image.Rect(20, 20, 40, 30), image.Rect(40+2*margin, 20, 80, 30)},
+			expected: []image.Rectangle{image.Rect(20, 20, 40, 30).Inset(-margin), image.Rect(40+2*margin, 20, 80, 30).Inset(-margin)},
+		},
+		{
+			name:     "at the edge of the image",
+			rects:    []image.Rectangle{image.Rect(0, 90, 10, 100)},
+			expected: []image.Rectangle{image.Rect(0, 90-margin, 10+margin, 100)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			windows := inpaintWindows(binaryMask(maskOf(bounds, test.rects...), bounds))
+			if !slices.Equal(windows, test.expected) {
+				t.Errorf("got %v, want %v", windows, test.expected)
+			}
+		})
+	}
+}
+
+func TestCreateMaskImage(t *testing.T) {
+	// The bounds do not start at the origin, as for images cut out of a page.
+	bounds := image.Rect(10, 20, 170, 120)
+	background := color.RGBA{R: 200, G: 120, B: 40, A: 255}
+	img := image.NewRGBA(bounds)
+	draw.Draw(img, bounds, &image.Uniform{C: background}, image.Point{}, draw.Src)
+	// A dark text on the flat background, with a mask around it, and another text left unmasked.
+	text := image.Rect(40, 50, 90, 70)
+	draw.Draw(img, text, image.Black, image.Point{}, draw.Src)
+	unmaskedText := image.Rect(130, 90, 150, 100)
+	draw.Draw(img, unmaskedText, image.Black, image.Point{}, draw.Src)
+	mask := maskOf(bounds, text.Inset(-2))
+
+	inpainted, err := NewLocal().CreateMaskImage(img, mask)
+	if err != nil {
+		t.Fatalf("failed to inpaint: %v", err)
+	}
+	if inpainted.Bounds() != bounds {
+		t.Fatalf("got bounds %v, want %v", inpainted.Bounds(), bounds)
+	}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			expected := img.RGBAAt(x, y)
+			if image.Pt(x, y).In(text.Inset(-2)) {
+				expected = background
+			}
+			if got := inpainted.At(x, y); got != expected {
+				t.Fatalf("got %v at (%d, %d), want %v", got, x, y, expected)
+			}
+		}
+	}
+}
+
+func TestCreateMaskImageMatchesWholeImage(t *testing.T) {
+	// A gradient with a pattern, on which the inpainted colors depend on the order the pixels are inpainted in.
+	bounds := image.Rect(0, 0, 120, 80)
+	img := image.NewRGBA(bounds)
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			img.SetRGBA(x, y, color.RGBA{R: uint8(2 * x), G: uint8(3 * y), B: uint8(x * y % 251), A: 255})
+		}
+	}
+	mask := maskOf(bounds, image.Rect(10, 10, 30, 25), image.Rect(34, 12, 50, 30), image.Rect(80, 50, 115, 78))
+
+	inpainted, err := NewLocal().CreateMaskImage(img, mask)
+	if err != nil {
+		t.Fatalf("failed to inpaint: %v", err)
+	}
+	whole := image.NewRGBA(bounds)
+	draw.Draw(whole, bounds, img, image.Point{}, draw.Src)
+	newInpainter(whole, binaryMask(mask, bounds), bounds).run()
+	if !slices.Equal(inpainted.(*image.RGBA).Pix, whole.Pix) {
+		t.Errorf("inpainting in windows differs from inpainting the whole image")
+	}
+}
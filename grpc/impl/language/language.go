@@ -0,0 +1,165 @@
+package language
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/abadojack/whatlanggo"
+
+	pb "github.com/visionex-project/visionex/grpc"
+)
+
+const (
+	// Latin texts shorter than this are too short for trigram based detection,
+	// so the hint is used instead when it is written in Latin letters.
+	MIN_LATIN_LETTER_COUNT = 12
+	// A Hangul syllable or a Han character carries about as much text as three Latin letters,
+	// so they are weighted when deciding the dominant script of mixed-script texts.
+	// E.g., "iPhone 15 구매" is Korean.
+	CJK_LETTER_WEIGHT = 3
+)
+
+// Trigram detection only chooses between the supported Latin languages,
+// because it often mistakes short English phrases for other European languages.
+var latinOptions = whatlanggo.Options{
+	Whitelist: map[whatlanggo.Lang]bool{
+		whatlanggo.Eng: true,
+		whatlanggo.Vie: true,
+		whatlanggo.Ind: true,
+		whatlanggo.Spa: true,
+		whatlanggo.Fra: true,
+		whatlanggo.Deu: true,
+	},
+}
+
+// Characters that are written differently in Simplified and Traditional Chinese.
+// Trigram detection cannot tell them apart, so these are counted instead.
+// Ref: https://en.wikipedia.org/wiki/Simplified_Chinese_characters
+var (
+	simplifiedOnlyChars  = []rune("这个们说国来对会发后学过见经还么东车长门马书时间现实点体电开关价买卖馆无与业区号乐园场饭")
+	traditionalOnlyChars = []rune("這個們說國來對會發後學過見經還麼東車長門馬書時間現實點體電開關價買賣館無與業區號樂園場飯")
+)
+
+// Returns whether the text contains any letter, and its most likely language.
+// LANGUAGE_UNSPECIFIED with true means the text is written in a language that is not supported.
+//
+// The hint, usually the source language of the whole document, is used when the text alone is ambiguous.
+// E.g., kanji-only lines in a Japanese document, or short Latin words such as "SALE".
+func Detect(text string, hint pb.Language) (bool, pb.Language) {
+	var letterCount, hangulCount, kanaCount, hanCount, latinCount int
+	for _, char := range text {
+		if !unicode.IsLetter(char) {
+			continue
+		}
+		letterCount++
+		switch {
+		case unicode.Is(unicode.Hangul, char):
+			hangulCount++
+		case unicode.Is(unicode.Hiragana, char) || unicode.Is(unicode.Katakana, char):
+			kanaCount++
+		case unicode.Is(unicode.Han, char):
+			hanCount++
+		case unicode.Is(unicode.Latin, char):
+			latinCount++
+		}
+	}
+	if letterCount == 0 {
+		return false, pb.Language_LANGUAGE_UNSPECIFIED
+	}
+
+	// Japanese mixes kana with kanji and Latin letters, but kana is never used in other languages.
+	if kanaCount > 0 {
+		return true, pb.Language_LANGUAGE_JA_JP
+	}
+
+	hangulCount *= CJK_LETTER_WEIGHT
+	hanCount *= CJK_LETTER_WEIGHT
+	switch max(hangulCount, hanCount, latinCount) {
+	case 0:
+		// Only letters of other scripts. E.g., Cyrillic.
+		return true, fromWhatlang(whatlanggo.Detect(text).Lang)
+	case hangulCount:
+		return true, pb.Language_LANGUAGE_KO_KR
+	case hanCount:
+		// Han characters alone cannot tell Japanese kanji from Chinese.
+		if hint == pb.Language_LANGUAGE_JA_JP || hint == pb.Language_LANGUAGE_ZH_CN || hint == pb.Language_LANGUAGE_ZH_TW {
+			return true, hint
+		}
+		return true, detectChineseScript(text)
+	default:
+		if latinCount < MIN_LATIN_LETTER_COUNT && IsLatinLanguage(hint) {
+			return true, hint
+		}
+		return true, fromWhatlang(whatlanggo.DetectLangWithOptions(text, latinOptions))
+	}
+}
+
+// Returns Traditional Chinese when the text has more traditional-only characters than simplified-only ones.
+// Defaults to Simplified Chinese as it is far more common.
+func detectChineseScript(text string) pb.Language {
+	var simplifiedCount, traditionalCount int
+	for _, char := range text {
+		switch {
+		case slices.Contains(simplifiedOnlyChars, char):
+			simplifiedCount++
+		case slices.Contains(traditionalOnlyChars, char):
+			traditionalCount++
+		}
+	}
+	if traditionalCount > simplifiedCount {
+		return pb.Language_LANGUAGE_ZH_TW
+	}
+	return pb.Language_LANGUAGE_ZH_CN
+}
+
+// Returns whether the language is written in the Latin script, with spaces between words.
+func IsLatinLanguage(language pb.Language) bool {
+	switch language {
+	case pb.Language_LANGUAGE_EN_US,
+		pb.Language_LANGUAGE_VI_VN,
+		pb.Language_LANGUAGE_ID_ID,
+		pb.Language_LANGUAGE_ES_ES,
+		pb.Language_LANGUAGE_FR_FR,
+		pb.Language_LANGUAGE_DE_DE:
+		return true
+	default:
+		return false
+	}
+}
+
+// Returns the BCP 47 language tag of the language, or an empty string when it is unspecified.
+// E.g., "ko-KR" for LANGUAGE_KO_KR
+func Tag(language pb.Language) string {
+	if language == pb.Language_LANGUAGE_UNSPECIFIED {
+		return ""
+	}
+	code, region, _ := strings.Cut(strings.TrimPrefix(language.String(), "LANGUAGE_"), "_")
+	return strings.ToLower(code) + "-" + region
+}
+
+// Ref: https://github.com/abadojack/whatlanggo/blob/master/lang.go
+func fromWhatlang(language whatlanggo.Lang) pb.Language {
+	switch language {
+	case whatlanggo.Eng:
+		return pb.Language_LANGUAGE_EN_US
+	case whatlanggo.Kor:
+		return pb.Language_LANGUAGE_KO_KR
+	case whatlanggo.Jpn:
+		return pb.Language_LANGUAGE_JA_JP
+	case whatlanggo.Tha:
+		return pb.Language_LANGUAGE_TH_TH
+	case whatlanggo.Vie:
+		return pb.Language_LANGUAGE_VI_VN
+	case whatlanggo.Ind:
+		return pb.Language_LANGUAGE_ID_ID
+	case whatlanggo.Spa:
+		return pb.Language_LANGUAGE_ES_ES
+	case whatlanggo.Fra:
+		return pb.Language_LANGUAGE_FR_FR
+	case whatlanggo.Deu:
+		return pb.Language_LANGUAGE_DE_DE
+	default:
+		return pb.Language_LANGUAGE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,76 @@
+package language
+
+import (
+	"testing"
+
+	pb "github.com/visionex-project/visionex/grpc"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name           string
+		text           string
+		hint           pb.Language
+		expectedLetter bool
+		expected       pb.Language
+	}{
+		{name: "no letters", text: "12,000 - 50%", expectedLetter: false, expected: pb.Language_LANGUAGE_UNSPECIFIED},
+		{name: "hiragana", text: "ありがとう", expectedLetter: true, expected: pb.Language_LANGUAGE_JA_JP},
+		{name: "katakana", text: "セール", expectedLetter: true, expected: pb.Language_LANGUAGE_JA_JP},
+		// Kana wins even with more kanji and a Chinese hint.
+		{name: "kanji with kana", text: "東京駅前店のご案内", hint: pb.Language_LANGUAGE_ZH_CN, expectedLetter: true, expected: pb.Language_LANGUAGE_JA_JP},
+		{name: "kanji with Japanese hint", text: "営業時間", hint: pb.Language_LANGUAGE_JA_JP, expectedLetter: true, expected: pb.Language_LANGUAGE_JA_JP},
+		{name: "Han with Traditional Chinese hint", text: "营业时间", hint: pb.Language_LANGUAGE_ZH_TW, expectedLetter: true, expected: pb.Language_LANGUAGE_ZH_TW},
+		{name: "simplified Chinese without hint", text: "营业时间", expectedLetter: true, expected: pb.Language_LANGUAGE_ZH_CN},
+		{name: "traditional Chinese without hint", text: "營業時間", expectedLetter: true, expected: pb.Language_LANGUAGE_ZH_TW},
+		// The hint only matters for Han when it is Japanese or Chinese.
+		{name: "traditional Chinese with Korean hint", text: "營業時間", hint: pb.Language_LANGUAGE_KO_KR, expectedLetter: true, expected: pb.Language_LANGUAGE_ZH_TW},
+		{name: "Han without script-specific characters", text: "中文", expectedLetter: true, expected: pb.Language_LANGUAGE_ZH_CN},
+		{name: "Korean", text: "오늘의 추천 메뉴", expectedLetter: true, expected: pb.Language_LANGUAGE_KO_KR},
+		{name: "short Latin with hint", text: "SALE", hint: pb.Language_LANGUAGE_FR_FR, expectedLetter: true, expected: pb.Language_LANGUAGE_FR_FR},
+		{name: "short Latin with German hint", text: "Angebot", hint: pb.Language_LANGUAGE_DE_DE, expectedLetter: true, expected: pb.Language_LANGUAGE_DE_DE},
+		// A hint that is not written in Latin letters is not used for Latin texts.
+		{name: "Latin with Korean hint", text: "Fresh bread baked every morning", hint: pb.Language_LANGUAGE_KO_KR, expectedLetter: true, expected: pb.Language_LANGUAGE_EN_US},
+		// Long enough for trigram detection, which overrides the hint.
+		{
+			name:           "long Latin with hint",
+			text:           "Nous sommes ouverts tous les jours de la semaine",
+			hint:           pb.Language_LANGUAGE_EN_US,
+			expectedLetter: true,
+			expected:       pb.Language_LANGUAGE_FR_FR,
+		},
+		{name: "English", text: "Fresh bread baked every morning", expectedLetter: true, expected: pb.Language_LANGUAGE_EN_US},
+		// 6 Latin letters tie with 2 Hangul syllables weighted by CJK_LETTER_WEIGHT, and ties go to Hangul.
+		{name: "Latin and Hangul", text: "iPhone 15 구매", expectedLetter: true, expected: pb.Language_LANGUAGE_KO_KR},
+		{name: "mostly Latin with Hangul", text: "Limited Edition Collection 한정", expectedLetter: true, expected: pb.Language_LANGUAGE_EN_US},
+		{name: "Latin and Han", text: "Pizza 披萨", expectedLetter: true, expected: pb.Language_LANGUAGE_ZH_CN},
+		{name: "Thai", text: "สวัสดีครับ ยินดีต้อนรับ", expectedLetter: true, expected: pb.Language_LANGUAGE_TH_TH},
+		{name: "unsupported language", text: "Добро пожаловать", expectedLetter: true, expected: pb.Language_LANGUAGE_UNSPECIFIED},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hasLetter, language := Detect(test.text, test.hint)
+			if hasLetter != test.expectedLetter || language != test.expected {
+				t.Errorf("got %v, %v, want %v, %v", hasLetter, language, test.expectedLetter, test.expected)
+			}
+		})
+	}
+}
+
+func TestTag(t *testing.T) {
+	tests := []struct {
+		language pb.Language
+		expected string
+	}{
+		{language: pb.Language_LANGUAGE_KO_KR, expected: "ko-KR"},
+		{language: pb.Language_LANGUAGE_ZH_TW, expected: "zh-TW"},
+		{language: pb.Language_LANGUAGE_UNSPECIFIED, expected: ""},
+	}
+
+	for _, test := range tests {
+		if got := Tag(test.language); got != test.expected {
+			t.Errorf("got %q for %v, want %q", got, test.language, test.expected)
+		}
+	}
+}
@@ -0,0 +1,387 @@
+package impl
+
+import (
+	"fmt"
+	"html"
+	"image"
+	"log"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/fogleman/gg"
+	"github.com/lucasb-eyer/go-colorful"
+	"golang.org/x/image/font/sfnt"
+
+	pb "github.com/visionex-project/visionex/grpc"
+	"github.com/visionex-project/visionex/grpc/impl/font"
+	"github.com/visionex-project/visionex/grpc/impl/language"
+	"github.com/visionex-project/visionex/pkg/utils"
+)
+
+const (
+	// The embedded fonts are named by this prefix followed by their index. E.g., "font-0"
+	EMBEDDED_FONT_FAMILY_PREFIX = "font-"
+)
+
+// Returns whether the format keeps the translated texts as text on the background, instead of drawing them.
+func isLayeredFormat(format pb.OutputFormat) bool {
+	return format == pb.OutputFormat_OUTPUT_FORMAT_SVG || format == pb.OutputFormat_OUTPUT_FORMAT_HTML
+}
+
+// Encodes the rows of the translated page between top and bottom as requested,
+// and returns the encoded bytes and their MIME type.
+func encodeTranslatedPage(page translatedPage, top int, bottom int, encoding *pb.OutputEncoding, targetLanguage pb.Language, targetLanguageFonts *font.FontsByFace) ([]byte, string, error) {
+	if !isLayeredFormat(encoding.GetFormat()) {
+		return encodeImage(pageRows(page.image, top, bottom), encoding)
+	}
+
+	// The background is always lossless, as it is often reused to edit the texts.
+	background, backgroundMimeType, err := encodeImage(pageRows(page.background, top, bottom), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	layered := &layeredPage{
+		width:               page.background.Bounds().Dx(),
+		top:                 top,
+		bottom:              bottom,
+		background:          toDataUri(background, backgroundMimeType),
+		words:               utils.Filter(page.words, func(word wordSegment) bool { return overlapsRows(word, top, bottom) }),
+		language:            language.Tag(targetLanguage),
+		drawingContext:      gg.NewContext(1, 1),
+		targetLanguageFonts: targetLanguageFonts,
+		fontFamilies:        map[*sfnt.Font]string{},
+		fontRunes:           map[*sfnt.Font][]rune{},
+	}
+	if encoding.GetFormat() == pb.OutputFormat_OUTPUT_FORMAT_HTML {
+		return []byte(layered.html()), MIME_TYPE_HTML, nil
+	}
+	return []byte(layered.svg()), MIME_TYPE_SVG, nil
+}
+
+// Returns the rows of the image between top and bottom, or the image itself when they are all of its rows.
+func pageRows(img image.Image, top int, bottom int) image.Image {
+	if top == 0 && bottom == img.Bounds().Dy() {
+		return img
+	}
+	return cropRows(img, top, bottom)
+}
+
+// Returns whether anything of the word, including its effects, may be drawn between the rows.
+func overlapsRows(word wordSegment, top int, bottom int) bool {
+	// Effects reach at most about the font size beyond the box.
+	margin := *word.fontSize
+	corners := []point{
+		{x: float64(word.position.left), y: float64(word.position.top)},
+		{x: float64(word.position.right), y: float64(word.position.top)},
+		{x: float64(word.position.right), y: float64(word.position.bottom)},
+		{x: float64(word.position.left), y: float64(word.position.bottom)},
+	}
+	if word.rotation != nil {
+		corners = utils.Map(corners, word.rotation.fromUpright)
+	}
+	ys := utils.Map(corners, func(corner point) float64 {
+		return corner.y
+	})
+	return slices.Min(ys)-margin < float64(bottom) && slices.Max(ys)+margin > float64(top)
+}
+
+// The rows of a translated page as a background image with the translated texts on it.
+type layeredPage struct {
+	width  int
+	top    int
+	bottom int
+	// The data URI of the background without texts.
+	background string
+	// The words drawn between the rows, in the coordinates of the page.
+	words []wordSegment
+	// The language tag of the texts. E.g., "ko-KR"
+	language string
+
+	// Used to measure the texts with the fonts they are drawn with.
+	drawingContext      *gg.Context
+	targetLanguageFonts *font.FontsByFace
+	// The family names of the fonts used by the words, so that each font is embedded once.
+	fontFamilies map[*sfnt.Font]string
+	fonts        []*sfnt.Font
+	// The runes of the words drawn with each font, whose glyphs are the only ones embedded.
+	fontRunes map[*sfnt.Font][]rune
+}
+
+// Returns the font-family value of a word, listing only the fonts of its chain that draw some of its runes,
+// so that the fonts never used are not embedded. E.g., `"font-0", "font-1"`
+func (p *layeredPage) fontFamily(word wordSegment) string {
+	chain := getFontByStyle(p.targetLanguageFonts, word.style).Covering(word.text)
+	families := []string{}
+	for _, chainFont := range chain {
+		if _, ok := font.Data(chainFont); !ok {
+			continue
+		}
+		if _, ok := p.fontFamilies[chainFont]; !ok {
+			p.fontFamilies[chainFont] = EMBEDDED_FONT_FAMILY_PREFIX + strconv.Itoa(len(p.fonts))
+			p.fonts = append(p.fonts, chainFont)
+		}
+		p.fontRunes[chainFont] = append(p.fontRunes[chainFont], []rune(word.text)...)
+		families = append(families, strconv.Quote(p.fontFamilies[chainFont]))
+	}
+	return strings.Join(families, ", ")
+}
+
+// Returns the @font-face rules of the fonts used so far, with the fonts embedded as data URIs.
+// Each font is reduced to the glyphs of the words on the page, so that every page and every slice
+// embeds only kilobytes of the fonts, rather than megabytes for CJK.
+func (p *layeredPage) fontFaces() string {
+	rules := utils.Map(p.fonts, func(usedFont *sfnt.Font) string {
+		data, err := font.Subset(usedFont, p.fontRunes[usedFont])
+		if err != nil {
+			// The whole font draws the same glyphs, only with a larger output.
+			log.Printf("Failed to subset font %s, embedding it whole: %v", p.fontFamilies[usedFont], err)
+			data, _ = font.Data(usedFont)
+		}
+		return fmt.Sprintf("@font-face { font-family: %q; src: url(%s); }", p.fontFamilies[usedFont], toDataUri(data, font.MimeType(data)))
+	})
+	return strings.Join(rules, "\n")
+}
+
+// The text of a word without the trailing space kept between words, as the space is part of the position of the next word.
+func wordText(word wordSegment) string {
+	return strings.TrimRightFunc(word.text, unicode.IsSpace)
+}
+
+// Returns the transform functions that rotate and skew a word like drawRotated, or nothing when the word is upright.
+// The functions are the same in SVG and CSS but for the units. E.g., "translate(50px, 20px) rotate(10deg) ..."
+func lineTransform(rotation *textRotation, pixel string, degree string) []string {
+	if rotation == nil {
+		return nil
+	}
+	centerX, centerY := formatNumber(rotation.centerX), formatNumber(rotation.centerY)
+	return []string{
+		fmt.Sprintf("translate(%s%s, %s%s)", centerX, pixel, centerY, pixel),
+		fmt.Sprintf("rotate(%s%s)", formatNumber(rotation.angle*180/math.Pi), degree),
+		fmt.Sprintf("skewX(%s%s)", formatNumber(math.Atan(rotation.shear)*180/math.Pi), degree),
+		fmt.Sprintf("translate(%s%s, %s%s)", formatNumber(-rotation.centerX), pixel, formatNumber(-rotation.centerY), pixel),
+	}
+}
+
+// Returns the transform functions that rotate a vertical character by 90 degrees around its center.
+func characterTransform(centerX float64, centerY float64, pixel string, degree string) []string {
+	return []string{
+		fmt.Sprintf("translate(%s%s, %s%s)", formatNumber(centerX), pixel, formatNumber(centerY), pixel),
+		"rotate(90" + degree + ")",
+		fmt.Sprintf("translate(%s%s, %s%s)", formatNumber(-centerX), pixel, formatNumber(-centerY), pixel),
+	}
+}
+
+// Returns the SVG document with the background as an image and the words as text elements.
+func (p *layeredPage) svg() string {
+	elements := []string{}
+	// Highlight bands go first, so that a band never covers the text of a neighboring word, as in drawTexts.
+	for _, word := range p.words {
+		if word.style.effects == nil || word.style.effects.highlight == nil {
+			continue
+		}
+		left, top, width, height := highlightBand(p.drawingContext, p.targetLanguageFonts, word)
+		elements = append(elements, fmt.Sprintf(
+			`<rect x="%s" y="%s" width="%s" height="%s" fill="%s"%s/>`,
+			formatNumber(left), formatNumber(top), formatNumber(width), formatNumber(height),
+			hexColor(word.style.effects.highlight.color), svgTransform(lineTransform(word.rotation, "", "")),
+		))
+	}
+
+	for i, word := range p.words {
+		x, y, rotated := wordBaseline(word)
+		text := wordText(word)
+		decorations := decorationLines(p.drawingContext, p.targetLanguageFonts, p.words, i)
+		attributes := fmt.Sprintf(`x="%s" y="%s" font-family="%s" font-size="%s"`,
+			formatNumber(x), formatNumber(y), html.EscapeString(p.fontFamily(word)), formatNumber(*word.fontSize))
+		if isSyntheticOblique(p.targetLanguageFonts, word) {
+			// The embedded fonts are declared upright, so browsers slant them like drawOblique.
+			attributes += ` font-style="italic"`
+		}
+		var charTransform []string
+		if word.vertical {
+			attributes += ` text-anchor="middle"`
+			if rotated {
+				centerX, centerY, _ := verticalCharacterCenter(word)
+				charTransform = characterTransform(centerX, centerY, "", "")
+			}
+		} else {
+			// Stretches the text to the width it is laid out with, as renderers measure text slightly differently.
+			width := measureText(p.drawingContext, p.targetLanguageFonts, word, text)
+			attributes += fmt.Sprintf(` textLength="%s" lengthAdjust="spacingAndGlyphs"`, formatNumber(width))
+		}
+
+		effects := word.style.effects
+		if effects != nil && effects.shadow != nil {
+			offset := fmt.Sprintf("translate(%s, %s)", formatNumber(effects.shadow.offsetXRatio**word.fontSize), formatNumber(effects.shadow.offsetYRatio**word.fontSize))
+			elements = append(elements, fmt.Sprintf(`<text %s fill="%s"%s>%s</text>`,
+				attributes, hexColor(effects.shadow.color),
+				svgTransform(utils.Concat(lineTransform(word.rotation, "", ""), []string{offset}, charTransform)),
+				html.EscapeString(text),
+			))
+			elements = append(elements, svgDecorations(decorations, 0, hexColor(effects.shadow.color), utils.Concat(lineTransform(word.rotation, "", ""), []string{offset}))...)
+		}
+		if effects != nil && effects.outline != nil {
+			// The stroke is centered on the edges of the glyphs and painted under the fill, so half of it shows.
+			width := max(1, math.Round(effects.outline.widthRatio**word.fontSize))
+			attributes += fmt.Sprintf(` stroke="%s" stroke-width="%s" stroke-linejoin="round" paint-order="stroke"`,
+				hexColor(effects.outline.color), formatNumber(2*width))
+			elements = append(elements, svgDecorations(decorations, width, hexColor(effects.outline.color), lineTransform(word.rotation, "", ""))...)
+		}
+		elements = append(elements, fmt.Sprintf(`<text %s fill="%s"%s>%s</text>`,
+			attributes, hexColor(word.style.textColor),
+			svgTransform(utils.Concat(lineTransform(word.rotation, "", ""), charTransform)),
+			html.EscapeString(text),
+		))
+		elements = append(elements, svgDecorations(decorations, 0, hexColor(word.style.textColor), lineTransform(word.rotation, "", ""))...)
+	}
+
+	height := p.bottom - p.top
+	builder := strings.Builder{}
+	fmt.Fprintf(&builder, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d"`, p.width, height, p.width, height)
+	if p.language != "" {
+		fmt.Fprintf(&builder, ` xml:lang="%s"`, p.language)
+	}
+	builder.WriteString(">\n")
+	// The fonts are known only after the words are written, as only the fonts drawing some rune are embedded.
+	fmt.Fprintf(&builder, "<style>\n%s\n</style>\n", p.fontFaces())
+	fmt.Fprintf(&builder, `<image href="%s" width="%d" height="%d"/>`+"\n", p.background, p.width, height)
+	fmt.Fprintf(&builder, `<g transform="translate(0, %d)" xml:space="preserve">`+"\n", -p.top)
+	builder.WriteString(strings.Join(elements, "\n"))
+	builder.WriteString("\n</g>\n</svg>\n")
+	return builder.String()
+}
+
+// Returns the HTML document with the background as an image and the words as absolutely positioned elements.
+func (p *layeredPage) html() string {
+	elements := []string{}
+	for _, word := range p.words {
+		if word.style.effects == nil || word.style.effects.highlight == nil {
+			continue
+		}
+		left, top, width, height := highlightBand(p.drawingContext, p.targetLanguageFonts, word)
+		elements = append(elements, fmt.Sprintf(
+			`<div class="highlight" style="width: %spx; height: %spx; background: %s; transform: %s;"></div>`,
+			formatNumber(width), formatNumber(height), hexColor(word.style.effects.highlight.color),
+			strings.Join(utils.Concat(lineTransform(word.rotation, "px", "deg"), []string{cssTranslate(left, top)}), " "),
+		))
+	}
+
+	for i, word := range p.words {
+		x, y, rotated := wordBaseline(word)
+		text := wordText(word)
+		decorations := decorationLines(p.drawingContext, p.targetLanguageFonts, p.words, i)
+		width := measureText(p.drawingContext, p.targetLanguageFonts, word, text)
+		if word.vertical {
+			x -= width / 2
+		}
+		// Elements are placed by their top, so the top is found from the baseline the way browsers place it,
+		// centering the ascent and descent of the first font in a line box as tall as the font size.
+		metrics := getFontByStyle(p.targetLanguageFonts, word.style).NewFace(*word.fontSize).Metrics()
+		ascent, descent := float64(metrics.Ascent)/64, float64(metrics.Descent)/64
+		top := y - (*word.fontSize+ascent-descent)/2
+
+		transform := lineTransform(word.rotation, "px", "deg")
+		shadowX, shadowY := 0.0, 0.0
+		if effects := word.style.effects; effects != nil && effects.shadow != nil {
+			shadowX, shadowY = effects.shadow.offsetXRatio**word.fontSize, effects.shadow.offsetYRatio**word.fontSize
+		}
+		if rotated {
+			centerX, centerY, _ := verticalCharacterCenter(word)
+			transform = append(transform, characterTransform(centerX, centerY, "px", "deg")...)
+			// Shadows are offset in the coordinates of the element, so the offset is turned back against the rotation.
+			shadowX, shadowY = shadowY, -shadowX
+		}
+		transform = append(transform, cssTranslate(x, top))
+
+		style := fmt.Sprintf("font-family: %s; font-size: %spx; color: %s; transform: %s;",
+			p.fontFamily(word), formatNumber(*word.fontSize), hexColor(word.style.textColor), strings.Join(transform, " "))
+		if isSyntheticOblique(p.targetLanguageFonts, word) {
+			style += " font-style: italic;"
+		}
+		// Decorations are boxes with the shadow or the outline of the text as box shadows.
+		decorationStyle := ""
+		if effects := word.style.effects; effects != nil && effects.shadow != nil {
+			style += fmt.Sprintf(" text-shadow: %spx %spx %s;", formatNumber(shadowX), formatNumber(shadowY), hexColor(effects.shadow.color))
+			decorationStyle = fmt.Sprintf(" box-shadow: %spx %spx %s;",
+				formatNumber(effects.shadow.offsetXRatio**word.fontSize), formatNumber(effects.shadow.offsetYRatio**word.fontSize), hexColor(effects.shadow.color))
+		}
+		if effects := word.style.effects; effects != nil && effects.outline != nil {
+			outlineWidth := max(1, math.Round(effects.outline.widthRatio**word.fontSize))
+			style += fmt.Sprintf(" -webkit-text-stroke: %spx %s; paint-order: stroke fill;", formatNumber(2*outlineWidth), hexColor(effects.outline.color))
+			decorationStyle = fmt.Sprintf(" box-shadow: 0 0 0 %spx %s;", formatNumber(outlineWidth), hexColor(effects.outline.color))
+		}
+		elements = append(elements, fmt.Sprintf(`<div class="text" style="%s">%s</div>`, html.EscapeString(style), html.EscapeString(text)))
+		for _, decoration := range decorations {
+			left, top, width, height := decorationRect(decoration, 0)
+			elements = append(elements, fmt.Sprintf(
+				`<div class="decoration" style="width: %spx; height: %spx; background: %s; transform: %s;%s"></div>`,
+				formatNumber(width), formatNumber(height), hexColor(word.style.textColor),
+				strings.Join(utils.Concat(lineTransform(word.rotation, "px", "deg"), []string{cssTranslate(left, top)}), " "), decorationStyle,
+			))
+		}
+	}
+
+	height := p.bottom - p.top
+	builder := strings.Builder{}
+	builder.WriteString("<!DOCTYPE html>\n")
+	if p.language != "" {
+		fmt.Fprintf(&builder, "<html lang=\"%s\">\n", p.language)
+	} else {
+		builder.WriteString("<html>\n")
+	}
+	builder.WriteString("<head>\n<meta charset=\"utf-8\">\n<style>\n")
+	builder.WriteString(p.fontFaces() + "\n")
+	fmt.Fprintf(&builder, ".page { position: relative; width: %dpx; height: %dpx; overflow: hidden; }\n", p.width, height)
+	builder.WriteString(".page > img { position: absolute; left: 0; top: 0; }\n")
+	fmt.Fprintf(&builder, ".texts { position: absolute; left: 0; top: %dpx; }\n", -p.top)
+	builder.WriteString(".texts > div { position: absolute; left: 0; top: 0; transform-origin: 0 0; }\n")
+	builder.WriteString(".text { line-height: 1; white-space: pre; }\n")
+	builder.WriteString("</style>\n</head>\n<body style=\"margin: 0;\">\n")
+	fmt.Fprintf(&builder, "<div class=\"page\">\n<img src=\"%s\" width=\"%d\" height=\"%d\" alt=\"\">\n", p.background, p.width, height)
+	builder.WriteString("<div class=\"texts\">\n")
+	builder.WriteString(strings.Join(elements, "\n"))
+	builder.WriteString("\n</div>\n</div>\n</body>\n</html>\n")
+	return builder.String()
+}
+
+// Returns the rectangle of an underline or a strikeout grown by the padding on every side, as left, top, width and height.
+func decorationRect(line decorationLine, padding float64) (float64, float64, float64, float64) {
+	halfThickness := line.thickness / 2
+	if line.from.x == line.to.x {
+		return line.from.x - halfThickness - padding, line.from.y - padding, line.thickness + 2*padding, line.to.y - line.from.y + 2*padding
+	}
+	return line.from.x - padding, line.from.y - halfThickness - padding, line.to.x - line.from.x + 2*padding, line.thickness + 2*padding
+}
+
+// Returns the underlines and the strikeouts as rectangles grown by the padding.
+func svgDecorations(lines []decorationLine, padding float64, fill string, transform []string) []string {
+	return utils.Map(lines, func(line decorationLine) string {
+		left, top, width, height := decorationRect(line, padding)
+		return fmt.Sprintf(`<rect x="%s" y="%s" width="%s" height="%s" fill="%s"%s/>`,
+			formatNumber(left), formatNumber(top), formatNumber(width), formatNumber(height), fill, svgTransform(transform))
+	})
+}
+
+func svgTransform(transform []string) string {
+	if len(transform) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(` transform="%s"`, strings.Join(transform, " "))
+}
+
+func cssTranslate(x float64, y float64) string {
+	return fmt.Sprintf("translate(%spx, %spx)", formatNumber(x), formatNumber(y))
+}
+
+// Rounds to two decimal places, which is finer than a pixel can show. E.g., "12.35"
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+// E.g., "#1a0080"
+func hexColor(color colorful.Color) string {
+	return color.Clamped().Hex()
+}
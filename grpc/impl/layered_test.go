@@ -0,0 +1,49 @@
+package impl
+
+import (
+	"encoding/base64"
+	"regexp"
+	"testing"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/font/sfnt"
+
+	pb "github.com/visionex-project/visionex/grpc"
+)
+
+func TestLayeredPageEmbedsFontSubsets(t *testing.T) {
+	fonts := testFonts(t, pb.Language_LANGUAGE_JA_JP)
+	fontSize := 24.0
+	page := &layeredPage{
+		drawingContext:      gg.NewContext(1, 1),
+		targetLanguageFonts: fonts,
+		fontFamilies:        map[*sfnt.Font]string{},
+		fontRunes:           map[*sfnt.Font][]rune{},
+	}
+	for _, text := range []string{"予約確認", "ご来店ありがとうございます"} {
+		page.fontFamily(wordSegment{text: text, fontSize: &fontSize, style: &style{}})
+	}
+
+	sources := regexp.MustCompile(`url\(data:font/[a-z]+;base64,([^)]*)\)`).FindAllStringSubmatch(page.fontFaces(), -1)
+	if len(sources) != 1 {
+		t.Fatalf("got %d embedded fonts, want the regular Japanese font", len(sources))
+	}
+	data, err := base64.StdEncoding.DecodeString(sources[0][1])
+	if err != nil {
+		t.Fatalf("failed to decode the font: %v", err)
+	}
+	// The whole font is a few megabytes.
+	if len(data) > 50_000 {
+		t.Errorf("got %d bytes, want the glyphs of the words only", len(data))
+	}
+	embedded, err := sfnt.Parse(data)
+	if err != nil {
+		t.Fatalf("failed to parse the font: %v", err)
+	}
+	buffer := &sfnt.Buffer{}
+	for _, r := range "予約確認ご来店ありがとうございます" {
+		if index, err := embedded.GlyphIndex(buffer, r); err != nil || index == 0 {
+			t.Errorf("%q: got glyph %d and error %v, want the glyph embedded", r, index, err)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package impl
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/fogleman/gg"
+
+	pb "github.com/visionex-project/visionex/grpc"
+	"github.com/visionex-project/visionex/grpc/impl/language"
+)
+
+const (
+	// Words shorter than this are never hyphenated. E.g., "menu" is moved to the next line as a whole.
+	MIN_HYPHENATION_WORD_LENGTH = 6
+	// The minimum number of letters kept on each side of a hyphen. E.g., "re-" and "-tion".
+	MIN_HYPHENATION_PREFIX_LENGTH = 2
+	MIN_HYPHENATION_SUFFIX_LENGTH = 3
+	// A word is hyphenated only when moving it to the next line as a whole would leave
+	// at least this fraction of the line empty, as hyphens are harder to read than ragged lines.
+	HYPHENATION_MIN_EMPTY_RATIO = 0.3
+)
+
+// Kinsoku shori: characters that must not start a line in Japanese, Chinese and Korean.
+// Ref: https://en.wikipedia.org/wiki/Line_breaking_rules_in_East_Asian_languages
+var noLineStartChars = []rune("、。，．,.・：；？！:;?!ー‐–〜～ゝゞヽヾ々…‥」』）)］]｝}〉》】〕＞>’”%％" +
+	"ぁぃぅぇぉっゃゅょゎゕゖァィゥェォッャュョヮヵヶ")
+
+// Characters that must not end a line. Thai leading vowels are written before the consonant they follow.
+var noLineEndChars = []rune("「『（(［[｛{〈《【〔＜<‘“¥￥$＄#＃" + "เแโใไ")
+
+// Vowels used to find syllable boundaries for hyphenation, including the accented ones of the supported Latin languages.
+var hyphenationVowels = []rune("aeiouyàáâãäåæèéêëìíîïòóôõöøùúûüýÿăơư")
+
+// Splits the text into the longest head that fits into the width and ends at a line break opportunity, and the rest.
+// Latin languages and Korean break between words, while Japanese, Chinese and Thai break between characters,
+// following kinsoku rules. Long Latin words may be hyphenated.
+// When nothing fits, the head is empty so that the text moves to the next line, unless the line is still empty,
+// in which case the text is broken between characters as a last resort.
+func breakText(drawingContext *gg.Context, text string, availableWidth float64, lineWidth float64, targetLanguage pb.Language) (string, string) {
+	fits := func(head string) bool {
+		width, _ := drawingContext.MeasureString(strings.TrimRightFunc(head, unicode.IsSpace))
+		return width <= availableWidth
+	}
+	if fits(text) {
+		return text, ""
+	}
+
+	runes := []rune(text)
+	split := func(index int) (string, string) {
+		return string(runes[:index]), strings.TrimLeftFunc(string(runes[index:]), unicode.IsSpace)
+	}
+	breakIndex := lastFittingBreak(runes, fits, func(index int) bool {
+		return canBreakBefore(runes, index, targetLanguage)
+	})
+
+	if language.IsLatinLanguage(targetLanguage) {
+		headWidth, _ := drawingContext.MeasureString(strings.TrimRightFunc(string(runes[:breakIndex]), unicode.IsSpace))
+		if breakIndex == 0 || (availableWidth-headWidth)/lineWidth >= HYPHENATION_MIN_EMPTY_RATIO {
+			if hyphenIndex := hyphenationIndex(runes, breakIndex, fits); hyphenIndex > 0 {
+				head, tail := split(hyphenIndex)
+				return head + "-", tail
+			}
+		}
+	}
+	if breakIndex > 0 {
+		return split(breakIndex)
+	}
+	if availableWidth < lineWidth {
+		return "", text
+	}
+
+	// A single word wider than the whole line, such as a long German compound or a Korean eojeol.
+	breakIndex = lastFittingBreak(runes, fits, func(index int) bool {
+		return canBreakBetweenCharacters(runes, index)
+	})
+	if breakIndex == 0 {
+		// Even a single character does not fit, so it overflows rather than being dropped.
+		breakIndex = 1
+		for breakIndex < len(runes) && unicode.Is(unicode.Mn, runes[breakIndex]) {
+			breakIndex++
+		}
+	}
+	return split(breakIndex)
+}
+
+// Returns the largest index where the line can break and the text before it fits, or 0 if there is none.
+func lastFittingBreak(runes []rune, fits func(head string) bool, canBreak func(index int) bool) int {
+	breakIndex := 0
+	for index := 1; index < len(runes); index++ {
+		if !canBreak(index) {
+			continue
+		}
+		// The width only grows with the index, so there is no need to look further.
+		if !fits(string(runes[:index])) {
+			break
+		}
+		breakIndex = index
+	}
+	return breakIndex
+}
+
+// Returns whether a line can break between runes[index-1] and runes[index].
+func canBreakBefore(runes []rune, index int, targetLanguage pb.Language) bool {
+	previous, current := runes[index-1], runes[index]
+	// Spaces stay at the end of the previous line, so that the next line does not start with a space.
+	if unicode.IsSpace(current) {
+		return false
+	}
+	if unicode.IsSpace(previous) {
+		return true
+	}
+
+	switch {
+	case language.IsLatinLanguage(targetLanguage):
+		// Hyphenated compounds such as "check-in" can break after the hyphen.
+		return previous == '-' && index >= 2 && unicode.IsLetter(runes[index-2]) && unicode.IsLetter(current)
+	case targetLanguage == pb.Language_LANGUAGE_KO_KR:
+		// Korean breaks between eojeols, the space separated units of words and particles.
+		return false
+	default:
+		return canBreakBetweenCharacters(runes, index)
+	}
+}
+
+// Returns whether a line can break between two characters, following kinsoku rules.
+// Latin words and numbers within Japanese or Chinese text, such as "iPhone 15", are kept together.
+func canBreakBetweenCharacters(runes []rune, index int) bool {
+	previous, current := runes[index-1], runes[index]
+	if unicode.Is(unicode.Mn, current) {
+		return false
+	}
+	if slices.Contains(noLineStartChars, current) || slices.Contains(noLineEndChars, previous) {
+		return false
+	}
+	return !isAlphanumeric(previous) || !isAlphanumeric(current)
+}
+
+func isAlphanumeric(char rune) bool {
+	return unicode.In(char, unicode.Latin) || unicode.IsDigit(char)
+}
+
+// Returns the largest index in the word starting at wordStart where the word can be hyphenated
+// and the text before it fits along with the hyphen, or 0 if there is none.
+// Syllable boundaries are approximated by vowel-consonant patterns, e.g., "reser-vation" and "reserva-tion".
+func hyphenationIndex(runes []rune, wordStart int, fits func(head string) bool) int {
+	wordEnd := wordStart
+	for wordEnd < len(runes) && unicode.IsLetter(runes[wordEnd]) {
+		wordEnd++
+	}
+	if wordEnd-wordStart < MIN_HYPHENATION_WORD_LENGTH {
+		return 0
+	}
+
+	isVowel := func(index int) bool {
+		return slices.Contains(hyphenationVowels, unicode.ToLower(runes[index]))
+	}
+	hyphenIndex := 0
+	for index := wordStart + MIN_HYPHENATION_PREFIX_LENGTH; index <= wordEnd-MIN_HYPHENATION_SUFFIX_LENGTH; index++ {
+		// The next syllable starts with a single consonant followed by a vowel, e.g., "re-ser" and "va-tion",
+		// or a consonant cluster is split in the middle, e.g., "reser-va".
+		isVowelConsonant := isVowel(index-1) && !isVowel(index) && isVowel(index+1)
+		isConsonantConsonant := !isVowel(index-1) && !isVowel(index) && isVowel(index-2) && isVowel(index+1)
+		if !isVowelConsonant && !isConsonantConsonant {
+			continue
+		}
+		if !fits(string(runes[:index]) + "-") {
+			break
+		}
+		hyphenIndex = index
+	}
+	return hyphenIndex
+}
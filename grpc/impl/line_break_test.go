@@ -0,0 +1,217 @@
+package impl
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fogleman/gg"
+
+	pb "github.com/visionex-project/visionex/grpc"
+	"github.com/visionex-project/visionex/grpc/impl/font"
+)
+
+// The fonts shipped with the repository, which are parsed once for every test of the package.
+var loadTestFonts = sync.OnceValues(func() (font.FontProvider, error) {
+	return font.New("../cmd/fonts")
+})
+
+// Returns the fonts of the language that texts are measured and drawn with in tests.
+func testFonts(t *testing.T, language pb.Language) *font.FontsByFace {
+	t.Helper()
+	fontProvider, err := loadTestFonts()
+	if err != nil {
+		t.Fatalf("failed to load fonts: %v", err)
+	}
+	fonts, err := fontProvider.GetFontByLanguage(language)
+	if err != nil {
+		t.Fatalf("failed to get %s fonts: %v", language, err)
+	}
+	return fonts
+}
+
+// Returns a drawing context measuring with the regular sans serif font of the language at the size.
+func testDrawingContext(t *testing.T, language pb.Language, fontSize float64) *gg.Context {
+	t.Helper()
+	drawingContext := gg.NewContext(1, 1)
+	drawingContext.SetFontFace(getFontByStyle(testFonts(t, language), &style{}).NewFace(fontSize))
+	return drawingContext
+}
+
+func TestBreakText(t *testing.T) {
+	tests := []struct {
+		name     string
+		language pb.Language
+		text     string
+		// The available width is the width of this text, so that a naive break would be right after it.
+		fitting string
+		// The line is wider than the available width, as when the text follows other words.
+		startsLine   bool
+		expectedHead string
+		expectedTail string
+	}{
+		{
+			name:         "fits as a whole",
+			language:     pb.Language_LANGUAGE_JA_JP,
+			text:         "あいう",
+			fitting:      "あいうえお",
+			startsLine:   true,
+			expectedHead: "あいう",
+			expectedTail: "",
+		},
+		{
+			name:         "Japanese breaks between characters",
+			language:     pb.Language_LANGUAGE_JA_JP,
+			text:         "あいうえおかきく",
+			fitting:      "あいうえお",
+			startsLine:   true,
+			expectedHead: "あいうえお",
+			expectedTail: "かきく",
+		},
+		{
+			name:         "period does not start a line",
+			language:     pb.Language_LANGUAGE_JA_JP,
+			text:         "あいうえお。かきく",
+			fitting:      "あいうえお",
+			startsLine:   true,
+			expectedHead: "あいうえ",
+			expectedTail: "お。かきく",
+		},
+		{
+			name:         "comma does not start a line",
+			language:     pb.Language_LANGUAGE_JA_JP,
+			text:         "あいう、えお",
+			fitting:      "あいう",
+			startsLine:   true,
+			expectedHead: "あい",
+			expectedTail: "う、えお",
+		},
+		{
+			name:         "long vowel mark does not start a line",
+			language:     pb.Language_LANGUAGE_JA_JP,
+			text:         "あいうコーヒー",
+			fitting:      "あいうコ",
+			startsLine:   true,
+			expectedHead: "あいう",
+			expectedTail: "コーヒー",
+		},
+		{
+			name:         "closing bracket does not start a line",
+			language:     pb.Language_LANGUAGE_JA_JP,
+			text:         "あい「うえ」おか",
+			fitting:      "あい「うえ",
+			startsLine:   true,
+			expectedHead: "あい「う",
+			expectedTail: "え」おか",
+		},
+		{
+			name:         "opening bracket does not end a line",
+			language:     pb.Language_LANGUAGE_JA_JP,
+			text:         "あいう「えお」",
+			fitting:      "あいう「",
+			startsLine:   true,
+			expectedHead: "あいう",
+			expectedTail: "「えお」",
+		},
+		{
+			name:         "English breaks between words",
+			language:     pb.Language_LANGUAGE_EN_US,
+			text:         "Our menu today",
+			fitting:      "Our menu to",
+			startsLine:   true,
+			expectedHead: "Our menu ",
+			expectedTail: "today",
+		},
+		{
+			name:         "long word is hyphenated at a syllable boundary",
+			language:     pb.Language_LANGUAGE_EN_US,
+			text:         "Our reservation",
+			fitting:      "Our reserva-",
+			startsLine:   true,
+			expectedHead: "Our reserva-",
+			expectedTail: "tion",
+		},
+		{
+			name:         "short word is moved rather than hyphenated",
+			language:     pb.Language_LANGUAGE_EN_US,
+			text:         "Our menu",
+			fitting:      "Our me-",
+			startsLine:   true,
+			expectedHead: "Our ",
+			expectedTail: "menu",
+		},
+		{
+			name:         "Korean moves a word to the next line",
+			language:     pb.Language_LANGUAGE_KO_KR,
+			text:         "예약확인서",
+			fitting:      "예약",
+			startsLine:   false,
+			expectedHead: "",
+			expectedTail: "예약확인서",
+		},
+		{
+			name:         "Korean breaks a word wider than the line between characters",
+			language:     pb.Language_LANGUAGE_KO_KR,
+			text:         "예약확인서",
+			fitting:      "예약",
+			startsLine:   true,
+			expectedHead: "예약",
+			expectedTail: "확인서",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			drawingContext := testDrawingContext(t, test.language, 20)
+			availableWidth, _ := drawingContext.MeasureString(test.fitting)
+			// Leaves room for rounding, but not for another character.
+			availableWidth += 1
+			lineWidth := availableWidth
+			if !test.startsLine {
+				lineWidth *= 2
+			}
+
+			head, tail := breakText(drawingContext, test.text, availableWidth, lineWidth, test.language)
+			if head != test.expectedHead || tail != test.expectedTail {
+				t.Errorf("breakText(%q) = (%q, %q), want (%q, %q)", test.text, head, tail, test.expectedHead, test.expectedTail)
+			}
+		})
+	}
+}
+
+func TestRepositionTextShrinksWordThatNeverFits(t *testing.T) {
+	fonts := testFonts(t, pb.Language_LANGUAGE_EN_US)
+	drawingContext := gg.NewContext(1, 1)
+	originalFontSize := 40.0
+	box := position{left: 0, top: 0, right: 100, bottom: 50}
+	word := wordSegment{
+		text:     "Reservations",
+		position: box,
+		fontSize: &originalFontSize,
+		style:    &style{},
+	}
+
+	words := repositionText([]position{box}, []wordSegment{word}, ALIGNMENT_LEFT, drawingContext, fonts, pb.Language_LANGUAGE_EN_US)
+
+	if len(words) != 1 {
+		t.Fatalf("got %d words, want the word on a single line: %v", len(words), words)
+	}
+	if strings.TrimSpace(words[0].text) != "Reservations" {
+		t.Errorf("got %q, want the word unbroken", words[0].text)
+	}
+	fontSize := *words[0].fontSize
+	shrinkCount := math.Log(fontSize/originalFontSize) / math.Log(FONT_SHRINK_RATIO)
+	if fontSize >= originalFontSize || math.Abs(shrinkCount-math.Round(shrinkCount)) > 1e-9 {
+		t.Errorf("got font size %v, want %v shrunk by FONT_SHRINK_RATIO", fontSize, originalFontSize)
+	}
+	drawingContext.SetFontFace(getFontByStyle(fonts, &style{}).NewFace(fontSize))
+	if width, _ := drawingContext.MeasureString(words[0].text); width > float64(box.right-box.left) {
+		t.Errorf("got width %v, want at most %d", width, box.right-box.left)
+	}
+	// One step larger would not have fit, or the loop would have stopped there.
+	drawingContext.SetFontFace(getFontByStyle(fonts, &style{}).NewFace(fontSize / FONT_SHRINK_RATIO))
+	if width, _ := drawingContext.MeasureString("Reservations"); width <= float64(box.right-box.left) {
+		t.Errorf("got font size %v, want the largest size that fits", fontSize)
+	}
+}
@@ -9,11 +9,13 @@ import (
 
 	"github.com/sashabaranov/go-openai"
 	pb "github.com/visionex-project/visionex/grpc"
+	"github.com/visionex-project/visionex/grpc/impl/glossary"
 )
 
 // Client interface for translation and chat completion
 type Client interface {
-	Translate(ctx context.Context, texts []string, targetLanguage TargetLanguage) ([]string, error)
+	// The source language is added to the prompt unless it is unspecified. The glossary is added to the prompt. It may be nil.
+	Translate(ctx context.Context, texts []string, sourceLanguage pb.Language, targetLanguage TargetLanguage, terms *pb.Glossary) ([]string, error)
 	ChatCompletion(ctx context.Context, request openai.ChatCompletionRequest) (string, error)
 	ChatCompletionWithCustomModel(ctx context.Context, request openai.ChatCompletionRequest) (string, error)
 }
@@ -33,6 +35,14 @@ const (
 	TargetLanguageKO_KR TargetLanguage = "KO-KR"
 	TargetLanguageEN_US TargetLanguage = "EN-US"
 	TargetLanguageJA_JP TargetLanguage = "JA-JP"
+	TargetLanguageZH_CN TargetLanguage = "ZH-CN"
+	TargetLanguageZH_TW TargetLanguage = "ZH-TW"
+	TargetLanguageTH_TH TargetLanguage = "TH-TH"
+	TargetLanguageVI_VN TargetLanguage = "VI-VN"
+	TargetLanguageID_ID TargetLanguage = "ID-ID"
+	TargetLanguageES_ES TargetLanguage = "ES-ES"
+	TargetLanguageFR_FR TargetLanguage = "FR-FR"
+	TargetLanguageDE_DE TargetLanguage = "DE-DE"
 )
 
 func ToTargetLanguage(targetLanguage pb.Language) TargetLanguage {
@@ -43,25 +53,37 @@ func ToTargetLanguage(targetLanguage pb.Language) TargetLanguage {
 		return TargetLanguageEN_US
 	case pb.Language_LANGUAGE_JA_JP:
 		return TargetLanguageJA_JP
+	case pb.Language_LANGUAGE_ZH_CN:
+		return TargetLanguageZH_CN
+	case pb.Language_LANGUAGE_ZH_TW:
+		return TargetLanguageZH_TW
+	case pb.Language_LANGUAGE_TH_TH:
+		return TargetLanguageTH_TH
+	case pb.Language_LANGUAGE_VI_VN:
+		return TargetLanguageVI_VN
+	case pb.Language_LANGUAGE_ID_ID:
+		return TargetLanguageID_ID
+	case pb.Language_LANGUAGE_ES_ES:
+		return TargetLanguageES_ES
+	case pb.Language_LANGUAGE_FR_FR:
+		return TargetLanguageFR_FR
+	case pb.Language_LANGUAGE_DE_DE:
+		return TargetLanguageDE_DE
 	default:
 		return TargetLanguageEN_US
 	}
 }
 
-func (c *client) Translate(ctx context.Context, texts []string, targetLanguage TargetLanguage) ([]string, error) {
+func (c *client) Translate(ctx context.Context, texts []string, sourceLanguage pb.Language, targetLanguage TargetLanguage, terms *pb.Glossary) ([]string, error) {
 	if len(texts) == 0 {
 		return []string{}, nil
 	}
 
 	// Create a translation prompt
-	targetLang := ""
-	switch targetLanguage {
-	case TargetLanguageKO_KR:
-		targetLang = "Korean"
-	case TargetLanguageEN_US:
-		targetLang = "English"
-	case TargetLanguageJA_JP:
-		targetLang = "Japanese"
+	targetLang := languageName(targetLanguage)
+	sourceLang := ""
+	if sourceLanguage != pb.Language_LANGUAGE_UNSPECIFIED {
+		sourceLang = " from " + languageName(ToTargetLanguage(sourceLanguage))
 	}
 
 	// Combine all texts for batch translation
@@ -70,9 +92,9 @@ func (c *client) Translate(ctx context.Context, texts []string, targetLanguage T
 		combinedText += fmt.Sprintf("[%d] %s\n", i, text)
 	}
 
-	prompt := fmt.Sprintf(`Translate the following texts to %s. Return only the translations in the same order, with each translation on a new line prefixed with its index number [0], [1], etc. Do not include any explanations or additional text.
-
-%s`, targetLang, combinedText)
+	prompt := fmt.Sprintf(`Translate the following texts%s to %s. Return only the translations in the same order, with each translation on a new line prefixed with its index number [0], [1], etc. Do not include any explanations or additional text.
+%s
+%s`, sourceLang, targetLang, glossary.Instructions(terms), combinedText)
 
 	request := openai.ChatCompletionRequest{
 		Model: openai.GPT3Dot5Turbo,
@@ -146,3 +168,33 @@ func (c *client) ChatCompletionWithCustomModel(ctx context.Context, request open
 	}
 	return result.Choices[0].Message.Content, nil
 }
+
+// Returns the English name of the language for the prompt. E.g., "Simplified Chinese"
+func languageName(language TargetLanguage) string {
+	switch language {
+	case TargetLanguageKO_KR:
+		return "Korean"
+	case TargetLanguageEN_US:
+		return "English"
+	case TargetLanguageJA_JP:
+		return "Japanese"
+	case TargetLanguageZH_CN:
+		return "Simplified Chinese"
+	case TargetLanguageZH_TW:
+		return "Traditional Chinese"
+	case TargetLanguageTH_TH:
+		return "Thai"
+	case TargetLanguageVI_VN:
+		return "Vietnamese"
+	case TargetLanguageID_ID:
+		return "Indonesian"
+	case TargetLanguageES_ES:
+		return "Spanish"
+	case TargetLanguageFR_FR:
+		return "French"
+	case TargetLanguageDE_DE:
+		return "German"
+	default:
+		return ""
+	}
+}
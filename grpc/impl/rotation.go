@@ -0,0 +1,200 @@
+package impl
+
+import (
+	"math"
+	"slices"
+
+	"github.com/fogleman/gg"
+
+	"github.com/visionex-project/visionex/pkg/utils"
+)
+
+const (
+	// Lines tilted less than this are drawn upright, as OCR boxes of upright text are rarely perfectly level.
+	MIN_ROTATION_DEGREES = 2.0
+	// Lines skewed less than this are drawn without skew. About 3 degrees.
+	MIN_SHEAR = 0.05
+)
+
+// A vertex of Document AI or Vision.
+type vertex interface {
+	GetX() int32
+	GetY() int32
+}
+
+// Converts the vertices of a bounding polygon into a quadrilateral. Returns nil unless there are exactly four vertices.
+// Vertices listed counterclockwise are reversed after the first one, so that every quadrilateral goes clockwise
+// from the top left corner in reading order.
+func toQuad[V vertex](vertices []V) []point {
+	if len(vertices) != 4 {
+		return nil
+	}
+	quad := utils.Map(vertices, func(vertex V) point {
+		return point{x: float64(vertex.GetX()), y: float64(vertex.GetY())}
+	})
+	// Twice the signed area, which is positive for clockwise corners as the y axis points downwards.
+	area := 0.0
+	for i, from := range quad {
+		to := quad[(i+1)%len(quad)]
+		area += from.x*to.y - to.x*from.y
+	}
+	if area < 0 {
+		return []point{quad[0], quad[3], quad[2], quad[1]}
+	}
+	return quad
+}
+
+// Combines the quadrilaterals of two words of a line into one that spans both,
+// from the start of the word that comes first along the text to the end of the word that comes last,
+// so that the words do not have to be adjacent or in reading order.
+// Returns nil when either one is unknown.
+func combinedQuad(previous []point, current []point) []point {
+	if previous == nil || current == nil {
+		return nil
+	}
+	// The distance along the top edge of the previous word.
+	along := func(p point) float64 {
+		return p.x*(previous[1].x-previous[0].x) + p.y*(previous[1].y-previous[0].y)
+	}
+	first, last := previous, current
+	if along(current[0]) < along(previous[0]) {
+		first = current
+	}
+	if along(previous[1]) > along(current[1]) {
+		last = previous
+	}
+	return []point{first[0], last[1], last[2], first[3]}
+}
+
+// Returns the rotation and skew shared by the words of the line, or nil when the line is upright.
+// The top edges give the angle, and the side edges give the skew.
+func lineRotation(line lineSegment) *textRotation {
+	quads := utils.Filter(utils.Map(line.words, func(word wordSegment) []point {
+		return word.quad
+	}), func(quad []point) bool {
+		return quad != nil
+	})
+	if len(quads) == 0 {
+		return nil
+	}
+
+	// Longer edges are measured more precisely, so the edges are summed instead of averaging the angles.
+	top := point{}
+	for _, quad := range quads {
+		top.x += quad[1].x - quad[0].x
+		top.y += quad[1].y - quad[0].y
+	}
+	angle := math.Atan2(top.y, top.x)
+
+	// The side edges as if the text were not rotated. Upright text has vertical side edges.
+	side := point{}
+	for _, quad := range quads {
+		left := rotatePoint(point{x: quad[3].x - quad[0].x, y: quad[3].y - quad[0].y}, point{}, -angle)
+		right := rotatePoint(point{x: quad[2].x - quad[1].x, y: quad[2].y - quad[1].y}, point{}, -angle)
+		side.x += left.x + right.x
+		side.y += left.y + right.y
+	}
+	shear := 0.0
+	if side.y > 0 {
+		shear = side.x / side.y
+	}
+
+	if math.Abs(angle) < gg.Radians(MIN_ROTATION_DEGREES) && math.Abs(shear) < MIN_SHEAR {
+		return nil
+	}
+	center := combinedPosition(utils.Map(line.words, func(word wordSegment) position {
+		return word.position
+	}))
+	return &textRotation{
+		angle:   angle,
+		shear:   shear,
+		centerX: float64(center.left+center.right) / 2,
+		centerY: float64(center.top+center.bottom) / 2,
+	}
+}
+
+// Moves the words of a rotated or skewed line to where they would be if the line were upright,
+// so that the translation is laid out as usual and then drawn with the rotation of the line.
+func uprightLine(line lineSegment) lineSegment {
+	rotation := lineRotation(line)
+	if rotation == nil {
+		return line
+	}
+
+	line.rotation = rotation
+	line.words = utils.Map(line.words, func(word wordSegment) wordSegment {
+		if word.quad == nil {
+			return word
+		}
+		corners := utils.Map(word.quad, rotation.toUpright)
+		xs := utils.Map(corners, func(corner point) float64 {
+			return corner.x
+		})
+		ys := utils.Map(corners, func(corner point) float64 {
+			return corner.y
+		})
+		word.position = position{
+			top:    int32(math.Round(slices.Min(ys))),
+			left:   int32(math.Round(slices.Min(xs))),
+			bottom: int32(math.Round(slices.Max(ys))),
+			right:  int32(math.Round(slices.Max(xs))),
+		}
+		return word
+	})
+	return line
+}
+
+// The inverse of the rotation. Unrotates the point around the center first, and then removes the skew.
+func (r *textRotation) toUpright(p point) point {
+	center := point{x: r.centerX, y: r.centerY}
+	unrotated := rotatePoint(p, center, -r.angle)
+	return point{x: unrotated.x - r.shear*(unrotated.y-center.y), y: unrotated.y}
+}
+
+// Skews the point around the center first, and then rotates it, as drawRotated does.
+func (r *textRotation) fromUpright(p point) point {
+	center := point{x: r.centerX, y: r.centerY}
+	return rotatePoint(point{x: p.x + r.shear*(p.y-center.y), y: p.y}, center, r.angle)
+}
+
+// Rotates the point clockwise around the center. The y axis points downwards as in images.
+func rotatePoint(p point, center point, angle float64) point {
+	sin, cos := math.Sincos(angle)
+	x, y := p.x-center.x, p.y-center.y
+	return point{x: center.x + x*cos - y*sin, y: center.y + x*sin + y*cos}
+}
+
+// Calls draw with the drawing context skewed and rotated, so that upright drawings match the rotated text.
+// Draws as is when the rotation is nil.
+func drawRotated(drawingContext *gg.Context, rotation *textRotation, draw func()) {
+	if rotation == nil {
+		draw()
+		return
+	}
+	drawingContext.Push()
+	defer drawingContext.Pop()
+	drawingContext.RotateAbout(rotation.angle, rotation.centerX, rotation.centerY)
+	drawingContext.ShearAbout(rotation.shear, 0, rotation.centerX, rotation.centerY)
+	draw()
+}
+
+// Moves every corner outwards by the padding along both edges of the quadrilateral.
+func paddedQuad(quad []point, padding float64) []point {
+	unit := func(from point, to point) point {
+		length := math.Hypot(to.x-from.x, to.y-from.y)
+		if length == 0 {
+			return point{}
+		}
+		return point{x: (to.x - from.x) / length, y: (to.y - from.y) / length}
+	}
+	// Along the text and across it.
+	along := unit(quad[0], quad[1])
+	across := unit(quad[0], quad[3])
+	move := func(corner point, alongSign float64, acrossSign float64) point {
+		return point{
+			x: corner.x + padding*(alongSign*along.x+acrossSign*across.x),
+			y: corner.y + padding*(alongSign*along.y+acrossSign*across.y),
+		}
+	}
+	return []point{move(quad[0], -1, -1), move(quad[1], 1, -1), move(quad[2], 1, 1), move(quad[3], -1, 1)}
+}
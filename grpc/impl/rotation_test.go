@@ -0,0 +1,174 @@
+package impl
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/fogleman/gg"
+
+	"github.com/visionex-project/visionex/pkg/utils"
+)
+
+type testVertex struct {
+	x int32
+	y int32
+}
+
+func (v testVertex) GetX() int32 { return v.x }
+func (v testVertex) GetY() int32 { return v.y }
+
+func isNearPoint(a point, b point) bool {
+	return math.Hypot(a.x-b.x, a.y-b.y) < 1e-6
+}
+
+func TestTextRotationRoundTrip(t *testing.T) {
+	rotations := []textRotation{
+		{angle: gg.Radians(15), centerX: 100, centerY: 50},
+		{angle: gg.Radians(-30), shear: 0.2, centerX: 0, centerY: 0},
+		{angle: gg.Radians(180), shear: -0.3, centerX: 320, centerY: 240},
+	}
+	points := []point{{x: 0, y: 0}, {x: 100, y: 50}, {x: -40, y: 300}, {x: 320, y: 240}}
+
+	for _, rotation := range rotations {
+		for _, p := range points {
+			if roundTrip := rotation.fromUpright(rotation.toUpright(p)); !isNearPoint(roundTrip, p) {
+				t.Errorf("%+v: fromUpright(toUpright(%v)) = %v", rotation, p, roundTrip)
+			}
+			if roundTrip := rotation.toUpright(rotation.fromUpright(p)); !isNearPoint(roundTrip, p) {
+				t.Errorf("%+v: toUpright(fromUpright(%v)) = %v", rotation, p, roundTrip)
+			}
+		}
+	}
+}
+
+func TestLineRotation(t *testing.T) {
+	// Two upright words of a line, which are rotated and skewed around the center of the line.
+	uprightWords := [][]point{
+		{{x: 100, y: 100}, {x: 200, y: 100}, {x: 200, y: 140}, {x: 100, y: 140}},
+		{{x: 220, y: 100}, {x: 300, y: 100}, {x: 300, y: 140}, {x: 220, y: 140}},
+	}
+	tests := []struct {
+		name     string
+		angle    float64
+		shear    float64
+		reversed bool
+		// Nil when the line is expected to be upright.
+		expected *textRotation
+	}{
+		{name: "upright", angle: 0, shear: 0},
+		{name: "slightly tilted", angle: gg.Radians(MIN_ROTATION_DEGREES / 2), shear: 0},
+		{name: "rotated", angle: gg.Radians(15), shear: 0, expected: &textRotation{angle: gg.Radians(15)}},
+		{name: "rotated backwards", angle: gg.Radians(-40), shear: 0, expected: &textRotation{angle: gg.Radians(-40)}},
+		{name: "skewed", angle: 0, shear: -0.2, expected: &textRotation{shear: -0.2}},
+		{name: "rotated and skewed", angle: gg.Radians(20), shear: 0.25, expected: &textRotation{angle: gg.Radians(20), shear: 0.25}},
+		{
+			name:     "words in reversed order",
+			angle:    gg.Radians(20),
+			shear:    0.25,
+			reversed: true,
+			expected: &textRotation{angle: gg.Radians(20), shear: 0.25},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rotation := textRotation{angle: test.angle, shear: test.shear, centerX: 200, centerY: 120}
+			words := utils.Map(uprightWords, func(quad []point) wordSegment {
+				quad = utils.Map(quad, rotation.fromUpright)
+				return wordSegment{quad: quad, position: quadPosition(quad)}
+			})
+			if test.reversed {
+				slices.Reverse(words)
+			}
+
+			detected := lineRotation(lineSegment{words: words})
+			if test.expected == nil {
+				if detected != nil {
+					t.Errorf("got %+v, want upright", detected)
+				}
+				return
+			}
+			if detected == nil {
+				t.Fatalf("got upright, want %+v", test.expected)
+			}
+			if math.Abs(detected.angle-test.expected.angle) > 1e-6 || math.Abs(detected.shear-test.expected.shear) > 1e-6 {
+				t.Errorf("got angle %v and shear %v, want angle %v and shear %v",
+					detected.angle, detected.shear, test.expected.angle, test.expected.shear)
+			}
+		})
+	}
+}
+
+func TestToQuad(t *testing.T) {
+	clockwise := []point{{x: 10, y: 20}, {x: 110, y: 40}, {x: 106, y: 60}, {x: 6, y: 40}}
+	tests := []struct {
+		name     string
+		vertices []testVertex
+		expected []point
+	}{
+		{
+			name:     "clockwise",
+			vertices: []testVertex{{10, 20}, {110, 40}, {106, 60}, {6, 40}},
+			expected: clockwise,
+		},
+		{
+			name:     "counterclockwise",
+			vertices: []testVertex{{10, 20}, {6, 40}, {106, 60}, {110, 40}},
+			expected: clockwise,
+		},
+		{
+			name:     "not a quadrilateral",
+			vertices: []testVertex{{10, 20}, {110, 40}, {106, 60}},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if quad := toQuad(test.vertices); !slices.Equal(quad, test.expected) {
+				t.Errorf("got %v, want %v", quad, test.expected)
+			}
+		})
+	}
+}
+
+func TestCombinedQuad(t *testing.T) {
+	// Three words along a line rotated by 30 degrees.
+	rotation := textRotation{angle: gg.Radians(30), centerX: 150, centerY: 20}
+	words := utils.Map([][]point{
+		{{x: 0, y: 0}, {x: 80, y: 0}, {x: 80, y: 40}, {x: 0, y: 40}},
+		{{x: 100, y: 0}, {x: 180, y: 0}, {x: 180, y: 40}, {x: 100, y: 40}},
+		{{x: 200, y: 0}, {x: 300, y: 0}, {x: 300, y: 40}, {x: 200, y: 40}},
+	}, func(quad []point) []point {
+		return utils.Map(quad, rotation.fromUpright)
+	})
+	expected := []point{words[0][0], words[2][1], words[2][2], words[0][3]}
+
+	tests := []struct {
+		name     string
+		previous []point
+		current  []point
+	}{
+		{name: "adjacent in reading order", previous: words[0], current: words[2]},
+		{name: "in reversed order", previous: words[2], current: words[0]},
+		{name: "contained in the previous word", previous: expected, current: words[1]},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if quad := combinedQuad(test.previous, test.current); !slices.EqualFunc(quad, expected, isNearPoint) {
+				t.Errorf("got %v, want %v", quad, expected)
+			}
+		})
+	}
+	if quad := combinedQuad(words[0], nil); quad != nil {
+		t.Errorf("got %v, want nil for an unknown quadrilateral", quad)
+	}
+}
+
+// Returns the bounding box of the quad, as Document AI reports along with it.
+func quadPosition(quad []point) position {
+	bounds := quadBounds(quad)
+	return position{left: int32(bounds.Min.X), top: int32(bounds.Min.Y), right: int32(bounds.Max.X), bottom: int32(bounds.Max.Y)}
+}
@@ -0,0 +1,114 @@
+package impl
+
+import (
+	"image"
+	"image/draw"
+	"math"
+	"slices"
+
+	pb "github.com/visionex-project/visionex/grpc"
+	"github.com/visionex-project/visionex/pkg/utils"
+)
+
+const (
+	// Smaller slices are rejected, as an image would be cut into too many of them,
+	// and most texts would be taller than a slice.
+	MIN_SLICE_HEIGHT = 100
+	// Rows within this fraction of the text height above and below a text are kept in the same slice as the text,
+	// so that cuts keep clear of the outlines, shadows and highlight bands drawn around it.
+	// Outlines and shadows reach at most MAX_OUTLINE_WIDTH_RATIO and MAX_SHADOW_OFFSET_RATIO of the font size,
+	// which is about the text height, and this leaves a few pixels for their anti-aliased edges.
+	SLICE_TEXT_MARGIN_RATIO = 0.2
+)
+
+// Returns the rows covered by the texts, including their margins.
+func textRows(textBoxes []position) []rowRange {
+	return utils.Map(textBoxes, func(box position) rowRange {
+		margin := int(math.Ceil(SLICE_TEXT_MARGIN_RATIO * float64(box.bottom-box.top)))
+		return rowRange{top: int(box.top) - margin, bottom: int(box.bottom) + margin}
+	})
+}
+
+// Returns the points to cut a tall image at, starting with 0 and ending with the image height,
+// so that every slice is at most maxHeight tall. Cuts fall on rows without text,
+// each as low as possible to keep the number of slices small.
+// A text taller than maxHeight is cut at maxHeight, as the slice would not fit otherwise.
+func sliceSplitPoints(textRows []rowRange, imageHeight int, maxHeight int) []int {
+	gaps := rowsWithoutText(textRows, imageHeight)
+	points := []int{0}
+	for start := 0; imageHeight-start > maxHeight; {
+		cut := start + maxHeight
+		// The lowest row without text that keeps the slice within maxHeight.
+		lowestGap := slices.IndexFunc(gaps, func(gap rowRange) bool {
+			return gap.top > cut
+		})
+		if lowestGap < 0 {
+			lowestGap = len(gaps)
+		}
+		if lowestGap > 0 {
+			if lowestCut := min(cut, gaps[lowestGap-1].bottom-1); lowestCut > start {
+				cut = lowestCut
+			}
+		}
+		points = append(points, cut)
+		start = cut
+	}
+	return append(points, imageHeight)
+}
+
+// Returns the rows of the image between top and bottom as a new image whose bounds start at the origin.
+func cropRows(img image.Image, top int, bottom int) image.Image {
+	bounds := img.Bounds()
+	cropped := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bottom-top))
+	draw.Draw(cropped, cropped.Bounds(), img, image.Pt(bounds.Min.X, bounds.Min.Y+top), draw.Src)
+	return cropped
+}
+
+// Cuts the translated page into slices at most maxHeight tall without cutting through its texts,
+// and encodes the rows of each slice with encodeRows. The page index of the slices is left for the caller to set.
+func sliceImage(imageHeight int, textBoxes []position, maxHeight int, encodeRows func(top int, bottom int) ([]byte, string, error)) ([]*pb.ImageSlice, error) {
+	points := sliceSplitPoints(textRows(textBoxes), imageHeight, maxHeight)
+	imageSlices := []*pb.ImageSlice{}
+	for i := 0; i < len(points)-1; i++ {
+		encodedSlice, mimeType, err := encodeRows(points[i], points[i+1])
+		if err != nil {
+			return nil, err
+		}
+		imageSlices = append(imageSlices, &pb.ImageSlice{
+			UriImage: toDataUri(encodedSlice, mimeType),
+			Top:      int32(points[i]),
+			Height:   int32(points[i+1] - points[i]),
+		})
+	}
+	return imageSlices, nil
+}
+
+// Rows of an image covered by something, from top (inclusive) to bottom (exclusive).
+type rowRange struct {
+	top    int
+	bottom int
+}
+
+// Returns the runs of rows that no text covers, from top to bottom.
+// Slices are only cut within them, so that no text is cut through.
+func rowsWithoutText(textRows []rowRange, imageHeight int) []rowRange {
+	isText := make([]bool, imageHeight)
+	for _, row := range textRows {
+		for y := max(0, row.top); y < min(imageHeight, row.bottom); y++ {
+			isText[y] = true
+		}
+	}
+
+	gaps := []rowRange{}
+	for y := 0; y < imageHeight; y++ {
+		if isText[y] {
+			continue
+		}
+		if len(gaps) > 0 && gaps[len(gaps)-1].bottom == y {
+			gaps[len(gaps)-1].bottom++
+			continue
+		}
+		gaps = append(gaps, rowRange{top: y, bottom: y + 1})
+	}
+	return gaps
+}
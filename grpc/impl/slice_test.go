@@ -0,0 +1,56 @@
+package impl
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSliceSplitPoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		textRows    []rowRange
+		imageHeight int
+		maxHeight   int
+		expected    []int
+	}{
+		{name: "fits in a slice", textRows: []rowRange{{top: 10, bottom: 50}}, imageHeight: 100, maxHeight: 100, expected: []int{0, 100}},
+		{name: "no text", imageHeight: 250, maxHeight: 100, expected: []int{0, 100, 200, 250}},
+		{
+			name:        "cuts below the text",
+			textRows:    []rowRange{{top: 20, bottom: 60}, {top: 80, bottom: 130}},
+			imageHeight: 200,
+			maxHeight:   100,
+			expected:    []int{0, 79, 179, 200},
+		},
+		{
+			name:        "cuts through a text taller than a slice",
+			textRows:    []rowRange{{top: 0, bottom: 150}},
+			imageHeight: 200,
+			maxHeight:   100,
+			expected:    []int{0, 100, 200},
+		},
+		{
+			name:        "ignores text outside the image",
+			textRows:    []rowRange{{top: -20, bottom: 10}, {top: 95, bottom: 230}},
+			imageHeight: 200,
+			maxHeight:   100,
+			expected:    []int{0, 94, 194, 200},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if points := sliceSplitPoints(test.textRows, test.imageHeight, test.maxHeight); !slices.Equal(points, test.expected) {
+				t.Errorf("got %v, want %v", points, test.expected)
+			}
+		})
+	}
+}
+
+func TestRowsWithoutText(t *testing.T) {
+	textRows := []rowRange{{top: -5, bottom: 10}, {top: 30, bottom: 40}, {top: 35, bottom: 50}, {top: 90, bottom: 120}}
+	expected := []rowRange{{top: 10, bottom: 30}, {top: 50, bottom: 90}}
+	if gaps := rowsWithoutText(textRows, 100); !slices.Equal(gaps, expected) {
+		t.Errorf("got %v, want %v", gaps, expected)
+	}
+}
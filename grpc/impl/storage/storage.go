@@ -3,12 +3,18 @@ package storage
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
 )
 
 type Client interface {
 	SaveBytes(ctx context.Context, bucketName string, objectName string, data []byte) error
+	ReadBytes(ctx context.Context, bucketName string, objectName string) ([]byte, error)
+	// Returns the names of the objects whose names start with the prefix, sorted by the name.
+	ListObjects(ctx context.Context, bucketName string, prefix string) ([]string, error)
+	DeleteObject(ctx context.Context, bucketName string, objectName string) error
 }
 
 type gcsClient struct {
@@ -34,3 +40,40 @@ func (s *gcsClient) SaveBytes(ctx context.Context, bucketName string, objectName
 
 	return nil
 }
+
+func (s *gcsClient) ReadBytes(ctx context.Context, bucketName string, objectName string) ([]byte, error) {
+	reader, err := s.storageClient.Bucket(bucketName).Object(objectName).NewReader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GCS reader: %w", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from GCS: %w", err)
+	}
+	return data, nil
+}
+
+func (s *gcsClient) ListObjects(ctx context.Context, bucketName string, prefix string) ([]string, error) {
+	objectNames := []string{}
+	objects := s.storageClient.Bucket(bucketName).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attributes, err := objects.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list GCS objects: %w", err)
+		}
+		objectNames = append(objectNames, attributes.Name)
+	}
+	return objectNames, nil
+}
+
+func (s *gcsClient) DeleteObject(ctx context.Context, bucketName string, objectName string) error {
+	if err := s.storageClient.Bucket(bucketName).Object(objectName).Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete from GCS: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package impl
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/fogleman/gg"
+
+	"github.com/visionex-project/visionex/grpc/impl/font"
+)
+
+const (
+	// Italic text is slanted by this much when the italic fonts are not installed. About 11 degrees,
+	// close to the slant of the italic fonts and of the synthetic oblique of browsers.
+	SYNTHETIC_OBLIQUE_SHEAR = 0.2
+	// Underlines and strikeouts are this thick relative to the font size, and at least a pixel.
+	// It is between the underline thicknesses in the post tables of the bundled English and Japanese fonts,
+	// 50 and 76 of their 1000 units per em.
+	DECORATION_THICKNESS_RATIO = 0.06
+	// Underlines are this far below the baseline relative to the font size.
+	UNDERLINE_OFFSET_RATIO = 0.12
+	// Strikeouts are this far above the baseline relative to the font size, through the middle of lowercase letters.
+	STRIKEOUT_OFFSET_RATIO = 0.3
+)
+
+// A straight line of an underline or a strikeout before the word is rotated.
+type decorationLine struct {
+	from      point
+	to        point
+	thickness float64
+}
+
+// Returns whether the word is italic but has no italic font to draw with, so that it has to be slanted.
+func isSyntheticOblique(targetLanguageFonts *font.FontsByFace, word wordSegment) bool {
+	return word.style.italic && targetLanguageFonts.ByFace(word.style.fontFace).Italic == nil
+}
+
+// Calls draw with the drawing context slanted around the baseline of the word when it is synthetic oblique.
+func drawOblique(drawingContext *gg.Context, targetLanguageFonts *font.FontsByFace, word wordSegment, draw func()) {
+	if !isSyntheticOblique(targetLanguageFonts, word) {
+		draw()
+		return
+	}
+	x, y, _ := wordBaseline(word)
+	drawingContext.Push()
+	defer drawingContext.Pop()
+	// Negative, as the y axis points downwards and the tops of the letters lean to the right.
+	drawingContext.ShearAbout(-SYNTHETIC_OBLIQUE_SHEAR, 0, x, y)
+	draw()
+}
+
+// Returns the underline and the strikeout of the word, if any.
+// Horizontal lines run under and through the text, and continue through the space to the next word
+// when it is decorated the same way on the same row, so that decorated phrases are not cut between words.
+// Vertical lines run on the right of and through the character, as in Japanese vertical text.
+func decorationLines(drawingContext *gg.Context, targetLanguageFonts *font.FontsByFace, words []wordSegment, i int) []decorationLine {
+	word := words[i]
+	if !word.style.underlined && !word.style.strikeout {
+		return nil
+	}
+	fontSize := *word.fontSize
+	thickness := max(1, fontSize*DECORATION_THICKNESS_RATIO)
+	lines := []decorationLine{}
+
+	if word.vertical {
+		centerX := float64(word.position.left+word.position.right) / 2
+		top, bottom := float64(word.position.top), float64(word.position.bottom)
+		if word.style.underlined {
+			x := centerX + fontSize/2 + fontSize*UNDERLINE_OFFSET_RATIO
+			lines = append(lines, decorationLine{from: point{x: x, y: top}, to: point{x: x, y: bottom}, thickness: thickness})
+		}
+		if word.style.strikeout {
+			lines = append(lines, decorationLine{from: point{x: centerX, y: top}, to: point{x: centerX, y: bottom}, thickness: thickness})
+		}
+		return lines
+	}
+
+	left := float64(word.position.left)
+	right := left + measureText(drawingContext, targetLanguageFonts, word, strings.TrimRightFunc(word.text, unicode.IsSpace))
+	if i+1 < len(words) {
+		next := words[i+1]
+		if !next.vertical && next.position.top == word.position.top && next.position.bottom == word.position.bottom &&
+			next.rotation == word.rotation && next.style.underlined == word.style.underlined && next.style.strikeout == word.style.strikeout {
+			right = float64(next.position.left)
+		}
+	}
+	_, baseline, _ := wordBaseline(word)
+	if word.style.underlined {
+		y := baseline + fontSize*UNDERLINE_OFFSET_RATIO
+		lines = append(lines, decorationLine{from: point{x: left, y: y}, to: point{x: right, y: y}, thickness: thickness})
+	}
+	if word.style.strikeout {
+		y := baseline - fontSize*STRIKEOUT_OFFSET_RATIO
+		lines = append(lines, decorationLine{from: point{x: left, y: y}, to: point{x: right, y: y}, thickness: thickness})
+	}
+	return lines
+}
+
+// Draws the lines in the current color.
+func drawDecorationLines(drawingContext *gg.Context, lines []decorationLine) {
+	for _, line := range lines {
+		drawingContext.SetLineWidth(line.thickness)
+		drawingContext.DrawLine(line.from.x, line.from.y, line.to.x, line.to.y)
+		drawingContext.Stroke()
+	}
+}
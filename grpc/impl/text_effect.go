@@ -0,0 +1,413 @@
+package impl
+
+import (
+	"image"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/fogleman/gg"
+	"github.com/lucasb-eyer/go-colorful"
+
+	"github.com/visionex-project/visionex/grpc/impl/font"
+	"github.com/visionex-project/visionex/pkg/utils"
+)
+
+const (
+	// Pixels within this RGB distance are considered the same color.
+	// RGB distance is used instead of CIEDE2000, as it is computed for every pixel around the text.
+	PIXEL_COLOR_DISTANCE_THRESHOLD = 0.2
+	// Text whose color covers less than this fraction of its box is left flat,
+	// as the text color from Document AI does not match the pixels well enough to find the effects around it.
+	MIN_TEXT_PIXEL_RATIO = 0.05
+	// An outline, shadow or band must cover at least this fraction of the pixels where it is expected.
+	MIN_EFFECT_COVERAGE = 0.6
+	// The widest outline and the farthest shadow that are looked for, relative to the font size.
+	MAX_OUTLINE_WIDTH_RATIO = 0.15
+	MAX_SHADOW_OFFSET_RATIO = 0.15
+	// In pixels.
+	MIN_SHADOW_OFFSET = 2
+	// A shadow covers the pixels on one side of the text much more than those on the opposite side,
+	// which tells it apart from an outline.
+	MIN_SHADOW_ASYMMETRY = 0.3
+	// The widest band beyond the text that is looked for, relative to the font size.
+	MAX_HIGHLIGHT_PADDING_RATIO = 0.5
+)
+
+// Detects outlines, drop shadows and highlight bands around every word from the pixels of the original image.
+// The styles are copied, as they are shared between words.
+func detectTextEffects(originImage image.Image, paragraphs []paragraphSegment) []paragraphSegment {
+	return utils.Map(paragraphs, func(paragraph paragraphSegment) paragraphSegment {
+		paragraph.lines = utils.Map(paragraph.lines, func(line lineSegment) lineSegment {
+			line.words = utils.Map(line.words, func(word wordSegment) wordSegment {
+				effects := detectWordEffects(originImage, word)
+				if effects == nil {
+					return word
+				}
+				effectStyle := *word.style
+				effectStyle.effects = effects
+				word.style = &effectStyle
+				return word
+			})
+			return line
+		})
+		return paragraph
+	})
+}
+
+// Returns nil when the word has no effect.
+func detectWordEffects(originImage image.Image, word wordSegment) *textEffects {
+	fontSize := float64(word.style.height)
+	if word.fontSize != nil && *word.fontSize > 0 {
+		fontSize = *word.fontSize
+	}
+	box := image.Rect(int(word.position.left), int(word.position.top), int(word.position.right), int(word.position.bottom)).
+		Intersect(originImage.Bounds())
+	if box.Empty() || fontSize <= 0 {
+		return nil
+	}
+
+	margin := int(math.Ceil(fontSize*MAX_HIGHLIGHT_PADDING_RATIO)) + 1
+	pixels := newPixelRegion(originImage, box.Inset(-margin))
+	isText := make([]bool, len(pixels.colors))
+	textPixelCount := 0
+	for y := box.Min.Y; y < box.Max.Y; y++ {
+		for x := box.Min.X; x < box.Max.X; x++ {
+			if pixels.at(x, y).DistanceRgb(word.style.textColor) < PIXEL_COLOR_DISTANCE_THRESHOLD {
+				isText[pixels.index(x, y)] = true
+				textPixelCount++
+			}
+		}
+	}
+	if float64(textPixelCount) < MIN_TEXT_PIXEL_RATIO*float64(box.Dx()*box.Dy()) {
+		return nil
+	}
+
+	maxOutlineWidth := max(1, int(math.Round(fontSize*MAX_OUTLINE_WIDTH_RATIO)))
+	distances := pixels.distancesTo(isText, maxOutlineWidth+2)
+	// The background is what is left in the box away from the text and its outline.
+	backgroundColors := []colorful.Color{}
+	for y := box.Min.Y; y < box.Max.Y; y++ {
+		for x := box.Min.X; x < box.Max.X; x++ {
+			if distances[pixels.index(x, y)] > maxOutlineWidth+1 {
+				backgroundColors = append(backgroundColors, pixels.at(x, y))
+			}
+		}
+	}
+	backgroundColor, backgroundCoverage := dominantColor(backgroundColors)
+	if len(backgroundColors) == 0 {
+		backgroundColor, backgroundCoverage = dominantColor(pixels.outside(box, 1))
+	}
+	isEffectColor := func(color colorful.Color) bool {
+		return color.DistanceRgb(word.style.textColor) >= PIXEL_COLOR_DISTANCE_THRESHOLD &&
+			color.DistanceRgb(backgroundColor) >= PIXEL_COLOR_DISTANCE_THRESHOLD
+	}
+
+	effects := &textEffects{}
+	effects.outline = detectOutline(pixels, box, distances, maxOutlineWidth, fontSize, isEffectColor)
+	if effects.outline == nil {
+		maxShadowOffset := max(MIN_SHADOW_OFFSET, int(math.Round(fontSize*MAX_SHADOW_OFFSET_RATIO)))
+		effects.shadow = detectShadow(pixels, box, isText, maxShadowOffset, fontSize, isEffectColor)
+	}
+	if backgroundCoverage < MIN_EFFECT_COVERAGE && word.style.backgroundColor != nil {
+		// Mostly text with little background in the box, so the background color from Document AI is used instead.
+		backgroundColor, backgroundCoverage = *word.style.backgroundColor, 1
+	}
+	if backgroundCoverage >= MIN_EFFECT_COVERAGE {
+		effects.highlight = detectHighlight(pixels, box, backgroundColor, margin, fontSize)
+	}
+
+	if effects.outline == nil && effects.shadow == nil && effects.highlight == nil {
+		return nil
+	}
+	return effects
+}
+
+// An outline surrounds the text evenly, so most pixels right next to the text share a color
+// that is neither the text color nor the background color.
+func detectOutline(pixels *pixelRegion, box image.Rectangle, distances []int, maxWidth int, fontSize float64, isEffectColor func(colorful.Color) bool) *outlineEffect {
+	// Rings of pixels at the same distance from the text. The first one is blended with the text by anti-aliasing.
+	rings := make([][]colorful.Color, maxWidth+2)
+	for y := box.Min.Y; y < box.Max.Y; y++ {
+		for x := box.Min.X; x < box.Max.X; x++ {
+			if distance := distances[pixels.index(x, y)]; distance > 0 && distance < len(rings) {
+				rings[distance] = append(rings[distance], pixels.at(x, y))
+			}
+		}
+	}
+
+	var outline *outlineEffect
+	for distance := 1; distance < len(rings); distance++ {
+		color, coverage := dominantColor(rings[distance])
+		if outline == nil {
+			if coverage >= MIN_EFFECT_COVERAGE && isEffectColor(color) {
+				outline = &outlineEffect{color: color, widthRatio: float64(distance) / fontSize}
+			}
+			continue
+		}
+		if coverage < MIN_EFFECT_COVERAGE || color.DistanceRgb(outline.color) >= PIXEL_COLOR_DISTANCE_THRESHOLD {
+			break
+		}
+		outline.widthRatio = float64(distance) / fontSize
+	}
+	return outline
+}
+
+// A shadow is a copy of the text moved in one direction, so the pixels at that offset from the text share a color,
+// while those at the opposite offset do not.
+func detectShadow(pixels *pixelRegion, box image.Rectangle, isText []bool, maxOffset int, fontSize float64, isEffectColor func(colorful.Color) bool) *shadowEffect {
+	offsetColors := func(offsetX int, offsetY int) []colorful.Color {
+		colors := []colorful.Color{}
+		for y := box.Min.Y; y < box.Max.Y; y++ {
+			for x := box.Min.X; x < box.Max.X; x++ {
+				if !isText[pixels.index(x, y)] || !pixels.contains(x+offsetX, y+offsetY) || isText[pixels.index(x+offsetX, y+offsetY)] {
+					continue
+				}
+				colors = append(colors, pixels.at(x+offsetX, y+offsetY))
+			}
+		}
+		return colors
+	}
+	coverageOf := func(colors []colorful.Color, color colorful.Color) float64 {
+		if len(colors) == 0 {
+			return 0
+		}
+		return float64(len(utils.Filter(colors, func(c colorful.Color) bool {
+			return c.DistanceRgb(color) < PIXEL_COLOR_DISTANCE_THRESHOLD
+		}))) / float64(len(colors))
+	}
+
+	var shadow *shadowEffect
+	bestAsymmetry := 0.0
+	directions := [][2]int{{1, 1}, {1, 0}, {0, 1}, {-1, 1}, {-1, -1}, {-1, 0}, {0, -1}, {1, -1}}
+	for _, direction := range directions {
+		// Pixels right next to the text are blended with it by anti-aliasing, which looks like a shadow of one pixel.
+		for distance := MIN_SHADOW_OFFSET; distance <= maxOffset; distance++ {
+			offsetX, offsetY := direction[0]*distance, direction[1]*distance
+			color, coverage := dominantColor(offsetColors(offsetX, offsetY))
+			if coverage < MIN_EFFECT_COVERAGE || !isEffectColor(color) {
+				continue
+			}
+			asymmetry := coverage - coverageOf(offsetColors(-offsetX, -offsetY), color)
+			if asymmetry >= MIN_SHADOW_ASYMMETRY && asymmetry > bestAsymmetry {
+				bestAsymmetry = asymmetry
+				shadow = &shadowEffect{
+					color:        color,
+					offsetXRatio: float64(offsetX) / fontSize,
+					offsetYRatio: float64(offsetY) / fontSize,
+				}
+			}
+		}
+	}
+	return shadow
+}
+
+// A band has the background color of the box right above and below the text, but not farther away.
+// Only the rows above and below are compared, as a band often spans the whole width of the image.
+func detectHighlight(pixels *pixelRegion, box image.Rectangle, bandColor colorful.Color, maxPadding int, fontSize float64) *highlightEffect {
+	isBand := func(distance int) bool {
+		colors := append(pixels.row(box.Min.Y-distance, box.Min.X, box.Max.X), pixels.row(box.Max.Y-1+distance, box.Min.X, box.Max.X)...)
+		if len(colors) == 0 {
+			return false
+		}
+		bandCount := len(utils.Filter(colors, func(color colorful.Color) bool {
+			return color.DistanceRgb(bandColor) < PIXEL_COLOR_DISTANCE_THRESHOLD
+		}))
+		return float64(bandCount) >= MIN_EFFECT_COVERAGE*float64(len(colors))
+	}
+
+	padding := 0
+	for padding < maxPadding && isBand(padding+1) {
+		padding++
+	}
+	// The band must extend beyond the text, and end before the farthest padding, or it is just the background.
+	if padding == 0 || padding == maxPadding {
+		return nil
+	}
+	return &highlightEffect{color: bandColor, paddingRatio: float64(padding) / fontSize}
+}
+
+// Returns the most common color, and the fraction of the colors that are close to it.
+func dominantColor(colors []colorful.Color) (colorful.Color, float64) {
+	if len(colors) == 0 {
+		return colorful.Color{}, 0
+	}
+
+	// Colors are counted in coarse buckets, so that slightly different pixels of the same color are counted together.
+	type bucket struct {
+		count int
+		sum   colorful.Color
+	}
+	buckets := map[[3]int]*bucket{}
+	var largest *bucket
+	for _, color := range colors {
+		key := [3]int{int(color.R * 7.99), int(color.G * 7.99), int(color.B * 7.99)}
+		if buckets[key] == nil {
+			buckets[key] = &bucket{}
+		}
+		buckets[key].count++
+		buckets[key].sum = colorful.Color{R: buckets[key].sum.R + color.R, G: buckets[key].sum.G + color.G, B: buckets[key].sum.B + color.B}
+		if largest == nil || buckets[key].count > largest.count {
+			largest = buckets[key]
+		}
+	}
+
+	count := float64(largest.count)
+	dominant := colorful.Color{R: largest.sum.R / count, G: largest.sum.G / count, B: largest.sum.B / count}
+	closeCount := len(utils.Filter(colors, func(color colorful.Color) bool {
+		return color.DistanceRgb(dominant) < PIXEL_COLOR_DISTANCE_THRESHOLD
+	}))
+	return dominant, float64(closeCount) / float64(len(colors))
+}
+
+// The colors of a rectangle of the image, kept in a slice as they are read many times.
+type pixelRegion struct {
+	bounds image.Rectangle
+	colors []colorful.Color
+}
+
+func newPixelRegion(img image.Image, bounds image.Rectangle) *pixelRegion {
+	bounds = bounds.Intersect(img.Bounds())
+	region := &pixelRegion{bounds: bounds, colors: make([]colorful.Color, bounds.Dx()*bounds.Dy())}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			color, _ := colorful.MakeColor(img.At(x, y))
+			region.colors[region.index(x, y)] = color
+		}
+	}
+	return region
+}
+
+func (r *pixelRegion) contains(x int, y int) bool {
+	return image.Pt(x, y).In(r.bounds)
+}
+
+func (r *pixelRegion) index(x int, y int) int {
+	return (y-r.bounds.Min.Y)*r.bounds.Dx() + (x - r.bounds.Min.X)
+}
+
+func (r *pixelRegion) at(x int, y int) colorful.Color {
+	return r.colors[r.index(x, y)]
+}
+
+// Returns the colors of a row between left and right, leaving out the pixels outside the region.
+func (r *pixelRegion) row(y int, left int, right int) []colorful.Color {
+	colors := []colorful.Color{}
+	for x := left; x < right; x++ {
+		if r.contains(x, y) {
+			colors = append(colors, r.at(x, y))
+		}
+	}
+	return colors
+}
+
+// Returns the colors of the ring of the given width right outside the box.
+func (r *pixelRegion) outside(box image.Rectangle, width int) []colorful.Color {
+	colors := []colorful.Color{}
+	outer := box.Inset(-width)
+	for y := outer.Min.Y; y < outer.Max.Y; y++ {
+		for x := outer.Min.X; x < outer.Max.X; x++ {
+			if r.contains(x, y) && !image.Pt(x, y).In(box) {
+				colors = append(colors, r.at(x, y))
+			}
+		}
+	}
+	return colors
+}
+
+// Returns the Chebyshev distance from every pixel to the nearest text pixel, capped at maxDistance.
+// Text pixels are at distance 0.
+func (r *pixelRegion) distancesTo(isText []bool, maxDistance int) []int {
+	width, height := r.bounds.Dx(), r.bounds.Dy()
+	distances := make([]int, len(isText))
+	for i := range distances {
+		if !isText[i] {
+			distances[i] = maxDistance
+		}
+	}
+	// Two passes over the pixels propagate the distances from the upper left and then from the lower right.
+	relax := func(x int, y int, neighbors [][2]int) {
+		i := y*width + x
+		for _, neighbor := range neighbors {
+			neighborX, neighborY := x+neighbor[0], y+neighbor[1]
+			if neighborX < 0 || neighborX >= width || neighborY < 0 || neighborY >= height {
+				continue
+			}
+			distances[i] = min(distances[i], distances[neighborY*width+neighborX]+1)
+		}
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			relax(x, y, [][2]int{{-1, 0}, {-1, -1}, {0, -1}, {1, -1}})
+		}
+	}
+	for y := height - 1; y >= 0; y-- {
+		for x := width - 1; x >= 0; x-- {
+			relax(x, y, [][2]int{{1, 0}, {1, 1}, {0, 1}, {-1, 1}})
+		}
+	}
+	return distances
+}
+
+// Draws the highlight bands of the words first, so that a band never covers the text of a neighboring word.
+func drawHighlights(drawingContext *gg.Context, targetLanguageFonts *font.FontsByFace, words []wordSegment) {
+	for _, word := range words {
+		if word.style.effects == nil || word.style.effects.highlight == nil {
+			continue
+		}
+		left, top, width, height := highlightBand(drawingContext, targetLanguageFonts, word)
+		drawRotated(drawingContext, word.rotation, func() {
+			drawingContext.SetColor(word.style.effects.highlight.color)
+			drawingContext.DrawRectangle(left, top, width, height)
+			drawingContext.Fill()
+		})
+	}
+}
+
+// Returns the rectangle of the highlight band of a word before it is rotated, as left, top, width and height.
+// The band of horizontal text ends with the text rather than its box, as the translation is often shorter.
+func highlightBand(drawingContext *gg.Context, targetLanguageFonts *font.FontsByFace, word wordSegment) (float64, float64, float64, float64) {
+	padding := word.style.effects.highlight.paddingRatio * *word.fontSize
+	right := float64(word.position.right)
+	if !word.vertical {
+		right = float64(word.position.left) + measureText(drawingContext, targetLanguageFonts, word, strings.TrimRightFunc(word.text, unicode.IsSpace))
+	}
+	return float64(word.position.left) - padding,
+		float64(word.position.top) - padding,
+		right - float64(word.position.left) + 2*padding,
+		float64(word.position.bottom-word.position.top) + 2*padding
+}
+
+// Draws the shadow and the outline of a word, which go under the text itself.
+// drawText draws the text at its position in the current color.
+func drawTextEffects(drawingContext *gg.Context, word wordSegment, drawText func()) {
+	effects := word.style.effects
+	if effects == nil {
+		return
+	}
+
+	if shadow := effects.shadow; shadow != nil {
+		drawingContext.SetColor(shadow.color)
+		drawingContext.Push()
+		drawingContext.Translate(shadow.offsetXRatio**word.fontSize, shadow.offsetYRatio**word.fontSize)
+		drawText()
+		drawingContext.Pop()
+	}
+
+	if outline := effects.outline; outline != nil {
+		// Text cannot be stroked, so the outline is drawn by repeating the text around its position.
+		width := max(1, int(math.Round(outline.widthRatio**word.fontSize)))
+		drawingContext.SetColor(outline.color)
+		for offsetY := -width; offsetY <= width; offsetY++ {
+			for offsetX := -width; offsetX <= width; offsetX++ {
+				if offsetX*offsetX+offsetY*offsetY > width*width {
+					continue
+				}
+				drawingContext.Push()
+				drawingContext.Translate(float64(offsetX), float64(offsetY))
+				drawText()
+				drawingContext.Pop()
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package impl
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/fogleman/gg"
+	"github.com/lucasb-eyer/go-colorful"
+
+	pb "github.com/visionex-project/visionex/grpc"
+)
+
+func TestDetectWordEffects(t *testing.T) {
+	const text, fontSize = "Sale", 60.0
+	fonts := getFontByStyle(testFonts(t, pb.Language_LANGUAGE_EN_US), &style{fontWeight: BOLD_WEIGHT})
+	textColor := colorful.Color{R: 0, G: 0, B: 0}
+	red := colorful.Color{R: 1, G: 0, B: 0}
+	gray := colorful.Color{R: 0.5, G: 0.5, B: 0.5}
+	yellow := colorful.Color{R: 1, G: 0.9, B: 0}
+
+	// Draws the word on a white image, with paint drawing its effects first.
+	drawWord := func(paint func(drawingContext *gg.Context, drawText func(offsetX float64, offsetY float64))) image.Image {
+		drawingContext := gg.NewContext(400, 200)
+		drawingContext.SetRGB(1, 1, 1)
+		drawingContext.Clear()
+		drawingContext.SetFontFace(fonts.NewFace(fontSize))
+		drawText := func(offsetX float64, offsetY float64) {
+			drawingContext.DrawString(text, 40+offsetX, 120+offsetY)
+		}
+		paint(drawingContext, drawText)
+		drawingContext.SetColor(textColor)
+		drawText(0, 0)
+		return drawingContext.Image()
+	}
+	// Document AI boxes the pixels of the text itself.
+	plainImage := drawWord(func(*gg.Context, func(float64, float64)) {})
+	box := image.Rectangle{}
+	for y := plainImage.Bounds().Min.Y; y < plainImage.Bounds().Max.Y; y++ {
+		for x := plainImage.Bounds().Min.X; x < plainImage.Bounds().Max.X; x++ {
+			if r, _, _, _ := plainImage.At(x, y).RGBA(); r < 0x8000 {
+				box = box.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	size := fontSize
+	word := wordSegment{
+		text:     text,
+		position: position{left: int32(box.Min.X), top: int32(box.Min.Y), right: int32(box.Max.X), bottom: int32(box.Max.Y)},
+		fontSize: &size,
+		style:    &style{textColor: textColor, height: box.Dy(), fontWeight: BOLD_WEIGHT},
+	}
+
+	t.Run("plain", func(t *testing.T) {
+		if effects := detectWordEffects(plainImage, word); effects != nil {
+			t.Errorf("got %+v, want no effects", effects)
+		}
+	})
+
+	t.Run("outlined", func(t *testing.T) {
+		const width = 4
+		img := drawWord(func(drawingContext *gg.Context, drawText func(float64, float64)) {
+			drawingContext.SetColor(red)
+			for offsetY := -width; offsetY <= width; offsetY++ {
+				for offsetX := -width; offsetX <= width; offsetX++ {
+					if offsetX*offsetX+offsetY*offsetY <= width*width {
+						drawText(float64(offsetX), float64(offsetY))
+					}
+				}
+			}
+		})
+		effects := detectWordEffects(img, word)
+		if effects == nil || effects.outline == nil {
+			t.Fatalf("got %+v, want an outline", effects)
+		}
+		if effects.shadow != nil || effects.highlight != nil {
+			t.Errorf("got %+v, want only an outline", effects)
+		}
+		if distance := effects.outline.color.DistanceRgb(red); distance >= PIXEL_COLOR_DISTANCE_THRESHOLD {
+			t.Errorf("got outline color %v, want %v", effects.outline.color.Hex(), red.Hex())
+		}
+		if detectedWidth := effects.outline.widthRatio * fontSize; math.Abs(detectedWidth-width) > 1 {
+			t.Errorf("got outline width %v, want %v", detectedWidth, width)
+		}
+	})
+
+	t.Run("shadowed", func(t *testing.T) {
+		const offset = 5
+		img := drawWord(func(drawingContext *gg.Context, drawText func(float64, float64)) {
+			drawingContext.SetColor(gray)
+			drawText(offset, offset)
+		})
+		effects := detectWordEffects(img, word)
+		if effects == nil || effects.shadow == nil {
+			t.Fatalf("got %+v, want a shadow", effects)
+		}
+		if effects.outline != nil || effects.highlight != nil {
+			t.Errorf("got %+v, want only a shadow", effects)
+		}
+		if distance := effects.shadow.color.DistanceRgb(gray); distance >= PIXEL_COLOR_DISTANCE_THRESHOLD {
+			t.Errorf("got shadow color %v, want %v", effects.shadow.color.Hex(), gray.Hex())
+		}
+		offsetX, offsetY := effects.shadow.offsetXRatio*fontSize, effects.shadow.offsetYRatio*fontSize
+		if offsetX <= 0 || offsetY <= 0 || offsetX > offset || offsetY > offset {
+			t.Errorf("got shadow offset (%v, %v), want towards the lower right by at most %v", offsetX, offsetY, offset)
+		}
+	})
+
+	t.Run("highlighted", func(t *testing.T) {
+		const padding = 8
+		img := drawWord(func(drawingContext *gg.Context, drawText func(float64, float64)) {
+			band := box.Inset(-padding)
+			drawingContext.SetColor(yellow)
+			drawingContext.DrawRectangle(float64(band.Min.X), float64(band.Min.Y), float64(band.Dx()), float64(band.Dy()))
+			drawingContext.Fill()
+		})
+		effects := detectWordEffects(img, word)
+		if effects == nil || effects.highlight == nil {
+			t.Fatalf("got %+v, want a highlight", effects)
+		}
+		if effects.outline != nil || effects.shadow != nil {
+			t.Errorf("got %+v, want only a highlight", effects)
+		}
+		if distance := effects.highlight.color.DistanceRgb(yellow); distance >= PIXEL_COLOR_DISTANCE_THRESHOLD {
+			t.Errorf("got highlight color %v, want %v", effects.highlight.color.Hex(), yellow.Hex())
+		}
+		if detectedPadding := effects.highlight.paddingRatio * fontSize; detectedPadding != padding {
+			t.Errorf("got highlight padding %v, want %v", detectedPadding, padding)
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package impl
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/lucasb-eyer/go-colorful"
+
+	"github.com/visionex-project/visionex/pkg/utils"
+)
+
+const (
+	// The background color around a text is sampled from a ring of this width in pixels right outside its padded box.
+	TEXT_MASK_RING_WIDTH = 2
+	// The background color must cover at least this fraction of the ring. Otherwise, the text is on a photo or a pattern
+	// whose pixels cannot be told apart from the text, and its whole padded box is masked instead.
+	MIN_TEXT_MASK_BACKGROUND_COVERAGE = 0.6
+	// When more than this fraction of the padded box differs from the background, the background color is likely wrong,
+	// and the whole padded box is masked instead.
+	MAX_TEXT_MASK_RATIO = 0.8
+	// Text pixels are grown by this many pixels in the mask, to cover their anti-aliased edges and compression artifacts.
+	TEXT_MASK_DILATION = 3
+)
+
+// Returns the mask of the texts to remove, which is white on the text pixels and transparent elsewhere.
+// Rather than masking the whole box of each line, only the pixels that differ from the background around it are masked,
+// so that icons, photo edges and decorative lines next to the text are kept, and inpainting has much less to fill.
+// Rotated and skewed lines are masked along their words, as their axis-aligned boxes cover much more than the text.
+func textMask(originImage image.Image, lines []lineSegment) *image.RGBA {
+	maskImg := image.NewRGBA(originImage.Bounds())
+	quads := utils.FlatMap(lines, func(line lineSegment) [][]point {
+		if lineRotation(line) == nil {
+			box := combinedPosition(utils.Map(line.words, func(word wordSegment) position {
+				return word.position
+			}))
+			return [][]point{boxQuad(box)}
+		}
+		words := utils.Filter(line.words, func(word wordSegment) bool {
+			return word.quad != nil
+		})
+		return utils.Map(words, func(word wordSegment) []point {
+			return word.quad
+		})
+	})
+	for _, quad := range quads {
+		maskTextPixels(originImage, maskImg, quad)
+	}
+	return maskImg
+}
+
+// Masks the pixels of the text within the quad padded by ADDITIONAL_MASK_PADDING, as the text often extends
+// slightly beyond its box. The whole padded quad is masked when the text cannot be told apart from the background.
+func maskTextPixels(originImage image.Image, maskImg *image.RGBA, quad []point) {
+	searchArea := paddedQuad(quad, ADDITIONAL_MASK_PADDING)
+	ringArea := paddedQuad(quad, ADDITIONAL_MASK_PADDING+TEXT_MASK_RING_WIDTH)
+	pixels := newPixelRegion(originImage, quadBounds(ringArea).Inset(-TEXT_MASK_DILATION))
+	if pixels.bounds.Empty() {
+		return
+	}
+
+	ring := []colorful.Color{}
+	for y := pixels.bounds.Min.Y; y < pixels.bounds.Max.Y; y++ {
+		for x := pixels.bounds.Min.X; x < pixels.bounds.Max.X; x++ {
+			if containsPixel(ringArea, x, y) && !containsPixel(searchArea, x, y) {
+				ring = append(ring, pixels.at(x, y))
+			}
+		}
+	}
+	backgroundColor, backgroundCoverage := dominantColor(ring)
+
+	isText := make([]bool, len(pixels.colors))
+	textPixelCount, areaPixelCount := 0, 0
+	if backgroundCoverage >= MIN_TEXT_MASK_BACKGROUND_COVERAGE {
+		for y := pixels.bounds.Min.Y; y < pixels.bounds.Max.Y; y++ {
+			for x := pixels.bounds.Min.X; x < pixels.bounds.Max.X; x++ {
+				if !containsPixel(searchArea, x, y) {
+					continue
+				}
+				areaPixelCount++
+				if pixels.at(x, y).DistanceRgb(backgroundColor) >= PIXEL_COLOR_DISTANCE_THRESHOLD {
+					isText[pixels.index(x, y)] = true
+					textPixelCount++
+				}
+			}
+		}
+	}
+	// No text pixels means that the text is too faint to segment, so it is masked as a whole as well.
+	if textPixelCount == 0 || float64(textPixelCount) > MAX_TEXT_MASK_RATIO*float64(areaPixelCount) {
+		fillQuad(maskImg, searchArea)
+		return
+	}
+
+	distances := pixels.distancesTo(isText, TEXT_MASK_DILATION+1)
+	for y := pixels.bounds.Min.Y; y < pixels.bounds.Max.Y; y++ {
+		for x := pixels.bounds.Min.X; x < pixels.bounds.Max.X; x++ {
+			if distances[pixels.index(x, y)] <= TEXT_MASK_DILATION {
+				maskImg.SetRGBA(x, y, color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
+			}
+		}
+	}
+}
+
+// Returns the corners of the box in the order of word quads, from the upper left clockwise.
+func boxQuad(box position) []point {
+	left, top, right, bottom := float64(box.left), float64(box.top), float64(box.right), float64(box.bottom)
+	return []point{{x: left, y: top}, {x: right, y: top}, {x: right, y: bottom}, {x: left, y: bottom}}
+}
+
+// Returns the smallest rectangle of pixels that contains the quad.
+func quadBounds(quad []point) image.Rectangle {
+	xs := utils.Map(quad, func(corner point) float64 { return corner.x })
+	ys := utils.Map(quad, func(corner point) float64 { return corner.y })
+	return image.Rect(
+		int(math.Floor(slices.Min(xs))), int(math.Floor(slices.Min(ys))),
+		int(math.Ceil(slices.Max(xs))), int(math.Ceil(slices.Max(ys))),
+	)
+}
+
+// Returns whether the center of the pixel is inside the convex quad, whichever way its corners go around.
+func containsPixel(quad []point, x int, y int) bool {
+	centerX, centerY := float64(x)+0.5, float64(y)+0.5
+	hasPositive, hasNegative := false, false
+	for i, from := range quad {
+		to := quad[(i+1)%len(quad)]
+		cross := (to.x-from.x)*(centerY-from.y) - (to.y-from.y)*(centerX-from.x)
+		hasPositive = hasPositive || cross > 0
+		hasNegative = hasNegative || cross < 0
+	}
+	return !(hasPositive && hasNegative)
+}
+
+func fillQuad(maskImg *image.RGBA, quad []point) {
+	bounds := quadBounds(quad).Intersect(maskImg.Bounds())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if containsPixel(quad, x, y) {
+				maskImg.SetRGBA(x, y, color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package impl
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math/rand"
+	"testing"
+
+	"github.com/fogleman/gg"
+
+	pb "github.com/visionex-project/visionex/grpc"
+)
+
+var textMaskTextColor = color.RGBA{R: 20, G: 20, B: 60, A: 255}
+
+// Draws "Sale" over the background, and returns the image along with the box of the text pixels,
+// as Document AI reports it.
+func textMaskImage(t *testing.T, background func(x int, y int) color.RGBA) (*image.RGBA, position) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 320, 140))
+	for y := 0; y < img.Bounds().Dy(); y++ {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			img.SetRGBA(x, y, background(x, y))
+		}
+	}
+	drawingContext := gg.NewContextForRGBA(img)
+	drawingContext.SetFontFace(getFontByStyle(testFonts(t, pb.Language_LANGUAGE_EN_US), &style{fontWeight: BOLD_WEIGHT}).NewFace(48))
+	drawingContext.SetColor(textMaskTextColor)
+	drawingContext.DrawString("Sale", 100, 90)
+
+	box := image.Rectangle{}
+	for y := 0; y < img.Bounds().Dy(); y++ {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			if img.RGBAAt(x, y) == textMaskTextColor {
+				box = box.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return img, position{left: int32(box.Min.X), top: int32(box.Min.Y), right: int32(box.Max.X), bottom: int32(box.Max.Y)}
+}
+
+func flatBackground(x int, y int) color.RGBA {
+	return color.RGBA{R: 230, G: 240, B: 250, A: 255}
+}
+
+// Returns the pixels of the box padded by ADDITIONAL_MASK_PADDING, where text pixels are looked for.
+func searchArea(box position) image.Rectangle {
+	return image.Rect(int(box.left), int(box.top), int(box.right), int(box.bottom)).Inset(-ADDITIONAL_MASK_PADDING)
+}
+
+func isMaskedPixel(maskImg *image.RGBA, x int, y int) bool {
+	return maskImg.RGBAAt(x, y).A != 0
+}
+
+// Returns the number of masked pixels within the rectangle.
+func maskedCount(maskImg *image.RGBA, rect image.Rectangle) int {
+	count := 0
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			if isMaskedPixel(maskImg, x, y) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestTextMaskSegmentsText(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	tests := []struct {
+		name       string
+		background func(x int, y int) color.RGBA
+	}{
+		{name: "flat background", background: flatBackground},
+		{
+			// Like the compression noise of JPEG images, well within PIXEL_COLOR_DISTANCE_THRESHOLD.
+			name: "noisy background",
+			background: func(x int, y int) color.RGBA {
+				noise := func(value int) uint8 {
+					return uint8(value + random.Intn(17) - 8)
+				}
+				return color.RGBA{R: noise(230), G: noise(240), B: noise(240), A: 255}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			img, box := textMaskImage(t, test.background)
+			maskImg := textMask(img, []lineSegment{{words: []wordSegment{{text: "Sale", position: box}}}})
+
+			area := searchArea(box)
+			for y := area.Min.Y; y < area.Max.Y; y++ {
+				for x := area.Min.X; x < area.Max.X; x++ {
+					if img.RGBAAt(x, y) == textMaskTextColor && !isMaskedPixel(maskImg, x, y) {
+						t.Fatalf("got (%d, %d) of the text unmasked", x, y)
+					}
+				}
+			}
+			// The corners of the padded box are farther than TEXT_MASK_DILATION from any text pixel.
+			for _, corner := range []image.Point{area.Min, {X: area.Max.X - 1, Y: area.Min.Y}, area.Max.Sub(image.Pt(1, 1)), {X: area.Min.X, Y: area.Max.Y - 1}} {
+				if isMaskedPixel(maskImg, corner.X, corner.Y) {
+					t.Errorf("got the corner %v of the padded box masked, want only the text pixels", corner)
+				}
+			}
+			if count, areaCount := maskedCount(maskImg, maskImg.Bounds()), area.Dx()*area.Dy(); count >= areaCount {
+				t.Errorf("got %d masked pixels, want fewer than the %d pixels of the padded box", count, areaCount)
+			}
+		})
+	}
+}
+
+func TestTextMaskMasksWholeBox(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	tests := []struct {
+		name       string
+		background func(x int, y int) color.RGBA
+		// Paints over the image after the text is drawn, given the box of the text.
+		paint func(img *image.RGBA, box position)
+	}{
+		{
+			// No color covers MIN_TEXT_MASK_BACKGROUND_COVERAGE of the ring, as on a photo.
+			name: "background coverage too low",
+			background: func(x int, y int) color.RGBA {
+				return color.RGBA{R: uint8(random.Intn(256)), G: uint8(random.Intn(256)), B: uint8(random.Intn(256)), A: 255}
+			},
+		},
+		{
+			// A dark band behind the text, which differs from the background around it over the whole padded box.
+			name:       "text ratio too high",
+			background: flatBackground,
+			paint: func(img *image.RGBA, box position) {
+				draw.Draw(img, searchArea(box), &image.Uniform{C: color.RGBA{R: 90, G: 0, B: 0, A: 255}}, image.Point{}, draw.Src)
+			},
+		},
+		{
+			// A text too faint to tell apart from the background.
+			name:       "no text pixels",
+			background: flatBackground,
+			paint: func(img *image.RGBA, box position) {
+				draw.Draw(img, searchArea(box), &image.Uniform{C: flatBackground(0, 0)}, image.Point{}, draw.Src)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			img, box := textMaskImage(t, test.background)
+			if test.paint != nil {
+				test.paint(img, box)
+			}
+			maskImg := textMask(img, []lineSegment{{words: []wordSegment{{text: "Sale", position: box}}}})
+
+			area := searchArea(box)
+			if count := maskedCount(maskImg, area); count != area.Dx()*area.Dy() {
+				t.Errorf("got %d of the %d pixels of the padded box masked, want all of them", count, area.Dx()*area.Dy())
+			}
+			// Unlike text pixels, the padded box is not dilated.
+			if count := maskedCount(maskImg, maskImg.Bounds()); count != area.Dx()*area.Dy() {
+				t.Errorf("got %d masked pixels, want only the %d pixels of the padded box", count, area.Dx()*area.Dy())
+			}
+		})
+	}
+}
+
+func TestTextMaskKeepsAdjacentIcon(t *testing.T) {
+	img, box := textMaskImage(t, flatBackground)
+	// An icon right after the padded box, within the ring the background color is sampled from.
+	area := searchArea(box)
+	icon := image.Rect(area.Max.X+1, int(box.top), area.Max.X+21, int(box.bottom))
+	draw.Draw(img, icon, &image.Uniform{C: color.RGBA{R: 220, G: 30, B: 30, A: 255}}, image.Point{}, draw.Src)
+
+	maskImg := textMask(img, []lineSegment{{words: []wordSegment{{text: "Sale", position: box}}}})
+
+	if count := maskedCount(maskImg, icon); count != 0 {
+		t.Errorf("got %d pixels of the icon masked, want the icon kept", count)
+	}
+	if count := maskedCount(maskImg, area); count == 0 || count == area.Dx()*area.Dy() {
+		t.Errorf("got %d of the %d pixels of the padded box masked, want the text pixels only", count, area.Dx()*area.Dy())
+	}
+}
@@ -0,0 +1,130 @@
+package impl
+
+import (
+	"context"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/visionex-project/visionex/grpc"
+	"github.com/visionex-project/visionex/grpc/impl/glossary"
+)
+
+const (
+	// The maximum number of images in one batch request.
+	// Merchants usually upload 5-15 sliced detail images per product.
+	MAX_BATCH_SIZE = 20
+	// The number of images processed at the same time in one batch request.
+	// Each image already calls external APIs concurrently, so this is kept small to avoid rate limits.
+	BATCH_CONCURRENCY = 4
+)
+
+func (s *server) TranslateToImageBatch(ctx context.Context, request *pb.TranslateToImageBatchRequest) (*pb.TranslateToImageBatchResponse, error) {
+	if err := validateBatchSize(len(request.GetImages())); err != nil {
+		return nil, err
+	}
+	if err := glossary.Validate(request.GetGlossary()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	results := make([]*pb.TranslateToImageBatchResult, len(request.GetImages()))
+	runBatch(len(request.GetImages()), func(i int) {
+		response, err := s.translateToImage(ctx, toImageRequest(request, i), nil)
+		if err != nil {
+			log.Printf("Failed to translate image %d in batch: %v", i, err)
+		}
+		results[i] = &pb.TranslateToImageBatchResult{
+			Status:   toBatchStatus(err),
+			Response: response,
+		}
+	})
+
+	return &pb.TranslateToImageBatchResponse{Results: results}, nil
+}
+
+func (s *server) TranslateToMarkdownBatch(ctx context.Context, request *pb.TranslateToMarkdownBatchRequest) (*pb.TranslateToMarkdownBatchResponse, error) {
+	if err := validateBatchSize(len(request.GetImages())); err != nil {
+		return nil, err
+	}
+	if err := glossary.Validate(request.GetGlossary()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	results := make([]*pb.TranslateToMarkdownBatchResult, len(request.GetImages()))
+	runBatch(len(request.GetImages()), func(i int) {
+		response, err := s.TranslateToMarkdown(ctx, toMarkdownRequest(request, i))
+		if err != nil {
+			log.Printf("Failed to translate image %d in batch: %v", i, err)
+		}
+		results[i] = &pb.TranslateToMarkdownBatchResult{
+			Status:   toBatchStatus(err),
+			Response: response,
+		}
+	})
+
+	return &pb.TranslateToMarkdownBatchResponse{Results: results}, nil
+}
+
+// Returns the request for the i-th image of the batch.
+func toImageRequest(request *pb.TranslateToImageBatchRequest, i int) *pb.TranslateToImageRequest {
+	return &pb.TranslateToImageRequest{
+		TargetLanguage: request.GetTargetLanguage(),
+		Image:          request.GetImages()[i],
+		OutputEncoding: request.GetOutputEncoding(),
+		SourceLanguage: request.GetSourceLanguage(),
+		Glossary:       request.GetGlossary(),
+		FontFamily:     request.GetFontFamily(),
+		MaxSliceHeight: request.GetMaxSliceHeight(),
+	}
+}
+
+// Returns the request for the i-th image of the batch.
+func toMarkdownRequest(request *pb.TranslateToMarkdownBatchRequest, i int) *pb.TranslateToMarkdownRequest {
+	return &pb.TranslateToMarkdownRequest{
+		TargetLanguage: request.GetTargetLanguage(),
+		Model:          request.GetModel(),
+		Image:          request.GetImages()[i],
+		SourceLanguage: request.GetSourceLanguage(),
+		Glossary:       request.GetGlossary(),
+	}
+}
+
+func validateBatchSize(size int) error {
+	if size == 0 {
+		return status.Error(codes.InvalidArgument, "at least one image is required")
+	}
+	if size > MAX_BATCH_SIZE {
+		return status.Errorf(codes.InvalidArgument, "at most %d images are allowed", MAX_BATCH_SIZE)
+	}
+	return nil
+}
+
+// Calls process for every index in [0, size) with at most BATCH_CONCURRENCY calls running at once,
+// and returns when all of them are done.
+func runBatch(size int, process func(i int)) {
+	semaphore := make(chan struct{}, BATCH_CONCURRENCY)
+	done := make(chan struct{}, size)
+	for i := 0; i < size; i++ {
+		go func(i int) {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			process(i)
+			done <- struct{}{}
+		}(i)
+	}
+	for i := 0; i < size; i++ {
+		<-done
+	}
+}
+
+func toBatchStatus(err error) *pb.BatchStatus {
+	if err == nil {
+		return &pb.BatchStatus{Code: int32(codes.OK)}
+	}
+	grpcStatus := status.Convert(err)
+	return &pb.BatchStatus{
+		Code:    int32(grpcStatus.Code()),
+		Message: grpcStatus.Message(),
+	}
+}
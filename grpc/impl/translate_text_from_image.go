@@ -3,7 +3,6 @@ package impl
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"image"
@@ -11,7 +10,6 @@ import (
 	"image/draw"
 	_ "image/gif"
 	_ "image/jpeg"
-	"image/png"
 	"log"
 	"unicode"
 
@@ -23,6 +21,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	pb "github.com/visionex-project/visionex/grpc"
+	"github.com/visionex-project/visionex/grpc/impl/glossary"
 	"github.com/visionex-project/visionex/grpc/impl/openai"
 	"github.com/visionex-project/visionex/pkg/utils"
 )
@@ -33,6 +32,9 @@ func (s *server) TranslateTextFromImage(ctx context.Context, request *pb.Transla
 		log.Printf("Failed to decode image: %v", err)
 		return nil, status.Errorf(codes.InvalidArgument, codes.InvalidArgument.String())
 	}
+	if err := glossary.Validate(request.GetGlossary()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	ocrResponse, err := s.ocrResult(ctx, request.GetImage(), img)
 	if err != nil {
@@ -72,7 +74,8 @@ func (s *server) TranslateTextFromImage(ctx context.Context, request *pb.Transla
 		return nil, status.Errorf(codes.Internal, codes.Internal.String())
 	}
 
-	translatedText, err := s.translationClient.Translate(ctx, []string{string(textJson)}, openai.ToTargetLanguage(request.GetTargetLanguage()))
+	sourceLanguage := detectSourceLanguage(paragraphSegments, request.GetSourceLanguage())
+	translatedText, err := s.translationClient.Translate(ctx, []string{string(textJson)}, sourceLanguage, openai.ToTargetLanguage(request.GetTargetLanguage()), request.GetGlossary())
 	if err != nil || len(translatedText) != 1 {
 		log.Printf("Failed to translate text: %v", err)
 		return nil, status.Errorf(codes.Internal, codes.Internal.String())
@@ -89,22 +92,27 @@ func (s *server) TranslateTextFromImage(ctx context.Context, request *pb.Transla
 	}
 
 	sentences := []*pb.Sentence{}
+	glossaryViolations := []*pb.GlossaryViolation{}
 	for i := 0; i < len(translatedTextMap); i++ {
 		sentences = append(sentences, &pb.Sentence{
 			Text:           textMap[i],
 			TranslatedText: translatedTextMap[i],
 		})
+		glossaryViolations = append(glossaryViolations, glossary.Check(request.GetGlossary(), textMap[i], translatedTextMap[i])...)
 	}
 
-	var buf bytes.Buffer
-	if err := png.Encode(&buf, paragraphImage); err != nil {
+	encodedImage, mimeType, err := encodeImage(paragraphImage, request.GetOutputEncoding())
+	if err != nil {
 		log.Printf("Failed to encode result image: %v", err)
 		return nil, status.Errorf(codes.Internal, codes.Internal.String())
 	}
 
 	return &pb.TranslateTextFromImageResponse{
-		UriImage:  fmt.Sprintf("data:image/png;base64,%s", base64.StdEncoding.EncodeToString(buf.Bytes())),
-		Sentences: sentences,
+		UriImage:           toDataUri(encodedImage, mimeType),
+		Sentences:          sentences,
+		MimeType:           mimeType,
+		SourceLanguage:     sourceLanguage,
+		GlossaryViolations: glossaryViolations,
 	}, nil
 }
 
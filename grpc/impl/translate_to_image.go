@@ -3,14 +3,13 @@ package impl
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"image"
-	"image/draw"
-	"image/png"
 	"log"
 	"math"
+	"slices"
 	"strings"
 	"time"
 	"unicode"
@@ -18,14 +17,16 @@ import (
 	"cloud.google.com/go/documentai/apiv1/documentaipb"
 	"github.com/cenkalti/backoff/v4"
 	"github.com/fogleman/gg"
-	"github.com/golang/freetype/truetype"
 	"github.com/lucasb-eyer/go-colorful"
 	"github.com/sashabaranov/go-openai"
+	"golang.org/x/image/font/sfnt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	pb "github.com/visionex-project/visionex/grpc"
 	"github.com/visionex-project/visionex/grpc/impl/font"
+	"github.com/visionex-project/visionex/grpc/impl/glossary"
+	"github.com/visionex-project/visionex/grpc/impl/language"
 	"github.com/visionex-project/visionex/pkg/utils"
 )
 
@@ -61,7 +62,8 @@ const (
 	// TODO(#7556): Use a more descriptive name for the line height comparison threshold.
 	// This value is used for comparing text segments within the total image.
 	HEIGHT_THRESHOLD_TOTAL = 0.125
-	// The additional padding to add around the text bounding box to ensure complete text removal.
+	// The additional padding around the text bounding box in which text pixels are masked, to ensure complete text removal.
+	// The whole padded box is masked when its text cannot be told apart from the background.
 	// This value is empirically determined and may be subject to change based on further testing and refinement.
 	// TODO(#2643): Document threshold values with comparative test results.
 	ADDITIONAL_MASK_PADDING = 8
@@ -69,98 +71,223 @@ const (
 	REGULAR_WEIGHT  = 400
 	SEMIBOLD_WEIGHT = 600
 	BOLD_WEIGHT     = 700
+	// The font size is multiplied by this ratio until the wrapped text fits into its lines.
+	FONT_SHRINK_RATIO = 0.9
 )
 
 func (s *server) TranslateToImage(ctx context.Context, request *pb.TranslateToImageRequest) (*pb.TranslateToImageResponse, error) {
-	img, _, err := image.Decode(bytes.NewReader(request.GetImage()))
+	return s.translateToImage(ctx, request, nil)
+}
+
+// Runs the TranslateToImage pipeline. Progress is reported to the tracker when it is not nil.
+func (s *server) translateToImage(ctx context.Context, request *pb.TranslateToImageRequest, tracker *progressTracker) (*pb.TranslateToImageResponse, error) {
+	mimeType, err := detectMimeType(request.GetImage())
 	if err != nil {
 		log.Printf("Failed to decode image: %v", err)
 		return nil, status.Errorf(codes.InvalidArgument, codes.InvalidArgument.String())
 	}
-	spec := &imageSpec{
-		width:     img.Bounds().Dx(),
-		height:    img.Bounds().Dy(),
-		uriImage:  "data:image/png;base64," + base64.StdEncoding.EncodeToString(request.GetImage()),
-		byteImage: request.GetImage(),
+	if err := glossary.Validate(request.GetGlossary()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if maxSliceHeight := request.GetMaxSliceHeight(); maxSliceHeight < 0 || (maxSliceHeight > 0 && maxSliceHeight < MIN_SLICE_HEIGHT) {
+		return nil, status.Errorf(codes.InvalidArgument, "max_slice_height must be at least %d", MIN_SLICE_HEIGHT)
+	}
+
+	// Checked before the pipeline starts, so that the request does not fail after the expensive API calls.
+	targetLanguageFonts, err := s.fontsForRequest(ctx, request.GetTargetLanguage(), request.GetFontFamily())
+	if err != nil {
+		return nil, err
 	}
 
 	currentTimestamp := time.Now().UTC().Unix()
 	s.storage.Client.SaveBytes(
 		ctx,
 		s.storage.ToImageBucket,
-		fmt.Sprintf("image-%d-%s-before.png", currentTimestamp, request.GetTargetLanguage().String()),
-		spec.byteImage,
+		fmt.Sprintf("image-%d-%s-before.%s", currentTimestamp, request.GetTargetLanguage().String(), fileExtension(mimeType)),
+		request.GetImage(),
 	)
 
-	paragraphs, err := s.detectDocument(ctx, spec.byteImage, request.GetTargetLanguage())
+	pages, sourceLanguage, err := s.detectDocument(ctx, request.GetImage(), mimeType, request.GetSourceLanguage(), request.GetTargetLanguage())
 	if err != nil {
 		log.Printf("Failed to detect document: %v", err)
 		return nil, status.Error(codes.Internal, codes.Internal.String())
 	}
+	tracker.report(pb.Stage_STAGE_DETECT_DOCUMENT)
+
+	uriImages := []string{}
+	imageSlices := []*pb.ImageSlice{}
+	glossaryViolations := []*pb.GlossaryViolation{}
+	var encodedImage []byte
+	var outputMimeType string
+	for i, page := range pages {
+		translated, pageViolations, err := s.translatePage(page, sourceLanguage, request.GetTargetLanguage(), request.GetGlossary(), targetLanguageFonts, tracker)
+		if err != nil {
+			return nil, err
+		}
+		for _, violation := range pageViolations {
+			violation.PageIndex = int32(i)
+		}
+		glossaryViolations = append(glossaryViolations, pageViolations...)
+
+		encodeRows := func(top int, bottom int) ([]byte, string, error) {
+			return encodeTranslatedPage(translated, top, bottom, request.GetOutputEncoding(), request.GetTargetLanguage(), targetLanguageFonts)
+		}
+		encodedImage, outputMimeType, err = encodeRows(0, translated.image.Bounds().Dy())
+		if err != nil {
+			log.Printf("Failed to encode image: %v", err)
+			return nil, status.Error(codes.Internal, codes.Internal.String())
+		}
+
+		objectName := fmt.Sprintf("image-%d-%s-after.%s", currentTimestamp, request.GetTargetLanguage().String(), fileExtension(outputMimeType))
+		if len(pages) > 1 {
+			objectName = fmt.Sprintf("image-%d-%s-after-%d.%s", currentTimestamp, request.GetTargetLanguage().String(), i+1, fileExtension(outputMimeType))
+		}
+		s.storage.Client.SaveBytes(ctx, s.storage.ToImageBucket, objectName, encodedImage)
+
+		// Sliced pages are only returned in slices, as the whole pages would double the size of the response.
+		if request.GetMaxSliceHeight() <= 0 {
+			uriImages = append(uriImages, toDataUri(encodedImage, outputMimeType))
+		} else {
+			pageSlices, err := sliceImage(translated.image.Bounds().Dy(), page.textBoxes, int(request.GetMaxSliceHeight()), encodeRows)
+			if err != nil {
+				log.Printf("Failed to slice image: %v", err)
+				return nil, status.Error(codes.Internal, codes.Internal.String())
+			}
+			for _, slice := range pageSlices {
+				slice.PageIndex = int32(i)
+			}
+			imageSlices = append(imageSlices, pageSlices...)
+		}
+	}
+
+	response := &pb.TranslateToImageResponse{
+		MimeType:           outputMimeType,
+		SourceLanguage:     sourceLanguage,
+		GlossaryViolations: glossaryViolations,
+		Slices:             imageSlices,
+	}
+	if len(uriImages) > 0 {
+		response.UriImage = uriImages[0]
+	}
+	if isDocumentMimeType(mimeType) && len(uriImages) > 0 {
+		response.PageUriImages = uriImages
+	}
+	return response, nil
+}
+
+// Removes the original texts from the page image and draws the translated texts on it.
+// Returns the translated page along with the sentences whose translation does not follow the glossary.
+func (s *server) translatePage(page pageSegment, sourceLanguage pb.Language, targetLanguage pb.Language, terms *pb.Glossary, targetLanguageFonts *font.FontsByFace, tracker *progressTracker) (translatedPage, []*pb.GlossaryViolation, error) {
+	originImage, _, err := image.Decode(bytes.NewReader(page.byteImage))
+	if err != nil {
+		log.Printf("Failed to decode image: %v", err)
+		return translatedPage{}, nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+	// Effects and faces are detected on the original image, before the texts are removed.
+	page.paragraphs = detectTextEffects(originImage, page.paragraphs)
+	page.paragraphs = detectFontFaces(originImage, page.paragraphs, targetLanguageFonts)
 
 	imageWithoutTextsChan := make(chan struct {
 		image image.Image
 		err   error
 	})
 	translatedChan := make(chan struct {
-		segments []lineSegment
-		err      error
+		segments   []lineSegment
+		violations []*pb.GlossaryViolation
+		err        error
 	})
 	go func() {
-		img, err := s.imageWithoutTexts(spec.byteImage, paragraphs)
+		img, err := s.imageWithoutTexts(originImage, page.paragraphs)
+		if err == nil {
+			tracker.report(pb.Stage_STAGE_IMAGE_WITHOUT_TEXTS)
+		}
 		imageWithoutTextsChan <- struct {
 			image image.Image
 			err   error
 		}{img, err}
 	}()
 	go func() {
-		segments, err := s.translateParagraphSegments(paragraphs, request.GetTargetLanguage())
+		segments, violations, err := s.translateParagraphSegments(page.paragraphs, sourceLanguage, targetLanguage, terms, tracker)
 		translatedChan <- struct {
-			segments []lineSegment
-			err      error
-		}{segments, err}
+			segments   []lineSegment
+			violations []*pb.GlossaryViolation
+			err        error
+		}{segments, violations, err}
 	}()
 
 	imageWithoutTextsResult := <-imageWithoutTextsChan
 	translatedResult := <-translatedChan
 	if imageWithoutTextsResult.err != nil {
 		log.Printf("Failed to create none text image: %v", imageWithoutTextsResult.err)
-		return nil, status.Error(codes.Internal, codes.Internal.String())
+		return translatedPage{}, nil, status.Error(codes.Internal, codes.Internal.String())
 	}
 	if translatedResult.err != nil {
 		log.Printf("Failed to translate line segments: %v", translatedResult.err)
-		return nil, status.Error(codes.Internal, codes.Internal.String())
+		return translatedPage{}, nil, status.Error(codes.Internal, codes.Internal.String())
 	}
 
-	translatedImage, err := drawTexts(imageWithoutTextsResult.image, translatedResult.segments, s.fontProvider.GetFontByLanguage(request.GetTargetLanguage()))
+	background := imageWithoutTextsResult.image
+	// The drawing context is only used to measure the texts while laying them out.
+	words := layoutTexts(gg.NewContext(1, 1), translatedResult.segments, targetLanguage, targetLanguageFonts)
+	translatedImage, err := drawTexts(background, words, targetLanguageFonts)
 	if err != nil {
 		log.Printf("Failed to draw texts: %v", err)
-		return nil, status.Error(codes.Internal, codes.Internal.String())
+		return translatedPage{}, nil, status.Error(codes.Internal, codes.Internal.String())
 	}
+	tracker.report(pb.Stage_STAGE_DRAW_TEXTS)
+	return translatedPage{image: translatedImage, background: background, words: words}, translatedResult.violations, nil
+}
 
-	// TODO(#2880): Enhance TranslateToImage to return individual translated images.
-	buffer := new(bytes.Buffer)
-	if err := png.Encode(buffer, translatedImage); err != nil {
-		log.Printf("Failed to encode image: %v", err)
-		return nil, status.Error(codes.Internal, codes.Internal.String())
+// Detects texts and their styles with Document AI, leaving out lines already written in the target language.
+// Returns the pages along with the source language, which is detected from every page when unspecified.
+func (s *server) detectDocument(ctx context.Context, content []byte, mimeType string, sourceLanguage pb.Language, targetLanguage pb.Language) ([]pageSegment, pb.Language, error) {
+	pages, err := s.detectPages(ctx, content, mimeType)
+	if err != nil {
+		return nil, pb.Language_LANGUAGE_UNSPECIFIED, err
 	}
 
-	s.storage.Client.SaveBytes(
-		ctx,
-		s.storage.ToImageBucket,
-		fmt.Sprintf("image-%d-%s-after.png", currentTimestamp, request.GetTargetLanguage().String()),
-		buffer.Bytes(),
-	)
-	return &pb.TranslateToImageResponse{UriImage: "data:image/png;base64," + base64.StdEncoding.EncodeToString(buffer.Bytes())}, nil
+	sourceLanguage = detectSourceLanguage(utils.FlatMap(pages, func(page pageSegment) []paragraphSegment {
+		return page.paragraphs
+	}), sourceLanguage)
+	return utils.Map(pages, func(page pageSegment) pageSegment {
+		page.textBoxes = utils.FlatMap(page.paragraphs, paragraphPositions)
+		page.paragraphs = groupedSimilarStyle(filterNonTargetLanguage(page.paragraphs, sourceLanguage, targetLanguage))
+		return page
+	}), sourceLanguage, nil
+}
+
+// Returns one page for raster images, and one page per document page for PDF and multi-page TIFF inputs.
+func (s *server) detectPages(ctx context.Context, content []byte, mimeType string) ([]pageSegment, error) {
+	document, err := s.processDocument(ctx, content, mimeType)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := toDocumentPageSegments(document)
+	if len(pages) == 0 {
+		return nil, errors.New("no pages in the document")
+	}
+	if !isDocumentMimeType(mimeType) {
+		// Raster images keep the original bytes instead of the image rendered by Document AI.
+		pages[0].byteImage = content
+		pages[0].mimeType = mimeType
+	}
+
+	for i, page := range pages {
+		if len(page.byteImage) == 0 {
+			return nil, fmt.Errorf("no image for page %d", i+1)
+		}
+	}
+	return pages, nil
 }
 
-func (s *server) detectDocument(ctx context.Context, byteImage []byte, targetLanguage pb.Language) ([]paragraphSegment, error) {
+func (s *server) processDocument(ctx context.Context, content []byte, mimeType string) (*documentaipb.Document, error) {
 	request := &documentaipb.ProcessRequest{
 		Name: fmt.Sprintf("projects/%s/locations/%s/processors/%s", s.documentaiSpec.ProjectID, s.documentaiSpec.Location, s.documentaiSpec.ProcessorID),
 		Source: &documentaipb.ProcessRequest_RawDocument{
 			RawDocument: &documentaipb.RawDocument{
-				Content:  byteImage,
-				MimeType: "image/png",
+				Content:  content,
+				MimeType: mimeType,
 			},
 		},
 		ProcessOptions: &documentaipb.ProcessOptions{
@@ -177,51 +304,101 @@ func (s *server) detectDocument(ctx context.Context, byteImage []byte, targetLan
 		log.Printf("Failed to process document: %v", err)
 		return nil, err
 	}
-
-	return groupedSimilarStyle(
-		filterNonTargetLanguage(
-			toDocumentParagraphSegments(response.GetDocument()),
-			targetLanguage,
-		),
-	), nil
+	return response.GetDocument(), nil
 }
 
-func drawTexts(image image.Image, lines []lineSegment, targetLanguageFonts *font.FontsByFace) (image.Image, error) {
-	drawingContext := gg.NewContextForImage(image)
+// Lays out the translated lines in the boxes of the original lines, and returns the words where they are drawn.
+func layoutTexts(drawingContext *gg.Context, lines []lineSegment, targetLanguage pb.Language, targetLanguageFonts *font.FontsByFace) []wordSegment {
+	// Vertical source text is kept vertical only when the target language is also written vertically.
+	// Otherwise it is laid out horizontally in the same box, as before.
+	verticalWords := []wordSegment{}
+	horizontalLines := []lineSegment{}
+	for _, line := range lines {
+		if supportsVerticalText(targetLanguage) && isVerticalLine(line) {
+			if repositionedWords, ok := repositionVerticalText(line); ok {
+				verticalWords = append(verticalWords, repositionedWords...)
+				continue
+			}
+			box := combinedPosition(utils.Map(line.words, func(word wordSegment) position {
+				return word.position
+			}))
+			log.Printf("Vertical text at %v does not fit into its columns, laying it out horizontally", box)
+		}
+		horizontalLines = append(horizontalLines, line)
+	}
+	// Rotated and skewed lines are laid out upright, and drawn rotated.
+	horizontalLines = utils.Map(horizontalLines, uprightLine)
 	// Need to resize the font based on the translated text.
-	lines = resizeFont(drawingContext, lines, targetLanguageFonts)
+	horizontalLines = resizeFont(drawingContext, horizontalLines, targetLanguageFonts)
 
-	words := utils.FlatMap(lines, func(line lineSegment) []wordSegment {
+	words := utils.FlatMap(horizontalLines, func(line lineSegment) []wordSegment {
 		currentPositions := utils.Map(line.words, func(word wordSegment) position {
 			return word.position
 		})
 
-		return repositionText(currentPositions, line.words, drawingContext, targetLanguageFonts)
+		repositionedWords := repositionText(currentPositions, line.words, line.alignment, drawingContext, targetLanguageFonts, targetLanguage)
+		return utils.Map(repositionedWords, func(word wordSegment) wordSegment {
+			word.rotation = line.rotation
+			return word
+		})
 	})
+	return append(words, verticalWords...)
+}
 
-	for _, word := range words {
-		font := getFontByWeight(targetLanguageFonts, word.style.fontWeight)
-		drawingContext.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: *word.fontSize}))
-		drawingContext.SetColor(word.style.textColor)
-
-		startOfWidth := float64(word.position.left)
-		middleOfHeight := float64(word.position.top+word.position.bottom) / 2
-		drawingContext.DrawStringAnchored(
-			word.text,
-			startOfWidth,   /* =x */
-			middleOfHeight, /* =y */
-			0,              /* =ax (align left in x) */
-			0.3,            /* =ay (align almost center in y) */
-		)
+func drawTexts(image image.Image, words []wordSegment, targetLanguageFonts *font.FontsByFace) (image.Image, error) {
+	drawingContext := gg.NewContextForImage(image)
+	drawHighlights(drawingContext, targetLanguageFonts, words)
+
+	for i, word := range words {
+		fonts := getFontByStyle(targetLanguageFonts, word.style)
+		decorations := decorationLines(drawingContext, targetLanguageFonts, words, i)
+		drawingContext.SetFontFace(fonts.NewFace(*word.fontSize))
+
+		drawText := func() {
+			drawOblique(drawingContext, targetLanguageFonts, word, func() {
+				if word.vertical {
+					drawVerticalCharacter(drawingContext, word)
+					return
+				}
+
+				startOfWidth := float64(word.position.left)
+				middleOfHeight := float64(word.position.top+word.position.bottom) / 2
+				drawingContext.DrawStringAnchored(
+					word.text,
+					startOfWidth,   /* =x */
+					middleOfHeight, /* =y */
+					0,              /* =ax (align left in x) */
+					0.3,            /* =ay (align almost center in y) */
+				)
+			})
+			// Decorations are drawn along with the text, so that they get the same shadow and outline.
+			drawDecorationLines(drawingContext, decorations)
+		}
+		drawRotated(drawingContext, word.rotation, func() {
+			drawTextEffects(drawingContext, word, drawText)
+			drawingContext.SetColor(word.style.textColor)
+			drawText()
+		})
 	}
 	return drawingContext.Image(), nil
 }
 
+// Returns where the baseline of a word starts, or where its center is on the baseline for vertical text,
+// along with whether it is a vertical character rotated by 90 degrees. Matches the anchors drawTexts draws with.
+func wordBaseline(word wordSegment) (float64, float64, bool) {
+	if word.vertical {
+		centerX, centerY, rotated := verticalCharacterCenter(word)
+		return centerX, centerY + 0.3**word.fontSize, rotated
+	}
+	return float64(word.position.left), float64(word.position.top+word.position.bottom)/2 + 0.3**word.fontSize, false
+}
+
 // Returns the largest font size (≤ original size) that allows text to fit within specified dimensions.
-func fitFontSize(drawingContext *gg.Context, font *truetype.Font, text string, originalFontSize float64, boundingBox position) float64 {
+// Runes missing from the font are measured with the fallback fonts that draw them.
+func fitFontSize(drawingContext *gg.Context, fonts font.FontChain, text string, originalFontSize float64, boundingBox position) float64 {
 	boxWidth := float64(boundingBox.right - boundingBox.left)
 	boxHeight := float64(boundingBox.bottom - boundingBox.top)
-	drawingContext.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: originalFontSize}))
+	drawingContext.SetFontFace(fonts.NewFace(originalFontSize))
 	width, height := drawingContext.MeasureString(text)
 	if width <= boxWidth && height <= boxHeight {
 		return originalFontSize
@@ -230,7 +407,7 @@ func fitFontSize(drawingContext *gg.Context, font *truetype.Font, text string, o
 	low, high := 1.0, originalFontSize
 	for low <= high {
 		mid := (low + high) / 2
-		drawingContext.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: float64(mid)}))
+		drawingContext.SetFontFace(fonts.NewFace(float64(mid)))
 		width, height = drawingContext.MeasureString(text)
 		if width <= boxWidth && height <= boxHeight {
 			low = mid + 1
@@ -259,7 +436,7 @@ func resizeFont(drawingContext *gg.Context, lines []lineSegment, targetLanguageF
 		sentence := strings.Join(utils.Map(line.words, func(word wordSegment) string {
 			return word.text
 		}), " ")
-		font := getFontByWeight(targetLanguageFonts, line.words[0].style.fontWeight)
+		fonts := getFontByStyle(targetLanguageFonts, line.words[0].style)
 
 		originalSize := 0.0
 		for _, word := range line.words {
@@ -278,31 +455,50 @@ func resizeFont(drawingContext *gg.Context, lines []lineSegment, targetLanguageF
 			}
 		}
 
-		lineFontSize := fitFontSize(drawingContext, font, sentence, originalSize, position{
+		lineFontSize := fitFontSize(drawingContext, fonts, sentence, originalSize, position{
 			left:   0,
 			top:    0,
 			right:  int32(totalWidth),
 			bottom: int32(maxHeight),
 		})
 
-		return lineSegment{
-			words: utils.Map(line.words, func(word wordSegment) wordSegment {
-				if word.fontSize == nil || *word.fontSize == 0 {
-					word.fontSize = &lineFontSize
-				}
+		line.words = utils.Map(line.words, func(word wordSegment) wordSegment {
+			if word.fontSize == nil || *word.fontSize == 0 {
+				word.fontSize = &lineFontSize
+			}
 
-				*word.fontSize = min(*word.fontSize, lineFontSize)
-				return word
-			}),
-		}
+			*word.fontSize = min(*word.fontSize, lineFontSize)
+			return word
+		})
+		return line
 	})
 }
 
 // Repositions text segments within the bounding box based on available width.
 // Handles differences between original and translated text lengths,
 // ensuring proper fit and wrapping within the original layout.
-func repositionText(currentPositions []position, words []wordSegment, drawingContext *gg.Context, targetLanguageFonts *font.FontsByFace) []wordSegment {
+// Lines break at the opportunities of the target language, and the font is shrunk when the text does not fit,
+// as breaking only at those opportunities leaves unused space at the end of lines.
+// Each row is then aligned the same way as the original paragraph.
+func repositionText(currentPositions []position, words []wordSegment, alignment textAlignment, drawingContext *gg.Context, targetLanguageFonts *font.FontsByFace, targetLanguage pb.Language) []wordSegment {
 	combinedPositions := combinedLinePositions(currentPositions)
+	for {
+		repositionedWords, overflowed := wrapText(combinedPositions, words, alignment, drawingContext, targetLanguageFonts, targetLanguage)
+		if !overflowed || slices.ContainsFunc(words, func(word wordSegment) bool {
+			return *word.fontSize <= 1
+		}) {
+			return repositionedWords
+		}
+		words = utils.Map(words, func(word wordSegment) wordSegment {
+			fontSize := max(1, *word.fontSize*FONT_SHRINK_RATIO)
+			word.fontSize = &fontSize
+			return word
+		})
+	}
+}
+
+// Lays out the words line by line, and returns whether some text did not fit into the lines.
+func wrapText(combinedPositions []position, words []wordSegment, alignment textAlignment, drawingContext *gg.Context, targetLanguageFonts *font.FontsByFace, targetLanguage pb.Language) ([]wordSegment, bool) {
 	wordQueue := utils.Map(words, func(word wordSegment) wordSegment {
 		return wordSegment{
 			text:     word.text + " ",
@@ -316,18 +512,22 @@ func repositionText(currentPositions []position, words []wordSegment, drawingCon
 			break
 		}
 
-		remainWidth := int(currentPosition.right - currentPosition.left)
+		row := currentPosition
+		rowStart := len(repositionedWords)
+		lineWidth := float64(currentPosition.right - currentPosition.left)
+		remainWidth := lineWidth
 
 		for len(wordQueue) > 0 {
 			word := wordQueue[0]
 			wordQueue = wordQueue[1:]
-			font := getFontByWeight(targetLanguageFonts, word.style.fontWeight)
-			drawingContext.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: *word.fontSize}))
-			width, _ := drawingContext.MeasureString(word.text)
+			fonts := getFontByStyle(targetLanguageFonts, word.style)
+			drawingContext.SetFontFace(fonts.NewFace(*word.fontSize))
 
-			if width <= float64(remainWidth) {
+			head, tail := breakText(drawingContext, word.text, remainWidth, lineWidth, targetLanguage)
+			if head != "" {
+				width, _ := drawingContext.MeasureString(head)
 				repositionedWords = append(repositionedWords, wordSegment{
-					text: word.text,
+					text: head,
 					position: position{
 						left:   currentPosition.left,
 						top:    currentPosition.top,
@@ -338,51 +538,46 @@ func repositionText(currentPositions []position, words []wordSegment, drawingCon
 					fontSize: word.fontSize,
 				})
 				currentPosition.left += int32(width)
-				remainWidth -= int(width)
-			} else {
-				availableTextCount := 0
-				for i := 1; i <= len([]rune(word.text)); i++ {
-					width, _ := drawingContext.MeasureString(string([]rune(word.text)[:i]))
-					if width > float64(remainWidth) {
-						availableTextCount = i - 1
-						break
-					}
-				}
+				remainWidth -= width
+			}
+			if strings.TrimSpace(tail) == "" {
+				continue
+			}
 
-				repositionedWords = append(repositionedWords, wordSegment{
-					text: string([]rune(word.text)[:availableTextCount]),
-					position: position{
-						left:   currentPosition.left,
-						top:    currentPosition.top,
-						right:  currentPosition.right,
-						bottom: currentPosition.bottom,
-					},
+			// The rest goes to the next line.
+			wordQueue = append([]wordSegment{
+				{
+					text:     tail,
 					style:    word.style,
 					fontSize: word.fontSize,
-				})
-
-				wordQueue = append([]wordSegment{
-					{
-						text:     string([]rune(word.text)[availableTextCount:]),
-						style:    word.style,
-						fontSize: word.fontSize,
-					},
-				}, wordQueue...)
-
-				break
-			}
+				},
+			}, wordQueue...)
+			break
 		}
+
+		alignedWords := alignRow(drawingContext, targetLanguageFonts, repositionedWords[rowStart:], row, alignment, len(wordQueue) == 0)
+		repositionedWords = append(repositionedWords[:rowStart], alignedWords...)
 	}
-	return repositionedWords
+	return repositionedWords, len(wordQueue) > 0
 }
 
-func getFontByWeight(targetLanguageFonts *font.FontsByFace, weight int) *truetype.Font {
-	if weight >= BOLD_WEIGHT {
-		return targetLanguageFonts.SansSerif.Bold
-	} else if weight >= SEMIBOLD_WEIGHT {
-		return targetLanguageFonts.SansSerif.SemiBold
-	}
-	return targetLanguageFonts.SansSerif.Regular
+// Returns the font of the face and weight of the style. Falls back to sans serif when the face is not installed,
+// and to the upright font when the italic font is not installed.
+// The font is followed by the fallback fonts of the same face and weight, for the runes it lacks.
+func getFontByStyle(targetLanguageFonts *font.FontsByFace, textStyle *style) font.FontChain {
+	languageFonts := append([]*font.FontsByFace{targetLanguageFonts}, targetLanguageFonts.Fallbacks...)
+	return utils.Map(languageFonts, func(fontsByFace *font.FontsByFace) *sfnt.Font {
+		fonts := fontsByFace.ByFace(textStyle.fontFace)
+		if textStyle.italic && fonts.Italic != nil {
+			fonts = *fonts.Italic
+		}
+		if textStyle.fontWeight >= BOLD_WEIGHT {
+			return fonts.Bold
+		} else if textStyle.fontWeight >= SEMIBOLD_WEIGHT {
+			return fonts.SemiBold
+		}
+		return fonts.Regular
+	})
 }
 
 func combinedLinePositions(positions []position) []position {
@@ -407,10 +602,14 @@ type segmentWithId struct {
 	Text     string `json:"text"`
 	style    *style
 	position position
+	quad     []point
 	fontSize *float64
 }
 
-func (s *server) translateParagraphSegments(paragraphSegments []paragraphSegment, targetLanguage pb.Language) ([]lineSegment, error) {
+// Returns the translated lines along with the sentences whose translation does not follow the glossary.
+func (s *server) translateParagraphSegments(paragraphSegments []paragraphSegment, sourceLanguage pb.Language, targetLanguage pb.Language, terms *pb.Glossary, tracker *progressTracker) ([]lineSegment, []*pb.GlossaryViolation, error) {
+	// Alignment is detected before grouping, as paragraphs are no longer known afterwards.
+	paragraphSegments = detectAlignments(paragraphSegments)
 	lines, err := backoff.RetryWithData(func() ([]lineSegment, error) {
 		lineSegments, err := s.groupedLines(paragraphSegments)
 		if err != nil {
@@ -419,8 +618,9 @@ func (s *server) translateParagraphSegments(paragraphSegments []paragraphSegment
 		return lineSegments, nil
 	}, backoff.WithMaxRetries(backoff.NewConstantBackOff(s.backoffDuration), 4))
 	if err != nil {
-		return nil, fmt.Errorf("failed to group paragraphs: %w", err)
+		return nil, nil, fmt.Errorf("failed to group paragraphs: %w", err)
 	}
+	tracker.report(pb.Stage_STAGE_GROUPED_LINES)
 
 	splitLines := [][]lineSegment{}
 	// If the number of lines is bigger than 2, LLM keeps failing frequently.
@@ -433,8 +633,9 @@ func (s *server) translateParagraphSegments(paragraphSegments []paragraphSegment
 	}
 
 	type resultType struct {
-		lines []lineSegment
-		err   error
+		lines      []lineSegment
+		violations []*pb.GlossaryViolation
+		err        error
 	}
 	resultChan := make(chan resultType, len(splitLines))
 	for _, splitLine := range splitLines {
@@ -443,12 +644,12 @@ func (s *server) translateParagraphSegments(paragraphSegments []paragraphSegment
 			textSegments := utils.Map(lines, func(line lineSegment) []segmentWithId {
 				return utils.Map(line.words, func(word wordSegment) segmentWithId {
 					id++
-					return segmentWithId{Id: id, Text: word.text, style: word.style, position: word.position, fontSize: word.fontSize}
+					return segmentWithId{Id: id, Text: word.text, style: word.style, position: word.position, quad: word.quad, fontSize: word.fontSize}
 				})
 			})
 
 			translatedSegments, err := backoff.RetryWithData(func() ([][]segmentWithId, error) {
-				translatedSegments, err := s.translate(textSegments, targetLanguage)
+				translatedSegments, err := s.translate(textSegments, sourceLanguage, targetLanguage, terms)
 				if err != nil {
 					return nil, fmt.Errorf("failed to translate: %w", err)
 				}
@@ -462,6 +663,11 @@ func (s *server) translateParagraphSegments(paragraphSegments []paragraphSegment
 				return
 			}
 
+			violations := []*pb.GlossaryViolation{}
+			for i, translatedSegment := range translatedSegments {
+				violations = append(violations, glossary.Check(terms, joinSegmentTexts(textSegments[i]), joinSegmentTexts(translatedSegment))...)
+			}
+
 			originalSegments := utils.FlatMap(textSegments, func(segment []segmentWithId) []segmentWithId {
 				return segment
 			})
@@ -476,25 +682,40 @@ func (s *server) translateParagraphSegments(paragraphSegments []paragraphSegment
 						return wordSegment{
 							text:     segment.Text,
 							position: originalSegments[id-1].position, // Position must be the same as the original text.
+							quad:     originalSegments[id-1].quad,
 							style:    matchedSegment.style,
 							fontSize: matchedSegment.fontSize,
 						}
 					}),
 				}
 			})
-			resultChan <- resultType{lines: result}
+			// Translated segments are in the same order as the lines.
+			for i := range result {
+				result[i].alignment = lines[i].alignment
+			}
+			resultChan <- resultType{lines: result, violations: violations}
 		}(splitLine)
 	}
 
 	var result []lineSegment
+	violations := []*pb.GlossaryViolation{}
 	for i := 0; i < len(splitLines); i++ {
 		r := <-resultChan
 		if r.err != nil {
-			return nil, r.err
+			return nil, nil, r.err
 		}
 		result = append(result, r.lines...)
+		violations = append(violations, r.violations...)
+		tracker.reportTranslate(i+1, len(splitLines))
 	}
-	return result, nil
+	return result, violations, nil
+}
+
+// E.g., [{"id": 1, "text": "Let's"}, {"id": 2, "text": "go"}] -> "Let's go"
+func joinSegmentTexts(segments []segmentWithId) string {
+	return strings.Join(utils.Map(segments, func(segment segmentWithId) string {
+		return segment.Text
+	}), " ")
 }
 
 func validateTranslatedSegments(originalSegments [][]segmentWithId, translatedSegments [][]segmentWithId) error {
@@ -521,11 +742,16 @@ func validateTranslatedSegments(originalSegments [][]segmentWithId, translatedSe
 	return nil
 }
 
-func (s *server) translate(segments [][]segmentWithId, targetLanguage pb.Language) ([][]segmentWithId, error) {
+// The source language is given to the model as in translateMarkdown, so that short words shared between languages are read right.
+func (s *server) translate(segments [][]segmentWithId, sourceLanguage pb.Language, targetLanguage pb.Language, terms *pb.Glossary) ([][]segmentWithId, error) {
 	text, err := json.Marshal(segments)
 	if err != nil {
 		return nil, err
 	}
+	sourceInstructions := ""
+	if sourceLanguage != pb.Language_LANGUAGE_UNSPECIFIED {
+		sourceInstructions = "The words are written in " + targetLanguageName(sourceLanguage) + ".\n"
+	}
 
 	// Using OpenAI directly instead of Fragma
 	response, err := s.translationClient.ChatCompletion(context.Background(), openai.ChatCompletionRequest{
@@ -542,7 +768,7 @@ Please only send json responses. Examples include:
 [ { "id": 1234, "text": "Translated word" } ],
 [ { "id": 1122, "text": "Translated word2" } ],
 ]
-`},
+` + sourceInstructions + glossary.Instructions(terms)},
 			{Role: openai.ChatMessageRoleUser, Content: `[ [ { "id": 1, "text": "밥" }, { "id": 2, "text": "먹으러" }, { "id": 3, "text": "가자" } ] ]`},
 			{Role: openai.ChatMessageRoleAssistant, Content: `[ [ { "id": 3, "text": "Let's" }, { "id": 2, "text": "go" }, { "id": 1, "text": "eat" } ] ]`},
 			{Role: openai.ChatMessageRoleUser, Content: string(text)},
@@ -638,10 +864,12 @@ Example:
 							return wordSegment{
 								text:     word.text,
 								position: word.position,
+								quad:     word.quad,
 								fontSize: &fontSize,
 								style:    word.style,
 							}
 						})...),
+						alignment: originalLines[id].alignment,
 					}
 				}, lineSegment{})
 			})
@@ -705,10 +933,13 @@ func extractJson(text string) (string, error) {
 	return text[startIndex+len("```json") : endIndex], nil
 }
 
-func toDocumentParagraphSegments(document *documentaipb.Document) []paragraphSegment {
+func toDocumentPageSegments(document *documentaipb.Document) []pageSegment {
 	// Document structure:
 	// Document
 	//   └── Pages []Document_Page
+	//        ├── Image
+	//        │    ├── Content
+	//        │    └── MimeType
 	//        └── Paragraphs []Document_Page_Paragraph
 	//             └── Tokens []Document_Page_Token
 	//                  ├── Layout
@@ -722,13 +953,22 @@ func toDocumentParagraphSegments(document *documentaipb.Document) []paragraphSeg
 	//                  │              └── Y
 	//                  └── StyleInfo
 	//                       ├── PixelFontSize
-	//                       └── TextColor
-	//                            ├── Red
-	//                            ├── Green
-	//                            └── Blue
-
-	words := utils.FlatMap(document.GetPages(), func(page *documentaipb.Document_Page) []wordSegment {
-		return utils.Map(page.GetTokens(), func(token *documentaipb.Document_Page_Token) wordSegment {
+	//                       ├── FontType
+	//                       ├── Handwritten
+	//                       ├── Italic
+	//                       ├── Underlined
+	//                       ├── Strikeout
+	//                       ├── TextColor
+	//                       │    ├── Red
+	//                       │    ├── Green
+	//                       │    └── Blue
+	//                       └── BackgroundColor
+
+	// Text anchors are indexes into the text of the whole document.
+	documentText := []rune(document.GetText())
+
+	return utils.Map(document.GetPages(), func(page *documentaipb.Document_Page) pageSegment {
+		words := utils.Map(page.GetTokens(), func(token *documentaipb.Document_Page_Token) wordSegment {
 			currentPosition := utils.Reduce(token.GetLayout().GetBoundingPoly().GetVertices(), func(currentPosition position, vertex *documentaipb.Vertex) position {
 				return position{
 					top:    min(currentPosition.top, vertex.GetY()),
@@ -743,7 +983,7 @@ func toDocumentParagraphSegments(document *documentaipb.Document) []paragraphSeg
 				right:  0,
 			})
 			text := strings.Join(utils.Map(token.GetLayout().GetTextAnchor().GetTextSegments(), func(segment *documentaipb.Document_TextAnchor_TextSegment) string {
-				return string([]rune(document.GetText())[segment.GetStartIndex():segment.GetEndIndex()])
+				return string(documentText[segment.GetStartIndex():segment.GetEndIndex()])
 			}), "")
 
 			styleInfo := token.GetStyleInfo()
@@ -760,26 +1000,44 @@ func toDocumentParagraphSegments(document *documentaipb.Document) []paragraphSeg
 				}
 			}
 			fontSize := float64(token.GetStyleInfo().GetPixelFontSize())
+			var backgroundColor *colorful.Color
+			if styleInfo.GetBackgroundColor() != nil {
+				backgroundColor = &colorful.Color{
+					R: float64(styleInfo.GetBackgroundColor().GetRed()),
+					G: float64(styleInfo.GetBackgroundColor().GetGreen()),
+					B: float64(styleInfo.GetBackgroundColor().GetBlue()),
+				}
+			}
 
 			return wordSegment{
 				text:     strings.TrimSuffix(text, "\n"),
 				position: currentPosition,
+				quad:     toQuad(token.GetLayout().GetBoundingPoly().GetVertices()),
 				style: &style{
 					textColor: colorful.Color{
 						R: float64(styleInfo.GetTextColor().GetRed()),
 						G: float64(styleInfo.GetTextColor().GetGreen()),
 						B: float64(styleInfo.GetTextColor().GetBlue()),
 					},
-					height:     int(currentPosition.bottom - currentPosition.top),
-					weight:     1,
-					fontWeight: fontWeight,
+					height:          int(currentPosition.bottom - currentPosition.top),
+					weight:          1,
+					fontWeight:      fontWeight,
+					fontFace:        fontFaceFromStyleInfo(styleInfo.GetFontType(), styleInfo.GetHandwritten()),
+					backgroundColor: backgroundColor,
+					italic:          styleInfo.GetItalic(),
+					underlined:      styleInfo.GetUnderlined(),
+					strikeout:       styleInfo.GetStrikeout(),
 				},
 				fontSize: &fontSize,
 			}
 		})
-	})
 
-	return toParagraphs(words)
+		return pageSegment{
+			byteImage:  page.GetImage().GetContent(),
+			mimeType:   page.GetImage().GetMimeType(),
+			paragraphs: toParagraphs(words),
+		}
+	})
 }
 
 // Iterates through document paragraph segments and unifies the style of text segments
@@ -820,7 +1078,7 @@ func groupBlackLines(lines []lineSegment) []lineSegment {
 				}
 
 				lastWord := combined[len(combined)-1]
-				if shouldTreatAsBlack(lastWord) && shouldTreatAsBlack(word) {
+				if shouldTreatAsBlack(lastWord) && shouldTreatAsBlack(word) && hasSameDecoration(lastWord.style, word.style) {
 					combined[len(combined)-1] = combineWordSegments(lastWord, word)
 					return combined
 				} else {
@@ -868,6 +1126,9 @@ func shouldCombineWord(previous wordSegment, current wordSegment) bool {
 	if isOnlySymbol(strings.TrimSpace(previous.text)) || isOnlySymbol(strings.TrimSpace(current.text)) {
 		return true
 	}
+	if !hasSameDecoration(previous.style, current.style) {
+		return false
+	}
 
 	return isSimilar(previous.style, current.style, HEIGHT_THRESHOLD, WORD_MERGE_COLOR_DIFF_THRESHOLD)
 }
@@ -895,6 +1156,9 @@ func shouldCombineLine(previous lineSegment, current lineSegment) bool {
 
 	prevStyle := lastWordOfPrevLine.style
 	currStyle := lastWordOfCurrLine.style
+	if !hasSameDecoration(prevStyle, currStyle) {
+		return false
+	}
 
 	if math.Abs(float64(prevStyle.height-currStyle.height)) > float64(prevStyle.height)*HEIGHT_THRESHOLD_TOTAL {
 		return false
@@ -908,6 +1172,14 @@ func shouldCombineLine(previous lineSegment, current lineSegment) bool {
 	return prevStyle.textColor.DistanceCIEDE2000(currStyle.textColor) <= colorThreshold
 }
 
+// Italic, underlined and struck-through texts are kept apart from the others, as they often mean something different.
+// E.g., "$20" struck through followed by "$15" is a discount, not a price of "$20 $15".
+func hasSameDecoration(previousTextStyle *style, currentTextStyle *style) bool {
+	return previousTextStyle.italic == currentTextStyle.italic &&
+		previousTextStyle.underlined == currentTextStyle.underlined &&
+		previousTextStyle.strikeout == currentTextStyle.strikeout
+}
+
 func isGrayscaleColor(color colorful.Color) bool {
 	maxDiff := math.Max(math.Max(math.Abs(color.R-color.G), math.Abs(color.G-color.B)), math.Abs(color.B-color.R))
 	// Threshold (0.1) to identify grayscale/near-grayscale colors by checking if R, G, B values
@@ -917,10 +1189,15 @@ func isGrayscaleColor(color colorful.Color) bool {
 
 func combineWordSegments(previous wordSegment, current wordSegment) wordSegment {
 	maintainedStyle := &style{
-		textColor:  previous.style.textColor.BlendHsv(current.style.textColor, float64(previous.style.weight)/float64(previous.style.weight+current.style.weight)),
-		height:     (previous.style.height + current.style.height) / 2,
-		weight:     previous.style.weight + current.style.weight,
-		fontWeight: previous.style.fontWeight,
+		textColor:       previous.style.textColor.BlendHsv(current.style.textColor, float64(previous.style.weight)/float64(previous.style.weight+current.style.weight)),
+		height:          (previous.style.height + current.style.height) / 2,
+		weight:          previous.style.weight + current.style.weight,
+		fontWeight:      previous.style.fontWeight,
+		fontFace:        previous.style.fontFace,
+		backgroundColor: previous.style.backgroundColor,
+		italic:          previous.style.italic,
+		underlined:      previous.style.underlined,
+		strikeout:       previous.style.strikeout,
 	}
 	// Symbols maintain the style of the adjacent text.
 	// If the previous text is a symbol, we use the current text's style. E.g., "(", "Hello" -> "(Hello"
@@ -936,6 +1213,7 @@ func combineWordSegments(previous wordSegment, current wordSegment) wordSegment
 	return wordSegment{
 		text:     previous.text + current.text,
 		position: combinedPosition([]position{previous.position, current.position}),
+		quad:     combinedQuad(previous.quad, current.quad),
 		style:    maintainedStyle,
 		fontSize: &fontSize,
 	}
@@ -959,61 +1237,21 @@ func isSimilar(previousTextStyle *style, currentTextStyle *style, heightThreshol
 	return true
 }
 
-// TODO(#2630): Consider changing to a language detection library for more accurate results.
 func isOnlySymbol(text string) bool {
-	for _, char := range []rune(text) {
-		if isLanguage, _ := detectedLanguage(char); isLanguage {
-			return false
-		}
-	}
-	return true
-}
-
-// TODO(#2630): Consider changing to a language detection library for more accurate results.
-func detectedLanguage(text rune) (bool, pb.Language) {
-	switch {
-	case unicode.Is(unicode.Hangul, text):
-		return true, pb.Language_LANGUAGE_KO_KR
-	case unicode.Is(unicode.Hiragana, text) || unicode.Is(unicode.Katakana, text):
-		return true, pb.Language_LANGUAGE_JA_JP
-	case unicode.Is(unicode.Latin, text):
-		return true, pb.Language_LANGUAGE_EN_US
-	case unicode.IsLetter(text):
-		return true, pb.Language_LANGUAGE_UNSPECIFIED
-	}
-	return false, pb.Language_LANGUAGE_UNSPECIFIED
+	return !strings.ContainsFunc(text, unicode.IsLetter)
 }
 
-func (s *server) imageWithoutTexts(byteImage []byte, paragraphs []paragraphSegment) (image.Image, error) {
-	originImage, _, err := image.Decode(bytes.NewReader(byteImage))
-	if err != nil {
-		log.Printf("Failed to decode image: %v", err)
-		return nil, err
-	}
-
-	maskImg := image.NewRGBA(originImage.Bounds())
-	draw.Draw(maskImg, maskImg.Bounds(), image.Transparent, image.Point{}, draw.Src)
-
+func (s *server) imageWithoutTexts(originImage image.Image, paragraphs []paragraphSegment) (image.Image, error) {
 	lines := utils.FlatMap(paragraphs, func(paragraph paragraphSegment) []lineSegment {
 		return paragraph.lines
 	})
-
-	positions := utils.Map(lines, func(line lineSegment) position {
-		return combinedPosition(utils.Map(line.words, func(word wordSegment) position {
-			return word.position
-		}))
-	})
-
-	for _, position := range positions {
-		draw.Draw(maskImg, image.Rect(
-			max(int(position.left)-ADDITIONAL_MASK_PADDING, 0),
-			max(int(position.top)-ADDITIONAL_MASK_PADDING, 0),
-			min(int(position.right)+ADDITIONAL_MASK_PADDING, maskImg.Bounds().Dx()),
-			min(int(position.bottom)+ADDITIONAL_MASK_PADDING, maskImg.Bounds().Dy()),
-		), image.White, image.Point{}, draw.Src)
+	maskImg := textMask(originImage, lines)
+	filledImage, remainingMask, needsInpainting := fillFlatBackgrounds(originImage, maskImg)
+	if !needsInpainting {
+		return filledImage, nil
 	}
 
-	outputImage, err := s.lama.CreateMaskImage(originImage, maskImg)
+	outputImage, err := s.lama.CreateMaskImage(filledImage, remainingMask)
 	if err != nil {
 		log.Printf("Failed to create mask image: %v", err)
 		return nil, err
@@ -1021,21 +1259,18 @@ func (s *server) imageWithoutTexts(byteImage []byte, paragraphs []paragraphSegme
 	return outputImage, nil
 }
 
-func filterNonTargetLanguage(paragraphSegments []paragraphSegment, targetLanguage pb.Language) []paragraphSegment {
+// Keeps only the lines that need translation. Lines without letters, such as prices, are also left out.
+// The source language helps to detect the language of ambiguous lines. E.g., kanji-only lines.
+func filterNonTargetLanguage(paragraphSegments []paragraphSegment, sourceLanguage pb.Language, targetLanguage pb.Language) []paragraphSegment {
 	return utils.Map(paragraphSegments, func(paragraph paragraphSegment) paragraphSegment {
 		return paragraphSegment{
 			lines: utils.Filter(paragraph.lines, func(line lineSegment) bool {
 				text := strings.Join(utils.Map(line.words, func(word wordSegment) string {
 					return word.text
-				}), "")
+				}), " ")
 
-				for _, char := range []rune(text) {
-					isLanguage, language := detectedLanguage(char)
-					if isLanguage && language != targetLanguage {
-						return true
-					}
-				}
-				return false
+				isLanguage, detectedLanguage := language.Detect(text, sourceLanguage)
+				return isLanguage && detectedLanguage != targetLanguage
 			}),
 		}
 	})
@@ -0,0 +1,76 @@
+package impl
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/visionex-project/visionex/grpc"
+)
+
+func (s *server) TranslateToImageStream(request *pb.TranslateToImageRequest, stream grpc.ServerStreamingServer[pb.TranslateToImageProgress]) error {
+	tracker := newProgressTracker(stream.Send)
+
+	response, err := s.translateToImage(stream.Context(), request, tracker)
+	if err != nil {
+		return err
+	}
+	return tracker.send(&pb.TranslateToImageProgress{
+		Stage:               pb.Stage_STAGE_DONE,
+		ElapsedMilliseconds: time.Since(tracker.startTime).Milliseconds(),
+		Result:              response,
+	})
+}
+
+// Reports the progress of the TranslateToImage pipeline to the client.
+// A nil tracker ignores every report, so the unary RPC can share the same pipeline.
+type progressTracker struct {
+	startTime time.Time
+
+	// Stages such as imageWithoutTexts and translate run concurrently,
+	// but a gRPC stream does not allow concurrent sends.
+	mutex    sync.Mutex
+	sendFunc func(*pb.TranslateToImageProgress) error
+}
+
+func newProgressTracker(send func(*pb.TranslateToImageProgress) error) *progressTracker {
+	return &progressTracker{
+		startTime: time.Now(),
+		sendFunc:  send,
+	}
+}
+
+func (t *progressTracker) report(stage pb.Stage) {
+	if t == nil {
+		return
+	}
+	// Progress events are best-effort, so a failed send does not stop the pipeline.
+	if err := t.send(&pb.TranslateToImageProgress{
+		Stage:               stage,
+		ElapsedMilliseconds: time.Since(t.startTime).Milliseconds(),
+	}); err != nil {
+		log.Printf("Failed to send progress: %v", err)
+	}
+}
+
+func (t *progressTracker) reportTranslate(translatedChunkCount int, totalChunkCount int) {
+	if t == nil {
+		return
+	}
+	if err := t.send(&pb.TranslateToImageProgress{
+		Stage:                pb.Stage_STAGE_TRANSLATE,
+		ElapsedMilliseconds:  time.Since(t.startTime).Milliseconds(),
+		TranslatedChunkCount: int32(translatedChunkCount),
+		TotalChunkCount:      int32(totalChunkCount),
+	}); err != nil {
+		log.Printf("Failed to send progress: %v", err)
+	}
+}
+
+func (t *progressTracker) send(progress *pb.TranslateToImageProgress) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.sendFunc(progress)
+}
@@ -19,58 +19,123 @@ import (
 	"google.golang.org/grpc/status"
 
 	pb "github.com/visionex-project/visionex/grpc"
+	"github.com/visionex-project/visionex/grpc/impl/glossary"
 	"github.com/visionex-project/visionex/pkg/utils"
 )
 
 const (
 	MARKDOWN_PREFIX = "```markdown\n"
 	MARKDOWN_SUFFIX = "\n```"
+	// Separates the Markdown of each page for PDF and multi-page TIFF inputs.
+	PAGE_SEPARATOR = "\n\n---\n\n"
 )
 
 func (s *server) TranslateToMarkdown(ctx context.Context, request *pb.TranslateToMarkdownRequest) (*pb.TranslateToMarkdownResponse, error) {
-	img, _, err := image.Decode(bytes.NewReader(request.GetImage()))
+	mimeType, err := detectMimeType(request.GetImage())
 	if err != nil {
 		log.Printf("Failed to decode image: %v", err)
 		return nil, status.Errorf(codes.InvalidArgument, codes.InvalidArgument.String())
 	}
-	spec := &imageSpec{
-		width:     img.Bounds().Dx(),
-		height:    img.Bounds().Dy(),
-		uriImage:  "data:image/png;base64," + base64.StdEncoding.EncodeToString(request.GetImage()),
-		byteImage: request.GetImage(),
+	if err := glossary.Validate(request.GetGlossary()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	currentTimestamp := time.Now().UTC().Unix()
 	s.storage.Client.SaveBytes(
 		ctx,
 		s.storage.ToMarkdownBucket,
-		fmt.Sprintf("image-%d-%s-%s-before.png", currentTimestamp, request.GetModel().String(), request.GetTargetLanguage().String()),
-		spec.byteImage,
+		fmt.Sprintf("image-%d-%s-%s-before.%s", currentTimestamp, request.GetModel().String(), request.GetTargetLanguage().String(), fileExtension(mimeType)),
+		request.GetImage(),
 	)
 
+	pages := []pageSegment{{byteImage: request.GetImage(), mimeType: mimeType}}
+	if isDocumentMimeType(mimeType) {
+		document, err := s.processDocument(ctx, request.GetImage(), mimeType)
+		if err != nil {
+			log.Printf("failed to process document: %v", err)
+			return nil, status.Error(codes.Internal, codes.Internal.String())
+		}
+		pages = toDocumentPageSegments(document)
+	}
+
+	pageMarkdowns := []string{}
+	glossaryViolations := []*pb.GlossaryViolation{}
+	sourceLanguage := request.GetSourceLanguage()
+	for i, page := range pages {
+		translatedMarkdown, pageLanguage, pageViolations, err := s.translatePageToMarkdown(ctx, page, request.GetModel(), request.GetSourceLanguage(), request.GetTargetLanguage(), request.GetGlossary())
+		if err != nil {
+			return nil, err
+		}
+		pageMarkdowns = append(pageMarkdowns, translatedMarkdown)
+		for _, violation := range pageViolations {
+			violation.PageIndex = int32(i)
+		}
+		glossaryViolations = append(glossaryViolations, pageViolations...)
+		// The first page with texts decides the source language of the whole document.
+		if sourceLanguage == pb.Language_LANGUAGE_UNSPECIFIED {
+			sourceLanguage = pageLanguage
+		}
+	}
+	translatedMarkdown := strings.Join(pageMarkdowns, PAGE_SEPARATOR)
+
+	s.storage.Client.SaveBytes(
+		ctx,
+		s.storage.ToMarkdownBucket,
+		fmt.Sprintf("image-%d-%s-%s-after.md", currentTimestamp, request.GetModel().String(), request.GetTargetLanguage().String()),
+		[]byte(translatedMarkdown),
+	)
+
+	response := &pb.TranslateToMarkdownResponse{
+		Markdown:           translatedMarkdown,
+		SourceLanguage:     sourceLanguage,
+		GlossaryViolations: glossaryViolations,
+	}
+	if isDocumentMimeType(mimeType) {
+		response.PageMarkdowns = pageMarkdowns
+	}
+	return response, nil
+}
+
+// Returns the translated Markdown of the page, along with the source language of the page
+// and the glossary terms that the translation does not follow.
+func (s *server) translatePageToMarkdown(ctx context.Context, page pageSegment, model pb.Model, sourceLanguage pb.Language, targetLanguage pb.Language, terms *pb.Glossary) (string, pb.Language, []*pb.GlossaryViolation, error) {
+	img, _, err := image.Decode(bytes.NewReader(page.byteImage))
+	if err != nil {
+		log.Printf("Failed to decode image: %v", err)
+		return "", pb.Language_LANGUAGE_UNSPECIFIED, nil, status.Errorf(codes.InvalidArgument, codes.InvalidArgument.String())
+	}
+	spec := &imageSpec{
+		width:     img.Bounds().Dx(),
+		height:    img.Bounds().Dy(),
+		uriImage:  "data:" + page.mimeType + ";base64," + base64.StdEncoding.EncodeToString(page.byteImage),
+		byteImage: page.byteImage,
+	}
+
 	ocrText, err := s.vision.DetectDocumentText(ctx, &visionpb.Image{Content: spec.byteImage}, nil)
 	if err != nil {
 		log.Printf("failed to detect text from the image: %v", err)
-		return nil, status.Error(codes.Internal, codes.Internal.String())
+		return "", pb.Language_LANGUAGE_UNSPECIFIED, nil, status.Error(codes.Internal, codes.Internal.String())
 	}
 
 	wordSegments, err := textAnnotationToWordSegments(ocrText)
 	if err != nil {
 		log.Printf("failed to convert OCR response to text segments: %v", err)
-		return nil, status.Error(codes.Internal, codes.Internal.String())
+		return "", pb.Language_LANGUAGE_UNSPECIFIED, nil, status.Error(codes.Internal, codes.Internal.String())
 	}
 
 	// Example of textWithPosition with aligned positions by inserting spaces:
 	// Monday  Tuesday  Wednesday  Thursday  Friday
 	// A       B        C          D         E
-	alignedText, err := s.alignWithSpaces(spec, toParagraphs(wordSegments))
+	paragraphs := toParagraphs(wordSegments)
+	sourceLanguage = detectSourceLanguage(paragraphs, sourceLanguage)
+	alignedText, err := s.alignWithSpaces(spec, paragraphs)
 	if err != nil {
 		log.Printf("failed to align text: %v", err)
-		return nil, status.Error(codes.Internal, codes.Internal.String())
+		return "", pb.Language_LANGUAGE_UNSPECIFIED, nil, status.Error(codes.Internal, codes.Internal.String())
 	}
 
 	markdown, err := backoff.RetryWithData(func() (string, error) {
-		markdown, err := s.toMarkdown(ctx, alignedText, spec.uriImage, request.GetModel())
+		markdown, err := s.toMarkdown(ctx, alignedText, spec.uriImage, model)
 		if err != nil {
 			return "", err
 		}
@@ -78,25 +143,15 @@ func (s *server) TranslateToMarkdown(ctx context.Context, request *pb.TranslateT
 	}, backoff.WithMaxRetries(backoff.NewConstantBackOff(s.backoffDuration), 4))
 	if err != nil {
 		log.Printf("failed to convert text to markdown: %v", err)
-		return nil, status.Error(codes.Internal, codes.Internal.String())
+		return "", pb.Language_LANGUAGE_UNSPECIFIED, nil, status.Error(codes.Internal, codes.Internal.String())
 	}
 
-	translatedMarkdown, err := s.translateMarkdown(ctx, markdown, request.GetTargetLanguage())
+	translatedMarkdown, err := s.translateMarkdown(ctx, markdown, sourceLanguage, targetLanguage, terms)
 	if err != nil {
 		log.Printf("failed to translate markdown: %v", err)
-		return nil, status.Error(codes.Internal, codes.Internal.String())
+		return "", pb.Language_LANGUAGE_UNSPECIFIED, nil, status.Error(codes.Internal, codes.Internal.String())
 	}
-
-	s.storage.Client.SaveBytes(
-		ctx,
-		s.storage.ToMarkdownBucket,
-		fmt.Sprintf("image-%d-%s-%s-after.md", currentTimestamp, request.GetModel().String(), request.GetTargetLanguage().String()),
-		[]byte(translatedMarkdown),
-	)
-
-	return &pb.TranslateToMarkdownResponse{
-		Markdown: translatedMarkdown,
-	}, nil
+	return translatedMarkdown, sourceLanguage, glossary.Check(terms, markdown, translatedMarkdown), nil
 }
 
 func (s *server) alignWithSpaces(imageSpec *imageSpec, paragraphSegments []paragraphSegment) (string, error) {
@@ -267,13 +322,20 @@ Please make sure the results are in Markdown format.`,
 	return markdown, nil
 }
 
-func (s *server) translateMarkdown(ctx context.Context, markdown string, targetLanguage pb.Language) (string, error) {
+// The source language is given to the model when it is known, so that texts shared between languages,
+// such as kanji and hanja, are read in the right language. Unspecified when it could not be detected either.
+func (s *server) translateMarkdown(ctx context.Context, markdown string, sourceLanguage pb.Language, targetLanguage pb.Language, terms *pb.Glossary) (string, error) {
+	instructions := `The user will provide you with a markdown document.`
+	if sourceLanguage != pb.Language_LANGUAGE_UNSPECIFIED {
+		instructions += ` The markdown document is written in ` + targetLanguageName(sourceLanguage) + `.`
+	}
+	instructions += ` Please translate the markdown document into ` + targetLanguageName(targetLanguage) + ".\n" + glossary.Instructions(terms)
 	response, err := s.translationClient.ChatCompletion(ctx, openai.ChatCompletionRequest{
 		Model: openai.GPT4,
 		Messages: []openai.ChatCompletionMessage{
 			{
 				Role:    openai.ChatMessageRoleSystem,
-				Content: `The user will provide you with a markdown document. Please translate the markdown document into ` + targetLanguageName(targetLanguage),
+				Content: instructions,
 			},
 			{
 				Role:    openai.ChatMessageRoleUser,
@@ -295,6 +357,22 @@ func targetLanguageName(language pb.Language) string {
 		return "Korean (South Korea) (ko-KR)"
 	case pb.Language_LANGUAGE_JA_JP:
 		return "Japanese (Japan) (ja-JP)"
+	case pb.Language_LANGUAGE_ZH_CN:
+		return "Simplified Chinese (China) (zh-CN)"
+	case pb.Language_LANGUAGE_ZH_TW:
+		return "Traditional Chinese (Taiwan) (zh-TW)"
+	case pb.Language_LANGUAGE_TH_TH:
+		return "Thai (Thailand) (th-TH)"
+	case pb.Language_LANGUAGE_VI_VN:
+		return "Vietnamese (Vietnam) (vi-VN)"
+	case pb.Language_LANGUAGE_ID_ID:
+		return "Indonesian (Indonesia) (id-ID)"
+	case pb.Language_LANGUAGE_ES_ES:
+		return "Spanish (Spain) (es-ES)"
+	case pb.Language_LANGUAGE_FR_FR:
+		return "French (France) (fr-FR)"
+	case pb.Language_LANGUAGE_DE_DE:
+		return "German (Germany) (de-DE)"
 	default:
 		return "American English (United States) (en-US)"
 	}
@@ -0,0 +1,461 @@
+package impl
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/visionex-project/visionex/grpc"
+	auth "github.com/visionex-project/visionex/grpc/auth"
+	"github.com/visionex-project/visionex/grpc/impl/glossary"
+	"github.com/visionex-project/visionex/grpc/impl/job"
+	"github.com/visionex-project/visionex/pkg/utils"
+)
+
+const (
+	// The maximum number of images in one job.
+	// Jobs are not bound by request timeouts, so they allow far more images than a batch request.
+	MAX_JOB_IMAGE_COUNT = 200
+	// The maximum number of jobs waiting for a worker. Submissions beyond this are rejected.
+	MAX_QUEUED_JOBS = 100
+	// The number of jobs running at the same time.
+	// Each job translates BATCH_CONCURRENCY images at once, so this is kept small to avoid rate limits.
+	JOB_WORKER_COUNT = 2
+	// The number of jobs returned by ListTranslationJobs when the request does not specify a page size.
+	DEFAULT_JOB_PAGE_SIZE = 50
+	// The maximum number of jobs returned by ListTranslationJobs at once.
+	MAX_JOB_PAGE_SIZE = 200
+	// The number of images whose responses are returned by GetTranslationJob when the request does not specify a page size.
+	DEFAULT_JOB_RESULT_PAGE_SIZE = 5
+	// The maximum number of images whose responses are returned by GetTranslationJob at once.
+	MAX_JOB_RESULT_PAGE_SIZE = 20
+	// No more responses are added to a page of GetTranslationJob beyond this many bytes,
+	// which leaves room for the rest of the job under the 4MB message size limit that gRPC clients default to.
+	MAX_JOB_RESULT_PAGE_BYTES = 3 * 1024 * 1024
+	// How often the jobs that finished longer ago than the retention are deleted.
+	JOB_CLEANUP_INTERVAL = time.Hour
+)
+
+// Requeues the jobs that were interrupted by a restart, and starts the workers that run the queued jobs
+// along with the cleanup that deletes the jobs finished longer ago than the retention. Zero retention keeps every job.
+// The workers stop when the context is done.
+func (s *server) StartJobWorkers(ctx context.Context, retention time.Duration) error {
+	unfinishedJobs, err := s.jobStore.ListUnfinished(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for _, translationJob := range unfinishedJobs {
+		// The results of a running job are discarded, as the images being translated at the restart are lost.
+		resetJobProgress(translationJob)
+		translationJob.State = pb.JobState_JOB_STATE_QUEUED
+		translationJob.UpdateTimeMilliseconds = time.Now().UnixMilli()
+		if err := s.jobStore.Update(ctx, translationJob); err != nil {
+			return fmt.Errorf("failed to requeue job %s: %w", translationJob.GetId(), err)
+		}
+	}
+
+	for i := 0; i < JOB_WORKER_COUNT; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case id := <-s.jobQueue:
+					s.runJob(ctx, id)
+				}
+			}
+		}()
+	}
+	// Sent after the workers start, because there may be more unfinished jobs than the queue holds.
+	// The jobs are listed from newest to oldest, so the oldest job is queued first.
+	go func() {
+		for i := len(unfinishedJobs) - 1; i >= 0; i-- {
+			s.jobQueue <- unfinishedJobs[i].GetId()
+		}
+	}()
+	if retention > 0 {
+		go s.deleteExpiredJobs(ctx, retention)
+	}
+	return nil
+}
+
+// Deletes the jobs finished longer ago than the retention, at start and every JOB_CLEANUP_INTERVAL until the context is done.
+func (s *server) deleteExpiredJobs(ctx context.Context, retention time.Duration) {
+	ticker := time.NewTicker(JOB_CLEANUP_INTERVAL)
+	defer ticker.Stop()
+	for {
+		deletedCount, err := s.jobStore.DeleteFinishedBefore(ctx, time.Now().Add(-retention))
+		if err != nil {
+			log.Printf("Failed to delete expired jobs: %v", err)
+		} else if deletedCount > 0 {
+			log.Printf("Deleted %d expired jobs", deletedCount)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *server) SubmitTranslationJob(ctx context.Context, request *pb.SubmitTranslationJobRequest) (*pb.TranslationJob, error) {
+	translationJob := &pb.TranslationJob{
+		OwnerEmail:             auth.EmailFromContext(ctx),
+		State:                  pb.JobState_JOB_STATE_QUEUED,
+		CreateTimeMilliseconds: time.Now().UnixMilli(),
+		UpdateTimeMilliseconds: time.Now().UnixMilli(),
+	}
+	var imageCount int
+	var terms *pb.Glossary
+	switch batchRequest := request.GetRequest().(type) {
+	case *pb.SubmitTranslationJobRequest_ToImage:
+		imageCount = len(batchRequest.ToImage.GetImages())
+		terms = batchRequest.ToImage.GetGlossary()
+		translationJob.Result = &pb.TranslationJob_ToImageResult{ToImageResult: &pb.TranslateToImageBatchResponse{}}
+	case *pb.SubmitTranslationJobRequest_ToMarkdown:
+		imageCount = len(batchRequest.ToMarkdown.GetImages())
+		terms = batchRequest.ToMarkdown.GetGlossary()
+		translationJob.Result = &pb.TranslationJob_ToMarkdownResult{ToMarkdownResult: &pb.TranslateToMarkdownBatchResponse{}}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "either to_image or to_markdown is required")
+	}
+	if imageCount == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one image is required")
+	}
+	if imageCount > MAX_JOB_IMAGE_COUNT {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d images are allowed", MAX_JOB_IMAGE_COUNT)
+	}
+	if err := glossary.Validate(terms); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	id, err := newJobId()
+	if err != nil {
+		log.Printf("Failed to create job ID: %v", err)
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+	translationJob.Id = id
+	translationJob.TotalImageCount = int32(imageCount)
+	resetJobProgress(translationJob)
+
+	if err := s.jobStore.Create(ctx, translationJob, request); err != nil {
+		log.Printf("Failed to create job: %v", err)
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+
+	select {
+	case s.jobQueue <- id:
+		return translationJob, nil
+	default:
+		_, err := s.updateJob(ctx, id, func(translationJob *pb.TranslationJob) {
+			translationJob.State = pb.JobState_JOB_STATE_FAILED
+			translationJob.Error = &pb.BatchStatus{Code: int32(codes.ResourceExhausted), Message: "too many queued jobs"}
+		})
+		if err != nil {
+			log.Printf("Failed to fail job %s: %v", id, err)
+		}
+		return nil, status.Error(codes.ResourceExhausted, "too many queued jobs, try again later")
+	}
+}
+
+func (s *server) GetTranslationJob(ctx context.Context, request *pb.GetTranslationJobRequest) (*pb.TranslationJob, error) {
+	translationJob, err := s.jobStore.Get(ctx, request.GetId())
+	if errors.Is(err, job.ErrNotFound) || (err == nil && !isJobOwner(ctx, translationJob)) {
+		return nil, status.Errorf(codes.NotFound, "job %s not found", request.GetId())
+	}
+	if err != nil {
+		log.Printf("Failed to get job: %v", err)
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+
+	pageSize := int(request.GetResultPageSize())
+	if pageSize <= 0 {
+		pageSize = DEFAULT_JOB_RESULT_PAGE_SIZE
+	}
+	pageSize = min(pageSize, MAX_JOB_RESULT_PAGE_SIZE)
+	// The page token is the index of the first image of the page.
+	start := 0
+	if request.GetResultPageToken() != "" {
+		start, err = strconv.Atoi(request.GetResultPageToken())
+		if err != nil || start < 0 || start >= int(translationJob.GetTotalImageCount()) {
+			return nil, status.Error(codes.InvalidArgument, "invalid result page token")
+		}
+	}
+
+	end, err := s.loadJobResults(ctx, translationJob, start, pageSize)
+	if err != nil {
+		log.Printf("Failed to get results of job %s: %v", translationJob.GetId(), err)
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+	if end < int(translationJob.GetTotalImageCount()) {
+		translationJob.NextResultPageToken = strconv.Itoa(end)
+	}
+	return translationJob, nil
+}
+
+func (s *server) ListTranslationJobs(ctx context.Context, request *pb.ListTranslationJobsRequest) (*pb.ListTranslationJobsResponse, error) {
+	pageSize := int(request.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = DEFAULT_JOB_PAGE_SIZE
+	}
+	pageSize = min(pageSize, MAX_JOB_PAGE_SIZE)
+
+	// The page token is the ID of the last job of the previous page.
+	jobs, nextPageToken, err := s.jobStore.List(ctx, auth.EmailFromContext(ctx), request.GetPageToken(), pageSize)
+	if errors.Is(err, job.ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	if err != nil {
+		log.Printf("Failed to list jobs: %v", err)
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+
+	return &pb.ListTranslationJobsResponse{
+		Jobs: utils.Map(jobs, func(translationJob *pb.TranslationJob) *pb.TranslationJob {
+			translationJob.Result = nil
+			return translationJob
+		}),
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (s *server) CancelTranslationJob(ctx context.Context, request *pb.CancelTranslationJobRequest) (*pb.TranslationJob, error) {
+	var finishedState pb.JobState
+	var ownedByOthers bool
+	translationJob, err := s.updateJob(ctx, request.GetId(), func(translationJob *pb.TranslationJob) {
+		if !isJobOwner(ctx, translationJob) {
+			ownedByOthers = true
+			return
+		}
+		if job.IsFinished(translationJob.GetState()) {
+			finishedState = translationJob.GetState()
+			return
+		}
+		translationJob.State = pb.JobState_JOB_STATE_CANCELLED
+		// A queued job is skipped by the worker, and a running job stops before its next image.
+		if cancel, ok := s.jobCancels[translationJob.GetId()]; ok {
+			cancel()
+		}
+	})
+	if errors.Is(err, job.ErrNotFound) || ownedByOthers {
+		return nil, status.Errorf(codes.NotFound, "job %s not found", request.GetId())
+	}
+	if err != nil {
+		log.Printf("Failed to cancel job: %v", err)
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+	if finishedState != pb.JobState_JOB_STATE_UNSPECIFIED {
+		return nil, status.Errorf(codes.FailedPrecondition, "job has already finished as %s", finishedState.String())
+	}
+	return translationJob, nil
+}
+
+func (s *server) runJob(ctx context.Context, id string) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var skipped bool
+	_, err := s.updateJob(ctx, id, func(translationJob *pb.TranslationJob) {
+		if translationJob.GetState() != pb.JobState_JOB_STATE_QUEUED {
+			skipped = true
+			return
+		}
+		translationJob.State = pb.JobState_JOB_STATE_RUNNING
+		s.jobCancels[id] = cancel
+	})
+	if err != nil {
+		log.Printf("Failed to start job %s: %v", id, err)
+		return
+	}
+	if skipped {
+		return
+	}
+	defer func() {
+		s.jobMutex.Lock()
+		delete(s.jobCancels, id)
+		s.jobMutex.Unlock()
+	}()
+
+	request, err := s.jobStore.GetRequest(ctx, id)
+	if err != nil {
+		log.Printf("Failed to get request of job %s: %v", id, err)
+		s.finishJob(ctx, id, &pb.BatchStatus{Code: int32(codes.Internal), Message: codes.Internal.String()})
+		return
+	}
+
+	switch batchRequest := request.GetRequest().(type) {
+	case *pb.SubmitTranslationJobRequest_ToImage:
+		runBatch(len(batchRequest.ToImage.GetImages()), func(i int) {
+			if jobCtx.Err() != nil {
+				return
+			}
+			response, err := s.translateToImage(jobCtx, toImageRequest(batchRequest.ToImage, i), nil)
+			if err != nil {
+				log.Printf("Failed to translate image %d in job %s: %v", i, id, err)
+			}
+			s.saveJobResult(ctx, id, i, &pb.TranslateToImageBatchResult{
+				Status:   toBatchStatus(err),
+				Response: response,
+			})
+		})
+	case *pb.SubmitTranslationJobRequest_ToMarkdown:
+		runBatch(len(batchRequest.ToMarkdown.GetImages()), func(i int) {
+			if jobCtx.Err() != nil {
+				return
+			}
+			response, err := s.TranslateToMarkdown(jobCtx, toMarkdownRequest(batchRequest.ToMarkdown, i))
+			if err != nil {
+				log.Printf("Failed to translate image %d in job %s: %v", i, id, err)
+			}
+			s.saveJobResult(ctx, id, i, &pb.TranslateToMarkdownBatchResult{
+				Status:   toBatchStatus(err),
+				Response: response,
+			})
+		})
+	}
+	s.finishJob(ctx, id, nil)
+}
+
+// The result of a single image, either a TranslateToImageBatchResult or a TranslateToMarkdownBatchResult.
+type jobResult interface {
+	proto.Message
+	GetStatus() *pb.BatchStatus
+}
+
+// Saves the result of a single image apart from the job, and counts it as completed.
+// Only the status is kept in the job, so that updating and listing jobs never reads or writes the translated images.
+func (s *server) saveJobResult(ctx context.Context, id string, index int, result jobResult) {
+	resultStatus := result.GetStatus()
+	if err := s.jobStore.SaveResult(ctx, id, index, result); err != nil {
+		log.Printf("Failed to save result %d of job %s: %v", index, id, err)
+		resultStatus = &pb.BatchStatus{Code: int32(codes.Internal), Message: codes.Internal.String()}
+	}
+	_, err := s.updateJob(ctx, id, func(translationJob *pb.TranslationJob) {
+		switch results := translationJob.GetResult().(type) {
+		case *pb.TranslationJob_ToImageResult:
+			results.ToImageResult.GetResults()[index] = &pb.TranslateToImageBatchResult{Status: resultStatus}
+		case *pb.TranslationJob_ToMarkdownResult:
+			results.ToMarkdownResult.GetResults()[index] = &pb.TranslateToMarkdownBatchResult{Status: resultStatus}
+		}
+		translationJob.CompletedImageCount++
+	})
+	if err != nil {
+		log.Printf("Failed to save result of job %s: %v", id, err)
+	}
+}
+
+// Fills the responses of the succeeded images of the page that starts at the index of start,
+// from the results saved apart from the job. Returns the index of the first image after the page.
+func (s *server) loadJobResults(ctx context.Context, translationJob *pb.TranslationJob, start int, pageSize int) (int, error) {
+	switch results := translationJob.GetResult().(type) {
+	case *pb.TranslationJob_ToImageResult:
+		return loadJobResultPage(ctx, s.jobStore, translationJob.GetId(), results.ToImageResult.GetResults(), start, pageSize)
+	case *pb.TranslationJob_ToMarkdownResult:
+		return loadJobResultPage(ctx, s.jobStore, translationJob.GetId(), results.ToMarkdownResult.GetResults(), start, pageSize)
+	}
+	return start, nil
+}
+
+// The page ends early when its responses would exceed MAX_JOB_RESULT_PAGE_BYTES, but never before its first image,
+// so that a single large response can still be fetched.
+func loadJobResultPage[T jobResult](ctx context.Context, jobStore job.Store, id string, results []T, start int, pageSize int) (int, error) {
+	pageBytes := 0
+	end := min(start+pageSize, len(results))
+	for i := start; i < end; i++ {
+		if results[i].GetStatus() == nil || results[i].GetStatus().GetCode() != int32(codes.OK) {
+			continue
+		}
+		result := results[i].ProtoReflect().New().Interface().(T)
+		if err := jobStore.GetResult(ctx, id, i, result); err != nil {
+			return 0, fmt.Errorf("failed to get result %d: %w", i, err)
+		}
+		resultBytes := proto.Size(result)
+		if i > start && pageBytes+resultBytes > MAX_JOB_RESULT_PAGE_BYTES {
+			return i, nil
+		}
+		pageBytes += resultBytes
+		results[i] = result
+	}
+	return end, nil
+}
+
+// Returns whether the job was submitted by the caller.
+// Jobs of other users are reported as not found, so that their IDs cannot be told apart from unknown ones.
+func isJobOwner(ctx context.Context, translationJob *pb.TranslationJob) bool {
+	return translationJob.GetOwnerEmail() == auth.EmailFromContext(ctx)
+}
+
+// Marks the job as succeeded, or as failed when an error is given.
+// A job cancelled in the meantime stays cancelled.
+func (s *server) finishJob(ctx context.Context, id string, jobError *pb.BatchStatus) {
+	_, err := s.updateJob(ctx, id, func(translationJob *pb.TranslationJob) {
+		if translationJob.GetState() != pb.JobState_JOB_STATE_RUNNING {
+			return
+		}
+		translationJob.State = pb.JobState_JOB_STATE_SUCCEEDED
+		if jobError != nil {
+			translationJob.State = pb.JobState_JOB_STATE_FAILED
+			translationJob.Error = jobError
+		}
+	})
+	if err != nil {
+		log.Printf("Failed to finish job %s: %v", id, err)
+	}
+}
+
+// Reads, modifies and writes back the job while holding jobMutex,
+// so that the workers and CancelTranslationJob do not overwrite each other.
+func (s *server) updateJob(ctx context.Context, id string, update func(translationJob *pb.TranslationJob)) (*pb.TranslationJob, error) {
+	s.jobMutex.Lock()
+	defer s.jobMutex.Unlock()
+
+	translationJob, err := s.jobStore.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	original := proto.Clone(translationJob)
+	update(translationJob)
+	if proto.Equal(original, translationJob) {
+		return translationJob, nil
+	}
+	translationJob.UpdateTimeMilliseconds = time.Now().UnixMilli()
+	if err := s.jobStore.Update(ctx, translationJob); err != nil {
+		return nil, err
+	}
+	return translationJob, nil
+}
+
+// Fills the result with one empty entry per image, so that results can be saved by index in any order.
+func resetJobProgress(translationJob *pb.TranslationJob) {
+	translationJob.CompletedImageCount = 0
+	switch result := translationJob.GetResult().(type) {
+	case *pb.TranslationJob_ToImageResult:
+		result.ToImageResult.Results = make([]*pb.TranslateToImageBatchResult, translationJob.GetTotalImageCount())
+		for i := range result.ToImageResult.Results {
+			result.ToImageResult.Results[i] = &pb.TranslateToImageBatchResult{}
+		}
+	case *pb.TranslationJob_ToMarkdownResult:
+		result.ToMarkdownResult.Results = make([]*pb.TranslateToMarkdownBatchResult, translationJob.GetTotalImageCount())
+		for i := range result.ToMarkdownResult.Results {
+			result.ToMarkdownResult.Results[i] = &pb.TranslateToMarkdownBatchResult{}
+		}
+	}
+}
+
+// E.g., "3f2b9c0e8d7a6b5c4f3e2d1c0b9a8f7e"
+func newJobId() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
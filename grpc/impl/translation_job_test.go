@@ -0,0 +1,142 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/visionex-project/visionex/grpc"
+	auth "github.com/visionex-project/visionex/grpc/auth"
+	"github.com/visionex-project/visionex/grpc/impl/job"
+)
+
+// Returns a server with a finished job of 5 Markdown translations, whose image at the index 3 failed.
+func jobTestServer(t *testing.T) (*server, string) {
+	t.Helper()
+	ctx := context.Background()
+	jobStore, err := job.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create the job store: %v", err)
+	}
+	translationJob := &pb.TranslationJob{
+		Id:              "a1",
+		OwnerEmail:      "user@yanolja.com",
+		State:           pb.JobState_JOB_STATE_SUCCEEDED,
+		TotalImageCount: 5,
+		Result:          &pb.TranslationJob_ToMarkdownResult{ToMarkdownResult: &pb.TranslateToMarkdownBatchResponse{}},
+	}
+	resetJobProgress(translationJob)
+	if err := jobStore.Create(ctx, translationJob, &pb.SubmitTranslationJobRequest{}); err != nil {
+		t.Fatalf("failed to create the job: %v", err)
+	}
+
+	s := &server{jobStore: jobStore}
+	for i := 0; i < 5; i++ {
+		result := &pb.TranslateToMarkdownBatchResult{
+			Status:   &pb.BatchStatus{Code: int32(codes.OK)},
+			Response: &pb.TranslateToMarkdownResponse{Markdown: fmt.Sprintf("# Page %d", i)},
+		}
+		if i == 3 {
+			result = &pb.TranslateToMarkdownBatchResult{Status: &pb.BatchStatus{Code: int32(codes.Internal)}}
+		}
+		s.saveJobResult(ctx, "a1", i, result)
+	}
+	return s, "a1"
+}
+
+func TestGetTranslationJobOwnerScoping(t *testing.T) {
+	s, id := jobTestServer(t)
+	tests := []struct {
+		name     string
+		email    string
+		jobId    string
+		wantCode codes.Code
+	}{
+		{name: "owner", email: "user@yanolja.com", jobId: id, wantCode: codes.OK},
+		// Emails are compared case insensitively, as auth.WithEmail lowercases them.
+		{name: "owner in upper case", email: "USER@yanolja.com", jobId: id, wantCode: codes.OK},
+		{name: "other user", email: "other@yanolja.com", jobId: id, wantCode: codes.NotFound},
+		{name: "anonymous", email: "", jobId: id, wantCode: codes.NotFound},
+		{name: "unknown job", email: "user@yanolja.com", jobId: "unknown", wantCode: codes.NotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.GetTranslationJob(auth.WithEmail(context.Background(), test.email), &pb.GetTranslationJobRequest{Id: test.jobId})
+			if got := status.Code(err); got != test.wantCode {
+				t.Errorf("got %v, want %v", got, test.wantCode)
+			}
+		})
+	}
+}
+
+func TestGetTranslationJobResultPaging(t *testing.T) {
+	s, id := jobTestServer(t)
+	ctx := auth.WithEmail(context.Background(), "user@yanolja.com")
+	tests := []struct {
+		name      string
+		pageSize  int32
+		pageToken string
+		// The images whose responses are filled in.
+		wantMarkdowns     map[int]string
+		wantNextPageToken string
+	}{
+		{
+			name:              "first page",
+			pageSize:          2,
+			wantMarkdowns:     map[int]string{0: "# Page 0", 1: "# Page 1"},
+			wantNextPageToken: "2",
+		},
+		{
+			// The failed image at the index 3 has no response to fill in.
+			name:              "middle page",
+			pageSize:          2,
+			pageToken:         "2",
+			wantMarkdowns:     map[int]string{2: "# Page 2"},
+			wantNextPageToken: "4",
+		},
+		{name: "last page", pageSize: 2, pageToken: "4", wantMarkdowns: map[int]string{4: "# Page 4"}},
+		{
+			name:          "default page size",
+			wantMarkdowns: map[int]string{0: "# Page 0", 1: "# Page 1", 2: "# Page 2", 4: "# Page 4"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			translationJob, err := s.GetTranslationJob(ctx, &pb.GetTranslationJobRequest{
+				Id:              id,
+				ResultPageSize:  test.pageSize,
+				ResultPageToken: test.pageToken,
+			})
+			if err != nil {
+				t.Fatalf("failed to get the job: %v", err)
+			}
+			results := translationJob.GetToMarkdownResult().GetResults()
+			if len(results) != 5 {
+				t.Fatalf("got %d results, want one per image", len(results))
+			}
+			for i, result := range results {
+				if result.GetStatus() == nil {
+					t.Errorf("got no status for the image %d, want the status of every image", i)
+				}
+				if got := result.GetResponse().GetMarkdown(); got != test.wantMarkdowns[i] {
+					t.Errorf("got %q for the image %d, want %q", got, i, test.wantMarkdowns[i])
+				}
+			}
+			if got := translationJob.GetNextResultPageToken(); got != test.wantNextPageToken {
+				t.Errorf("got the next page token %q, want %q", got, test.wantNextPageToken)
+			}
+		})
+	}
+
+	for _, pageToken := range []string{"5", "-1", "two"} {
+		_, err := s.GetTranslationJob(ctx, &pb.GetTranslationJobRequest{Id: id, ResultPageToken: pageToken})
+		if got := status.Code(err); got != codes.InvalidArgument {
+			t.Errorf("got %v for the page token %q, want %v", got, pageToken, codes.InvalidArgument)
+		}
+	}
+}
@@ -1,6 +1,35 @@
 package impl
 
-import "github.com/lucasb-eyer/go-colorful"
+import (
+	"image"
+
+	"github.com/lucasb-eyer/go-colorful"
+
+	"github.com/visionex-project/visionex/grpc/impl/font"
+)
+
+// A single page of the input. Raster images always have one page,
+// while PDF and multi-page TIFF documents have one page per document page.
+type pageSegment struct {
+	// The page image. For documents, this is the page rendered by Document AI.
+	byteImage []byte
+	// The MIME type of the page image. E.g., "image/png"
+	mimeType   string
+	paragraphs []paragraphSegment
+	// The boxes of every word of the page, including the lines left out of the translation
+	// because they are already written in the target language.
+	textBoxes []position
+}
+
+// A page with the translated texts, along with what it is drawn from, so that the texts can also be output as text.
+type translatedPage struct {
+	// The translated texts drawn on the background.
+	image image.Image
+	// The original image with the texts removed.
+	background image.Image
+	// The translated words where they are drawn.
+	words []wordSegment
+}
 
 type paragraphSegment struct {
 	lines []lineSegment
@@ -8,8 +37,23 @@ type paragraphSegment struct {
 
 type lineSegment struct {
 	words []wordSegment
+	// The alignment of the rows of the line within its paragraph. Left aligned when empty.
+	alignment textAlignment
+	// Set while drawing when the line is rotated or skewed, after its words are laid out upright.
+	rotation *textRotation
 }
 
+// The horizontal alignment of the rows of a paragraph.
+type textAlignment string
+
+const (
+	ALIGNMENT_LEFT   textAlignment = "left"
+	ALIGNMENT_CENTER textAlignment = "center"
+	ALIGNMENT_RIGHT  textAlignment = "right"
+	// Every row but the last one spans the whole width.
+	ALIGNMENT_JUSTIFIED textAlignment = "justified"
+)
+
 // Contains detailed information about each text.
 type wordSegment struct {
 	// Text. E.g., "Hello"
@@ -20,6 +64,31 @@ type wordSegment struct {
 	fontSize *float64
 	// The style information of the text. E.g., {textColor: (0.1, 0, 0.5), height: 100, weight: 1}
 	style *style
+	// Whether the text is a single character of vertical text, which is drawn centered in its position.
+	vertical bool
+	// The corners of the text, clockwise from the top left corner in reading order.
+	// Unlike the position, they follow rotated and skewed text. Nil when unknown.
+	// E.g., [{10, 20}, {110, 40}, {106, 60}, {6, 40}]
+	quad []point
+	// How the text is rotated and skewed when drawn. Nil for upright text.
+	rotation *textRotation
+}
+
+// A point in pixels. E.g., {x: 10, y: 20}
+type point struct {
+	x float64
+	y float64
+}
+
+// Transforms upright text into the rotated and skewed text of the image.
+// The text is skewed first and then rotated, both around the center.
+type textRotation struct {
+	// Clockwise in radians. E.g., 0.2
+	angle float64
+	// How far the text shifts to the right per pixel downwards. E.g., -0.2 for text that leans to the right.
+	shear   float64
+	centerX float64
+	centerY float64
 }
 
 // Represents the bounding box coordinates (top, left, bottom, right)
@@ -43,6 +112,46 @@ type style struct {
 	weight int
 	// Numeric font weight from Document AI. E.g., 450
 	fontWeight int
+	// The font face closest to the original text. Sans serif when empty. E.g., "Serif"
+	fontFace font.FontFace
+	// The color behind the text from Document AI, if any. E.g., (1.0, 0.9, 0.0)
+	backgroundColor *colorful.Color
+	// Outline, shadow and highlight band detected from the original image. Nil when the text is flat.
+	effects *textEffects
+	// Drawn with the italic fonts when they are installed, and slanted otherwise.
+	italic bool
+	// E.g., calls to action such as "Buy now"
+	underlined bool
+	// E.g., the original price of a discounted item
+	strikeout bool
+}
+
+// Typographic effects that make text readable on busy backgrounds. Each effect is nil when absent.
+// Sizes are relative to the font size, so that they scale with the font of the translated text.
+type textEffects struct {
+	outline   *outlineEffect
+	shadow    *shadowEffect
+	highlight *highlightEffect
+}
+
+type outlineEffect struct {
+	color colorful.Color
+	// The outline width divided by the font size. E.g., 0.05
+	widthRatio float64
+}
+
+type shadowEffect struct {
+	color colorful.Color
+	// The shadow offset divided by the font size. E.g., (0.04, 0.04) for a shadow to the lower right.
+	offsetXRatio float64
+	offsetYRatio float64
+}
+
+// A band of color behind the text, like a highlighter.
+type highlightEffect struct {
+	color colorful.Color
+	// How far the band extends beyond the text, divided by the font size. E.g., 0.2
+	paddingRatio float64
 }
 
 // Represents the image specifications for the combined image.
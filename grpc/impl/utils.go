@@ -3,21 +3,90 @@ package impl
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"image"
 	_ "image/gif"
 	_ "image/jpeg"
 	"image/png"
 	"log"
 	"math"
+	"net/http"
 	"sort"
+	"strings"
 	"unicode"
 
 	"cloud.google.com/go/vision/v2/apiv1/visionpb"
-	"github.com/visionex-project/visionex/pkg/utils"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	pb "github.com/visionex-project/visionex/grpc"
+	"github.com/visionex-project/visionex/grpc/impl/language"
+	"github.com/visionex-project/visionex/pkg/utils"
+)
+
+const (
+	MIME_TYPE_PDF  = "application/pdf"
+	MIME_TYPE_TIFF = "image/tiff"
+	// Output formats with the translated texts as text.
+	MIME_TYPE_SVG  = "image/svg+xml"
+	MIME_TYPE_HTML = "text/html"
 )
 
+// Returns the MIME type of the input, which is either a raster image or a document.
+// Raster images are also decoded to reject corrupted input early.
+func detectMimeType(content []byte) (string, error) {
+	// http.DetectContentType does not sniff TIFF, so the byte order mark is checked manually.
+	// Ref: https://www.itu.int/itudoc/itu-t/com16/tiff-fx/docs/tiff6.pdf
+	if bytes.HasPrefix(content, []byte("II*\x00")) || bytes.HasPrefix(content, []byte("MM\x00*")) {
+		return MIME_TYPE_TIFF, nil
+	}
+
+	mimeType := http.DetectContentType(content)
+	if mimeType == MIME_TYPE_PDF {
+		return mimeType, nil
+	}
+	if _, _, err := image.DecodeConfig(bytes.NewReader(content)); err != nil {
+		return "", fmt.Errorf("unsupported input type %s: %w", mimeType, err)
+	}
+	return mimeType, nil
+}
+
+// Documents may contain several pages, which are rendered into images by Document AI.
+func isDocumentMimeType(mimeType string) bool {
+	return mimeType == MIME_TYPE_PDF || mimeType == MIME_TYPE_TIFF
+}
+
+// Returns the file extension used when saving the input to the storage. E.g., "png"
+func fileExtension(mimeType string) string {
+	switch mimeType {
+	case MIME_TYPE_PDF:
+		return "pdf"
+	case MIME_TYPE_TIFF:
+		return "tiff"
+	case "image/jpeg":
+		return "jpg"
+	case "image/gif":
+		return "gif"
+	case "image/webp":
+		return "webp"
+	case MIME_TYPE_SVG:
+		return "svg"
+	case MIME_TYPE_HTML:
+		return "html"
+	default:
+		return "png"
+	}
+}
+
+// Returns the requested source language, or detects it from every text when it is unspecified.
+func detectSourceLanguage(paragraphs []paragraphSegment, requestedLanguage pb.Language) pb.Language {
+	if requestedLanguage != pb.Language_LANGUAGE_UNSPECIFIED {
+		return requestedLanguage
+	}
+	_, detectedLanguage := language.Detect(strings.Join(toTexts(paragraphs), "\n"), pb.Language_LANGUAGE_UNSPECIFIED)
+	return detectedLanguage
+}
+
 func textAnnotationToWordSegments(ocrResponse *visionpb.TextAnnotation) ([]wordSegment, error) {
 	blocks := utils.FlatMap(ocrResponse.GetPages(), func(page *visionpb.Page) []*visionpb.Block {
 		return page.GetBlocks()
@@ -48,6 +117,7 @@ func textAnnotationToWordSegments(ocrResponse *visionpb.TextAnnotation) ([]wordS
 				return text + symbol.GetText()
 			}, ""),
 			position: currentPosition,
+			quad:     toQuad(word.GetBoundingBox().GetVertices()),
 		}
 	}), nil
 }
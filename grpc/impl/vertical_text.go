@@ -0,0 +1,237 @@
+package impl
+
+import (
+	"math"
+	"slices"
+	"unicode"
+
+	"github.com/fogleman/gg"
+
+	pb "github.com/visionex-project/visionex/grpc"
+	"github.com/visionex-project/visionex/pkg/utils"
+)
+
+const (
+	// A column at least this many times taller than it is wide is considered vertical text.
+	// CJK glyphs are set in square em boxes, so two stacked glyphs already reach it.
+	VERTICAL_TEXT_ASPECT_RATIO = 2.0
+	// Small kana are moved by this fraction of the font size towards the upper right in vertical text.
+	SMALL_KANA_OFFSET = 0.1
+	// Commas and periods are moved by this fraction of the font size from the lower left to the upper right in vertical text.
+	VERTICAL_PUNCTUATION_OFFSET = 0.5
+)
+
+// Characters drawn rotated 90° clockwise in vertical text, because their shape follows the writing direction.
+// E.g., "ー" becomes a vertical bar and "「" becomes "﹁".
+var verticalRotatedChars = []rune("ー－―—–-~〜～…‥「」『』（）()［］[]｛｝{}〈〉《》【】〔〕＜＞<>＝=")
+
+// Characters that sit in the lower left of their box in horizontal text but in the upper right in vertical text.
+var verticalPunctuationChars = []rune("、。，．")
+
+// Ref: https://en.wikipedia.org/wiki/Sutegana
+var smallKanaChars = []rune("ぁぃぅぇぉっゃゅょゎゕゖァィゥェォッャュョヮヵヶ")
+
+// Vertical layout is only used for languages that are commonly written vertically.
+func supportsVerticalText(language pb.Language) bool {
+	return language == pb.Language_LANGUAGE_JA_JP
+}
+
+// Returns whether the line is written vertically, judging from the shape of its columns and the order of its words.
+// Words of vertical text run from top to bottom within a column, and columns run from right to left.
+func isVerticalLine(line lineSegment) bool {
+	positions := utils.Map(line.words, func(word wordSegment) position {
+		return word.position
+	})
+	if len(positions) == 0 {
+		return false
+	}
+
+	tallestColumn := slices.MaxFunc(combinedColumnPositions(positions), func(a position, b position) int {
+		return int((a.bottom - a.top) - (b.bottom - b.top))
+	})
+	columnHeight := float64(tallestColumn.bottom - tallestColumn.top)
+	if columnHeight < VERTICAL_TEXT_ASPECT_RATIO*float64(tallestColumn.right-tallestColumn.left) {
+		return false
+	}
+	// A tall box may also be a single horizontal character such as "I", so the height is also compared with the font size.
+	for _, word := range line.words {
+		if word.fontSize != nil && *word.fontSize > 0 && columnHeight < VERTICAL_TEXT_ASPECT_RATIO**word.fontSize {
+			return false
+		}
+	}
+
+	for i := 1; i < len(positions); i++ {
+		previous, current := positions[i-1], positions[i]
+		isBelowInSameColumn := current.top >= previous.top && current.left < previous.right && current.right > previous.left
+		isNextColumn := current.right <= previous.left
+		if !isBelowInSameColumn && !isNextColumn {
+			return false
+		}
+	}
+	return true
+}
+
+// Merges the positions that overlap horizontally into columns, keeping their order.
+// The vertical counterpart of combinedLinePositions.
+func combinedColumnPositions(positions []position) []position {
+	return utils.Reduce(positions, func(combined []position, currentPosition position) []position {
+		if len(combined) == 0 {
+			return []position{currentPosition}
+		}
+
+		lastPosition := combined[len(combined)-1]
+		middleOfWidth := (lastPosition.left + lastPosition.right) / 2
+		if currentPosition.left <= middleOfWidth && currentPosition.right >= middleOfWidth {
+			combined[len(combined)-1] = combinedPosition([]position{lastPosition, currentPosition})
+			return combined
+		}
+		return append(combined, currentPosition)
+	}, []position{})
+}
+
+// Lays out the characters of a vertical line one by one, from top to bottom within each column
+// and from the first column to the next, which is the one on the left in vertical text.
+// Every character gets its own segment, as vertical text is drawn character by character.
+// Returns false when the characters do not fit into the columns even at the smallest font size,
+// so that the line is laid out horizontally instead of losing characters.
+func repositionVerticalText(line lineSegment) ([]wordSegment, bool) {
+	columns := combinedColumnPositions(utils.Map(line.words, func(word wordSegment) position {
+		return word.position
+	}))
+
+	type character struct {
+		char rune
+		word wordSegment
+	}
+	// Spaces between the translated words are not used in vertical Japanese.
+	characters := utils.FlatMap(line.words, func(word wordSegment) []character {
+		return utils.Map(utils.Filter([]rune(word.text), func(char rune) bool {
+			return !unicode.IsSpace(char)
+		}), func(char rune) character {
+			return character{char: char, word: word}
+		})
+	})
+	if len(characters) == 0 {
+		return []wordSegment{}, true
+	}
+
+	originalFontSize := 0.0
+	for _, word := range line.words {
+		if word.fontSize != nil && *word.fontSize > 0 {
+			originalFontSize = *word.fontSize
+			break
+		}
+	}
+	fontSize, ok := fitVerticalFontSize(columns, len(characters), originalFontSize)
+	if !ok {
+		return nil, false
+	}
+
+	repositionedWords := []wordSegment{}
+	columnIndex := 0
+	top := float64(columns[0].top)
+	for _, character := range characters {
+		// Columns shorter than a character are skipped, as fitVerticalFontSize does not count them either.
+		for top+fontSize > float64(columns[columnIndex].bottom) {
+			columnIndex++
+			if columnIndex >= len(columns) {
+				return nil, false
+			}
+			top = float64(columns[columnIndex].top)
+		}
+		middleOfWidth := float64(columns[columnIndex].left+columns[columnIndex].right) / 2
+		repositionedWords = append(repositionedWords, wordSegment{
+			text: string(character.char),
+			position: position{
+				left:   int32(middleOfWidth - fontSize/2),
+				top:    int32(top),
+				right:  int32(middleOfWidth + fontSize/2),
+				bottom: int32(top + fontSize),
+			},
+			style:    character.word.style,
+			fontSize: &fontSize,
+			vertical: true,
+		})
+		top += fontSize
+	}
+	return repositionedWords, true
+}
+
+// Returns the largest font size (≤ original size) that fits every character into the columns,
+// or false when not even a font size of 1 fits.
+// Japanese characters are square, so each character takes the font size in height and width.
+func fitVerticalFontSize(columns []position, characterCount int, originalFontSize float64) (float64, bool) {
+	maxColumnWidth := 0.0
+	for _, column := range columns {
+		maxColumnWidth = math.Max(maxColumnWidth, float64(column.right-column.left))
+	}
+	if originalFontSize <= 0 {
+		originalFontSize = maxColumnWidth
+	}
+
+	fits := func(fontSize float64) bool {
+		capacity := 0
+		for _, column := range columns {
+			capacity += int(float64(column.bottom-column.top) / fontSize)
+		}
+		return capacity >= characterCount
+	}
+	if fits(originalFontSize) {
+		return originalFontSize, true
+	}
+
+	// Searches the whole sizes below the original size, which is known not to fit,
+	// keeping the largest size that is verified to fit.
+	fittingSize := 0.0
+	low, high := 1.0, math.Ceil(originalFontSize)-1
+	for low <= high {
+		mid := math.Floor((low + high) / 2)
+		if fits(mid) {
+			fittingSize = mid
+			low = mid + 1
+		} else {
+			high = mid - 1
+		}
+	}
+	return fittingSize, fittingSize > 0
+}
+
+// Draws a single character of vertical text centered in its box,
+// rotating or moving the characters whose shape differs in vertical text.
+func drawVerticalCharacter(drawingContext *gg.Context, word wordSegment) {
+	centerX, centerY, rotated := verticalCharacterCenter(word)
+	if rotated {
+		drawingContext.Push()
+		drawingContext.RotateAbout(gg.Radians(90), centerX, centerY)
+		drawingContext.DrawStringAnchored(word.text, centerX, centerY, 0.5, 0.3)
+		drawingContext.Pop()
+		return
+	}
+	drawingContext.DrawStringAnchored(
+		word.text,
+		centerX, /* =x */
+		centerY, /* =y */
+		0.5,     /* =ax (align center in x) */
+		0.3,     /* =ay (align almost center in y) */
+	)
+}
+
+// Returns where a single character of vertical text is centered, and whether it is rotated by 90 degrees around there.
+// Punctuation and small kana are moved towards the upper right, where they sit in vertical text.
+func verticalCharacterCenter(word wordSegment) (float64, float64, bool) {
+	char := []rune(word.text)[0]
+	centerX := float64(word.position.left+word.position.right) / 2
+	centerY := float64(word.position.top+word.position.bottom) / 2
+
+	switch {
+	case slices.Contains(verticalRotatedChars, char):
+		return centerX, centerY, true
+	case slices.Contains(verticalPunctuationChars, char):
+		centerX += *word.fontSize * VERTICAL_PUNCTUATION_OFFSET
+		centerY -= *word.fontSize * VERTICAL_PUNCTUATION_OFFSET
+	case slices.Contains(smallKanaChars, char):
+		centerX += *word.fontSize * SMALL_KANA_OFFSET
+		centerY -= *word.fontSize * SMALL_KANA_OFFSET
+	}
+	return centerX, centerY, false
+}
@@ -0,0 +1,63 @@
+package webp
+
+// The boolean entropy encoder of VP8 partitions, as specified in section 7.3 of RFC 6386.
+// Each bit is written with the probability out of 256 that it is 0, and takes fewer bits the better the guess.
+// Ref: https://datatracker.ietf.org/doc/html/rfc6386#section-7.3
+type boolEncoder struct {
+	output   []byte
+	rangeM   uint32
+	bottom   uint32
+	bitCount int
+}
+
+func newBoolEncoder() *boolEncoder {
+	return &boolEncoder{rangeM: 255, bitCount: 24}
+}
+
+func (e *boolEncoder) putBit(bit bool, prob uint8) {
+	split := 1 + (e.rangeM-1)*uint32(prob)>>8
+	if bit {
+		e.bottom += split
+		e.rangeM -= split
+	} else {
+		e.rangeM = split
+	}
+	for e.rangeM < 128 {
+		e.rangeM <<= 1
+		if e.bottom&(1<<31) != 0 {
+			e.carry()
+		}
+		e.bottom <<= 1
+		e.bitCount--
+		if e.bitCount == 0 {
+			e.output = append(e.output, byte(e.bottom>>24))
+			e.bottom &= 1<<24 - 1
+			e.bitCount = 8
+		}
+	}
+}
+
+// Adds one to the bytes already written.
+func (e *boolEncoder) carry() {
+	for i := len(e.output) - 1; i >= 0; i-- {
+		e.output[i]++
+		if e.output[i] != 0 {
+			return
+		}
+	}
+}
+
+// Writes the value as an unsigned integer of the given number of bits, the most significant bit first.
+func (e *boolEncoder) putLiteral(value int, bitCount int) {
+	for bit := bitCount - 1; bit >= 0; bit-- {
+		e.putBit(value>>bit&1 != 0, UNIFORM_PROB)
+	}
+}
+
+// Returns the encoded bytes. Zeros are written until every pending bit is out.
+func (e *boolEncoder) finish() []byte {
+	for i := 0; i < 32; i++ {
+		e.putBit(false, UNIFORM_PROB)
+	}
+	return e.output
+}
@@ -0,0 +1,121 @@
+package webp
+
+import (
+	"encoding/binary"
+	"image"
+	"image/draw"
+	"io"
+	"math"
+)
+
+const (
+	// The flag of the VP8X chunk that the image has an ALPH chunk.
+	ALPHA_FLAG = 0x10
+	// The ALPH chunk header of raw alpha values, without filtering, preprocessing or compression.
+	UNCOMPRESSED_ALPHA = 0
+)
+
+// Encodes the image as a lossy WEBP with the quality between 1 and 100, like JPEG.
+// The WEBP packages in pure Go only encode lossless WEBP, and the server is built without cgo for libwebp.
+// Any transparency is kept in an uncompressed alpha plane.
+func EncodeLossy(w io.Writer, img image.Image, quality int) error {
+	bounds := img.Bounds()
+	rgba := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+
+	frame, err := encodeFrame(toYUV(rgba), bounds.Dx(), bounds.Dy(), quantizerIndex(quality))
+	if err != nil {
+		return err
+	}
+	chunks := []byte{}
+	if alpha, isOpaque := alphaPlane(rgba); !isOpaque {
+		header := make([]byte, 10)
+		header[0] = ALPHA_FLAG
+		putUint24(header[4:], bounds.Dx()-1)
+		putUint24(header[7:], bounds.Dy()-1)
+		chunks = appendChunk(chunks, "VP8X", header)
+		chunks = appendChunk(chunks, "ALPH", append([]byte{UNCOMPRESSED_ALPHA}, alpha...))
+	}
+	chunks = appendChunk(chunks, "VP8 ", frame)
+
+	riff := append([]byte("RIFF"), 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(riff[4:], uint32(4+len(chunks)))
+	riff = append(riff, "WEBP"...)
+	_, err = w.Write(append(riff, chunks...))
+	return err
+}
+
+// Maps the quality to the quantizer index by the curve of libwebp, so that the sizes are about those of cwebp at the same quality.
+// Ref: https://chromium.googlesource.com/webm/libwebp/+/refs/heads/main/src/enc/quant_enc.c (QualityToCompression)
+func quantizerIndex(quality int) int {
+	c := float64(min(max(quality, 1), 100)) / 100
+	linear := 2*c - 1
+	if c < 0.75 {
+		linear = c * 2 / 3
+	}
+	return min(max(int(math.Round(127*(1-math.Cbrt(linear)))), 0), 127)
+}
+
+// Converts the image to limited range BT.601 YUV 4:2:0 with integer arithmetic as libwebp does,
+// repeating the right and bottom edges to whole macroblocks.
+// Ref: https://chromium.googlesource.com/webm/libwebp/+/refs/heads/main/src/dsp/yuv.h
+func toYUV(rgba *image.NRGBA) *yuvImage {
+	width, height := rgba.Bounds().Dx(), rgba.Bounds().Dy()
+	mbw, mbh := (width+15)/16, (height+15)/16
+	yuv := newYUVImage(mbw, mbh)
+	pixel := func(x int, y int) (int, int, int) {
+		offset := rgba.PixOffset(min(x, width-1), min(y, height-1))
+		return int(rgba.Pix[offset]), int(rgba.Pix[offset+1]), int(rgba.Pix[offset+2])
+	}
+	for y := 0; y < 16*mbh; y++ {
+		for x := 0; x < 16*mbw; x++ {
+			r, g, b := pixel(x, y)
+			yuv.y[y*yuv.yStride+x] = uint8((16839*r + 33059*g + 6420*b + 16<<16 + 1<<15) >> 16)
+		}
+	}
+	for y := 0; y < 8*mbh; y++ {
+		for x := 0; x < 8*mbw; x++ {
+			// The sums of the 2x2 pixels, which are 4 times the averages.
+			r, g, b := 0, 0, 0
+			for j := 0; j < 2; j++ {
+				for i := 0; i < 2; i++ {
+					pr, pg, pb := pixel(2*x+i, 2*y+j)
+					r, g, b = r+pr, g+pg, b+pb
+				}
+			}
+			yuv.u[y*yuv.uvStride+x] = uint8(min(max((-9719*r-19081*g+28800*b+128<<18+1<<17)>>18, 0), 255))
+			yuv.v[y*yuv.uvStride+x] = uint8(min(max((28800*r-24116*g-4684*b+128<<18+1<<17)>>18, 0), 255))
+		}
+	}
+	return yuv
+}
+
+// Returns the alpha values in raster order, and whether they are all opaque.
+func alphaPlane(rgba *image.NRGBA) ([]byte, bool) {
+	width, height := rgba.Bounds().Dx(), rgba.Bounds().Dy()
+	alpha := make([]byte, 0, width*height)
+	isOpaque := true
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			a := rgba.Pix[rgba.PixOffset(x, y)+3]
+			alpha = append(alpha, a)
+			isOpaque = isOpaque && a == 255
+		}
+	}
+	return alpha, isOpaque
+}
+
+// Appends a RIFF chunk, padded to an even size.
+func appendChunk(chunks []byte, fourCC string, payload []byte) []byte {
+	chunks = append(chunks, fourCC...)
+	chunks = binary.LittleEndian.AppendUint32(chunks, uint32(len(payload)))
+	chunks = append(chunks, payload...)
+	if len(payload)%2 == 1 {
+		chunks = append(chunks, 0)
+	}
+	return chunks
+}
+
+func putUint24(b []byte, value int) {
+	b[0], b[1], b[2] = byte(value), byte(value>>8), byte(value>>16)
+}
@@ -0,0 +1,168 @@
+package webp
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"golang.org/x/image/webp"
+)
+
+// A gradient with a sharp-edged box and some fine stripes, like a translated image with text over a picture.
+func testImage(width int, height int, alpha func(x int, y int) uint8) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.NRGBA{R: uint8(255 * x / width), G: uint8(255 * y / height), B: uint8(128 + 64*math.Sin(float64(x+y)/9)), A: alpha(x, y)}
+			if x > width/4 && x < width/2 && y > height/4 && y < height/2 {
+				c.R, c.G, c.B = 250, 250, 240
+			}
+			if y > 3*height/4 && x%4 < 2 {
+				c.R, c.G, c.B = 20, 20, 60
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func opaque(x int, y int) uint8 {
+	return 255
+}
+
+// Decodes the WEBP with the decoder of x/image. Its YCbCr images are in the limited range of VP8,
+// which the color package of Go takes as the full range, so they are converted here.
+func decode(t *testing.T, encoded []byte) (image.Image, func(x int, y int) (float64, float64, float64)) {
+	t.Helper()
+	img, err := webp.Decode(bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	var yCbCr *image.YCbCr
+	switch decoded := img.(type) {
+	case *image.YCbCr:
+		yCbCr = decoded
+	case *image.NYCbCrA:
+		yCbCr = &decoded.YCbCr
+	default:
+		t.Fatalf("got the decoded image of %T, want YCbCr", img)
+	}
+	return img, func(x int, y int) (float64, float64, float64) {
+		luma := 1.164 * (float64(yCbCr.Y[yCbCr.YOffset(x, y)]) - 16)
+		cb, cr := float64(yCbCr.Cb[yCbCr.COffset(x, y)])-128, float64(yCbCr.Cr[yCbCr.COffset(x, y)])-128
+		return luma + 1.596*cr, luma - 0.392*cb - 0.813*cr, luma + 2.017*cb
+	}
+}
+
+// Returns the peak signal-to-noise ratio of the decoded colors against the original in dB.
+func psnr(original *image.NRGBA, decoded func(x int, y int) (float64, float64, float64)) float64 {
+	sum := 0.0
+	bounds := original.Bounds()
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			c := original.NRGBAAt(x, y)
+			r, g, b := decoded(x, y)
+			for _, diff := range []float64{r - float64(c.R), g - float64(c.G), b - float64(c.B)} {
+				diff = min(max(diff, -255), 255)
+				sum += diff * diff
+			}
+		}
+	}
+	return 10 * math.Log10(255*255/(sum/float64(3*bounds.Dx()*bounds.Dy())))
+}
+
+func TestEncodeLossy(t *testing.T) {
+	tests := []struct {
+		name    string
+		width   int
+		height  int
+		quality int
+		minPSNR float64
+	}{
+		{name: "high quality", width: 96, height: 64, quality: 95, minPSNR: 32},
+		{name: "default quality", width: 96, height: 64, quality: 90, minPSNR: 30},
+		{name: "low quality", width: 96, height: 64, quality: 20, minPSNR: 22},
+		{name: "lowest quality", width: 96, height: 64, quality: 1, minPSNR: 15},
+		{name: "partial macroblocks", width: 37, height: 21, quality: 80, minPSNR: 28},
+		{name: "single pixel", width: 1, height: 1, quality: 80, minPSNR: 28},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			original := testImage(test.width, test.height, opaque)
+			buffer := new(bytes.Buffer)
+			if err := EncodeLossy(buffer, original, test.quality); err != nil {
+				t.Fatalf("failed to encode: %v", err)
+			}
+			img, decoded := decode(t, buffer.Bytes())
+			if img.Bounds() != original.Bounds() {
+				t.Fatalf("got the bounds %v, want %v", img.Bounds(), original.Bounds())
+			}
+			if _, isOpaque := img.(*image.YCbCr); !isOpaque {
+				t.Errorf("got %T, want an image without alpha", img)
+			}
+			if got := psnr(original, decoded); got < test.minPSNR {
+				t.Errorf("got PSNR %.1f dB, want at least %.1f dB", got, test.minPSNR)
+			}
+		})
+	}
+}
+
+func TestEncodeLossySizeDecreasesWithQuality(t *testing.T) {
+	original := testImage(128, 128, opaque)
+	previousSize := math.MaxInt
+	for _, quality := range []int{100, 90, 75, 50, 25, 1} {
+		buffer := new(bytes.Buffer)
+		if err := EncodeLossy(buffer, original, quality); err != nil {
+			t.Fatalf("failed to encode: %v", err)
+		}
+		if buffer.Len() >= previousSize {
+			t.Errorf("got %d bytes at the quality %d, want fewer than the %d bytes of the higher quality", buffer.Len(), quality, previousSize)
+		}
+		previousSize = buffer.Len()
+	}
+}
+
+func TestEncodeLossyKeepsAlpha(t *testing.T) {
+	original := testImage(40, 30, func(x int, y int) uint8 {
+		return uint8(x * 6)
+	})
+	buffer := new(bytes.Buffer)
+	if err := EncodeLossy(buffer, original, 80); err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	img, _ := decode(t, buffer.Bytes())
+	nYCbCrA, ok := img.(*image.NYCbCrA)
+	if !ok {
+		t.Fatalf("got %T, want an image with alpha", img)
+	}
+	for y := 0; y < 30; y++ {
+		for x := 0; x < 40; x++ {
+			if got, want := nYCbCrA.A[nYCbCrA.AOffset(x, y)], original.NRGBAAt(x, y).A; got != want {
+				t.Fatalf("got the alpha %d at (%d, %d), want %d", got, x, y, want)
+			}
+		}
+	}
+}
+
+func TestQuantizerIndex(t *testing.T) {
+	tests := []struct {
+		quality int
+		want    int
+	}{
+		{quality: 100, want: 0},
+		{quality: 75, want: 26},
+		{quality: 1, want: 103},
+		// Out of the range, which is clamped like that of JPEG.
+		{quality: 0, want: 103},
+		{quality: 200, want: 0},
+	}
+
+	for _, test := range tests {
+		if got := quantizerIndex(test.quality); got != test.want {
+			t.Errorf("got %d for the quality %d, want %d", got, test.quality, test.want)
+		}
+	}
+}
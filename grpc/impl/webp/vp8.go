@@ -0,0 +1,640 @@
+package webp
+
+import (
+	"errors"
+	"math"
+)
+
+// A VP8 key frame encoder, as specified in RFC 6386. Every macroblock is predicted as a whole, by the 16x16 luma
+// and 8x8 chroma modes, which keeps the encoder simple while flat backgrounds and gradients still cost few bits.
+// Ref: https://datatracker.ietf.org/doc/html/rfc6386
+
+const (
+	// The probability of a bit that is as likely 0 as 1.
+	UNIFORM_PROB = 128
+	// VP8 frames store their width and height in 14 bits.
+	MAX_VP8_SIZE = 1<<14 - 1
+	// The frame tag stores the size of the first partition in 19 bits.
+	MAX_FIRST_PARTITION_SIZE = 1<<19 - 1
+	// The largest level the tokens can express is 2114, and levels above this do not occur for 8-bit residuals.
+	MAX_LEVEL = 2047
+	// Quantized levels are rounded to the nearest for DC coefficients, while AC coefficients are rounded up only
+	// from the remainder of 1 - AC_ROUNDING / 256 of the step, as their small levels cost more bits than they add detail.
+	DC_ROUNDING = 128
+	AC_ROUNDING = 104
+	// The loop filter level is this fraction of the AC quantizer step, so that the edges of coarsely quantized blocks
+	// are smoothed more. At most 63.
+	FILTER_LEVEL_RATIO = 0.4
+)
+
+// The intra prediction modes of 16x16 luma and 8x8 chroma blocks.
+const (
+	PRED_DC = iota
+	PRED_TM
+	PRED_VE
+	PRED_HE
+	PRED_COUNT
+)
+
+// The inverse DCT constants of the decoders, 65536 * cos(pi/8) * sqrt(2) and 65536 * sin(pi/8) * sqrt(2).
+const (
+	IDCT_C1 = 85627
+	IDCT_C2 = 35468
+)
+
+// A YUV 4:2:0 image, with the planes padded to whole macroblocks.
+type yuvImage struct {
+	y, u, v  []uint8
+	yStride  int
+	uvStride int
+}
+
+func newYUVImage(mbw int, mbh int) *yuvImage {
+	return &yuvImage{
+		y:        make([]uint8, 16*mbw*16*mbh),
+		u:        make([]uint8, 8*mbw*8*mbh),
+		v:        make([]uint8, 8*mbw*8*mbh),
+		yStride:  16 * mbw,
+		uvStride: 8 * mbw,
+	}
+}
+
+// The quantizer steps of the DC and AC coefficients of each kind of block, as specified in section 14.1.
+type quantizer struct {
+	y1 [2]int32
+	y2 [2]int32
+	uv [2]int32
+}
+
+func newQuantizer(index int) quantizer {
+	return quantizer{
+		y1: [2]int32{DC_QUANT_STEPS[index], AC_QUANT_STEPS[index]},
+		y2: [2]int32{DC_QUANT_STEPS[index] * 2, max(AC_QUANT_STEPS[index]*155/100, 8)},
+		// The decoders clamp the chroma DC step at 132.
+		uv: [2]int32{DC_QUANT_STEPS[min(index, 117)], AC_QUANT_STEPS[index]},
+	}
+}
+
+// Whether the 4x4 blocks along an edge of a macroblock have non-zero coefficients,
+// which selects the token probabilities of the blocks next to them: 4 luma, 2 U, 2 V and the Y2 block.
+type nonZeroEdge [9]uint8
+
+// The modes of a macroblock and whether it has no coefficients, which are written in the first partition.
+type macroblockHeader struct {
+	lumaMode   int
+	chromaMode int
+	skip       bool
+}
+
+type frameEncoder struct {
+	source        *yuvImage
+	reconstructed *yuvImage
+	mbw           int
+	mbh           int
+	quantIndex    int
+	quant         quantizer
+	tokens        *boolEncoder
+	// The bottom edges of the macroblocks above, and the right edge of the macroblock to the left.
+	topNonZero  []nonZeroEdge
+	leftNonZero nonZeroEdge
+}
+
+// Encodes the image as a VP8 key frame with the quantizer index between 0 and 127.
+// The image is predicted from the reconstructed pixels the decoders see, so that the errors do not add up.
+func encodeFrame(source *yuvImage, width int, height int, quantIndex int) ([]byte, error) {
+	if width <= 0 || height <= 0 || width > MAX_VP8_SIZE || height > MAX_VP8_SIZE {
+		return nil, errors.New("the image size is out of the range of VP8")
+	}
+	mbw, mbh := (width+15)/16, (height+15)/16
+	e := &frameEncoder{
+		source:        source,
+		reconstructed: newYUVImage(mbw, mbh),
+		mbw:           mbw,
+		mbh:           mbh,
+		quantIndex:    quantIndex,
+		quant:         newQuantizer(quantIndex),
+		tokens:        newBoolEncoder(),
+		topNonZero:    make([]nonZeroEdge, mbw),
+	}
+	headers := make([]macroblockHeader, 0, mbw*mbh)
+	for mby := 0; mby < mbh; mby++ {
+		e.leftNonZero = nonZeroEdge{}
+		for mbx := 0; mbx < mbw; mbx++ {
+			headers = append(headers, e.encodeMacroblock(mbx, mby))
+		}
+	}
+	tokens := e.tokens.finish()
+	firstPartition := e.firstPartition(headers)
+	if len(firstPartition) > MAX_FIRST_PARTITION_SIZE {
+		return nil, errors.New("the image has too many macroblocks for VP8")
+	}
+
+	frame := make([]byte, 0, 10+len(firstPartition)+len(tokens))
+	// A shown key frame of version 0.
+	tag := len(firstPartition)<<5 | 1<<4
+	frame = append(frame, byte(tag), byte(tag>>8), byte(tag>>16), 0x9d, 0x01, 0x2a)
+	frame = append(frame, byte(width), byte(width>>8), byte(height), byte(height>>8))
+	frame = append(frame, firstPartition...)
+	return append(frame, tokens...), nil
+}
+
+// Writes the frame header and the modes of the macroblocks, as specified in sections 9 and 19.2.
+func (e *frameEncoder) firstPartition(headers []macroblockHeader) []byte {
+	p := newBoolEncoder()
+	// The color space and the clamping type, then no segmentation.
+	p.putLiteral(0, 1)
+	p.putLiteral(0, 1)
+	p.putLiteral(0, 1)
+	// The normal loop filter, its level and sharpness, and no level adjustments.
+	p.putLiteral(0, 1)
+	p.putLiteral(min(int(FILTER_LEVEL_RATIO*float64(e.quant.y1[1])), 63), 6)
+	p.putLiteral(0, 3)
+	p.putLiteral(0, 1)
+	// A single partition for the tokens.
+	p.putLiteral(0, 2)
+	// The quantizer index, without deltas for any kind of coefficient.
+	p.putLiteral(e.quantIndex, 7)
+	for i := 0; i < 5; i++ {
+		p.putLiteral(0, 1)
+	}
+	// Whether the probabilities are kept for later frames, and no updates to the token probabilities.
+	p.putLiteral(0, 1)
+	for _, bands := range tokenProbUpdateProbs {
+		for _, contexts := range bands {
+			for _, probs := range contexts {
+				for _, prob := range probs {
+					p.putBit(false, prob)
+				}
+			}
+		}
+	}
+
+	skipped := 0
+	for _, header := range headers {
+		if header.skip {
+			skipped++
+		}
+	}
+	skipProb := uint8(0)
+	p.putBit(skipped > 0, UNIFORM_PROB)
+	if skipped > 0 {
+		skipProb = uint8(min(max(255*(len(headers)-skipped)/len(headers), 1), 255))
+		p.putLiteral(int(skipProb), 8)
+	}
+
+	for _, header := range headers {
+		if skipped > 0 {
+			p.putBit(header.skip, skipProb)
+		}
+		// The luma mode tree of key frames, whose first branch is 4x4 prediction.
+		p.putBit(true, 145)
+		switch header.lumaMode {
+		case PRED_DC, PRED_VE:
+			p.putBit(false, 156)
+			p.putBit(header.lumaMode == PRED_VE, 163)
+		case PRED_HE, PRED_TM:
+			p.putBit(true, 156)
+			p.putBit(header.lumaMode == PRED_TM, 128)
+		}
+		// The chroma mode tree of key frames.
+		p.putBit(header.chromaMode != PRED_DC, 142)
+		if header.chromaMode != PRED_DC {
+			p.putBit(header.chromaMode != PRED_VE, 114)
+			if header.chromaMode != PRED_VE {
+				p.putBit(header.chromaMode == PRED_TM, 183)
+			}
+		}
+	}
+	return p.finish()
+}
+
+// Predicts, transforms and quantizes the macroblock, writes its tokens, and reconstructs it as the decoders do.
+func (e *frameEncoder) encodeMacroblock(mbx int, mby int) macroblockHeader {
+	// The levels of the 16 luma blocks, the 4 U blocks, the 4 V blocks and the Y2 block, in raster order.
+	var levels [25][16]int32
+	header := macroblockHeader{}
+
+	lumaEdges := edgesOf(e.reconstructed.y, e.reconstructed.yStride, 16*mbx, 16*mby, 16)
+	var lumaPrediction [256]int32
+	header.lumaMode = bestMode([]blockEdges{lumaEdges}, [][]uint8{e.source.y}, e.source.yStride, 16*mbx, 16*mby)
+	lumaEdges.predict(header.lumaMode, lumaPrediction[:])
+	var dcs [16]int32
+	for block := 0; block < 16; block++ {
+		x, y := 16*mbx+block%4*4, 16*mby+block/4*4
+		coeffs := forwardDCT(residual(e.source.y, e.source.yStride, x, y, lumaPrediction[:], 16, block%4*4, block/4*4))
+		dcs[block] = coeffs[0]
+		for i := 1; i < 16; i++ {
+			levels[block][i] = quantize(coeffs[i], e.quant.y1[1], AC_ROUNDING)
+		}
+	}
+	for i, coeff := range forwardWHT(dcs) {
+		levels[24][i] = quantize(coeff, e.quant.y2[min(i, 1)], DC_ROUNDING)
+	}
+	var y2 [16]int32
+	for i, level := range levels[24] {
+		y2[i] = level * e.quant.y2[min(i, 1)]
+	}
+	dequantizedDCs := inverseWHT(y2)
+	for block := 0; block < 16; block++ {
+		coeffs := dequantize(levels[block], e.quant.y1)
+		coeffs[0] = dequantizedDCs[block]
+		reconstruct(e.reconstructed.y, e.reconstructed.yStride, 16*mbx+block%4*4, 16*mby+block/4*4,
+			lumaPrediction[:], 16, block%4*4, block/4*4, coeffs)
+	}
+
+	chromaPlanes := [][]uint8{e.source.u, e.source.v}
+	reconstructedPlanes := [][]uint8{e.reconstructed.u, e.reconstructed.v}
+	chromaEdges := []blockEdges{
+		edgesOf(e.reconstructed.u, e.reconstructed.uvStride, 8*mbx, 8*mby, 8),
+		edgesOf(e.reconstructed.v, e.reconstructed.uvStride, 8*mbx, 8*mby, 8),
+	}
+	header.chromaMode = bestMode(chromaEdges, chromaPlanes, e.source.uvStride, 8*mbx, 8*mby)
+	for plane := range chromaPlanes {
+		var prediction [64]int32
+		chromaEdges[plane].predict(header.chromaMode, prediction[:])
+		for block := 0; block < 4; block++ {
+			x, y := 8*mbx+block%2*4, 8*mby+block/2*4
+			coeffs := forwardDCT(residual(chromaPlanes[plane], e.source.uvStride, x, y, prediction[:], 8, block%2*4, block/2*4))
+			index := 16 + 4*plane + block
+			levels[index][0] = quantize(coeffs[0], e.quant.uv[0], DC_ROUNDING)
+			for i := 1; i < 16; i++ {
+				levels[index][i] = quantize(coeffs[i], e.quant.uv[1], AC_ROUNDING)
+			}
+			reconstruct(reconstructedPlanes[plane], e.reconstructed.uvStride, x, y,
+				prediction[:], 8, block%2*4, block/2*4, dequantize(levels[index], e.quant.uv))
+		}
+	}
+
+	header.skip = true
+	for _, block := range levels {
+		for _, level := range block {
+			header.skip = header.skip && level == 0
+		}
+	}
+	if header.skip {
+		// The decoders reset the contexts of skipped macroblocks, which are all zero anyway.
+		e.leftNonZero = nonZeroEdge{}
+		e.topNonZero[mbx] = nonZeroEdge{}
+		return header
+	}
+	e.putTokens(mbx, &levels)
+	return header
+}
+
+// Writes the tokens of the blocks in the order of section 13: the Y2 block, the luma blocks, then the U and V blocks.
+func (e *frameEncoder) putTokens(mbx int, levels *[25][16]int32) {
+	top, left := &e.topNonZero[mbx], &e.leftNonZero
+	nonZero := e.putBlock(&levels[24], PLANE_Y2, left[8]+top[8], 0)
+	left[8], top[8] = nonZero, nonZero
+	for y := 0; y < 4; y++ {
+		nonZero := left[y]
+		for x := 0; x < 4; x++ {
+			nonZero = e.putBlock(&levels[y*4+x], PLANE_Y1_WITH_Y2, nonZero+top[x], 1)
+			top[x] = nonZero
+		}
+		left[y] = nonZero
+	}
+	for plane := 0; plane < 2; plane++ {
+		edge := 4 + 2*plane
+		for y := 0; y < 2; y++ {
+			nonZero := left[edge+y]
+			for x := 0; x < 2; x++ {
+				nonZero = e.putBlock(&levels[16+4*plane+y*2+x], PLANE_UV, nonZero+top[edge+x], 0)
+				top[edge+x] = nonZero
+			}
+			left[edge+y] = nonZero
+		}
+	}
+}
+
+// Writes the levels of a block from the first position in the zigzag order, as specified in section 13.2.
+// Returns 1 when the block has a non-zero level, which is the context of the blocks right of and below it.
+func (e *frameEncoder) putBlock(levels *[16]int32, plane int, context uint8, first int) uint8 {
+	probs := &defaultTokenProbs[plane]
+	last := -1
+	for n := first; n < 16; n++ {
+		if levels[ZIGZAG[n]] != 0 {
+			last = n
+		}
+	}
+	p := &probs[BANDS[first]][context]
+	e.tokens.putBit(last >= 0, p[0])
+	if last < 0 {
+		return 0
+	}
+	for n := first; n <= last; {
+		level := levels[ZIGZAG[n]]
+		n++
+		// A zero cannot be followed by the end of the block, so the next token skips that branch.
+		e.tokens.putBit(level != 0, p[1])
+		if level == 0 {
+			p = &probs[BANDS[n]][0]
+			continue
+		}
+		magnitude := max(level, -level)
+		e.tokens.putBit(magnitude > 1, p[2])
+		if magnitude == 1 {
+			p = &probs[BANDS[n]][1]
+		} else {
+			e.putLargeLevel(magnitude, p)
+			p = &probs[BANDS[n]][2]
+		}
+		e.tokens.putBit(level < 0, UNIFORM_PROB)
+		if n == 16 {
+			break
+		}
+		// Whether another token follows rather than the end of the block.
+		e.tokens.putBit(n <= last, p[0])
+	}
+	return 1
+}
+
+// Writes a level of 2 or more, from the branch after the level 1 in the token tree.
+func (e *frameEncoder) putLargeLevel(magnitude int32, p *[TOKEN_PROB_COUNT]uint8) {
+	switch {
+	case magnitude <= 4:
+		e.tokens.putBit(false, p[3])
+		e.tokens.putBit(magnitude > 2, p[4])
+		if magnitude > 2 {
+			e.tokens.putBit(magnitude == 4, p[5])
+		}
+	case magnitude <= 10:
+		e.tokens.putBit(true, p[3])
+		e.tokens.putBit(false, p[6])
+		e.tokens.putBit(magnitude > 6, p[7])
+		if magnitude <= 6 {
+			// The category 1 of the levels 5 and 6.
+			e.tokens.putBit(magnitude == 6, 159)
+		} else {
+			// The category 2 of the levels 7 to 10.
+			e.tokens.putBit((magnitude-7)>>1 != 0, 165)
+			e.tokens.putBit((magnitude-7)&1 != 0, 145)
+		}
+	default:
+		e.tokens.putBit(true, p[3])
+		e.tokens.putBit(true, p[6])
+		category := 3
+		switch {
+		case magnitude < 19:
+			category = 0
+		case magnitude < 35:
+			category = 1
+		case magnitude < 67:
+			category = 2
+		}
+		e.tokens.putBit(category>>1 != 0, p[8])
+		e.tokens.putBit(category&1 != 0, p[9+category>>1])
+		extra := magnitude - (3 + 8<<category)
+		extraProbs := LARGE_CATEGORY_PROBS[category]
+		for i, prob := range extraProbs {
+			e.tokens.putBit(extra>>(len(extraProbs)-1-i)&1 != 0, prob)
+		}
+	}
+}
+
+// The row above and the column left of a block, and the pixel above and left of it, as the decoders see them:
+// 127 above the image, and 129 left of it.
+type blockEdges struct {
+	top     []int32
+	left    []int32
+	topLeft int32
+	hasTop  bool
+	hasLeft bool
+}
+
+func edgesOf(plane []uint8, stride int, x0 int, y0 int, size int) blockEdges {
+	edges := blockEdges{top: make([]int32, size), left: make([]int32, size), hasTop: y0 > 0, hasLeft: x0 > 0}
+	for i := 0; i < size; i++ {
+		edges.top[i], edges.left[i] = 127, 129
+		if edges.hasTop {
+			edges.top[i] = int32(plane[(y0-1)*stride+x0+i])
+		}
+		if edges.hasLeft {
+			edges.left[i] = int32(plane[(y0+i)*stride+x0-1])
+		}
+	}
+	switch {
+	case !edges.hasTop:
+		edges.topLeft = 127
+	case !edges.hasLeft:
+		edges.topLeft = 129
+	default:
+		edges.topLeft = int32(plane[(y0-1)*stride+x0-1])
+	}
+	return edges
+}
+
+// Writes the prediction of the block in the mode to the square of its size, in raster order, as specified in section 12.2.
+func (edges blockEdges) predict(mode int, prediction []int32) {
+	size := len(edges.top)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			switch mode {
+			case PRED_TM:
+				prediction[y*size+x] = min(max(edges.left[y]+edges.top[x]-edges.topLeft, 0), 255)
+			case PRED_VE:
+				prediction[y*size+x] = edges.top[x]
+			case PRED_HE:
+				prediction[y*size+x] = edges.left[y]
+			}
+		}
+	}
+	if mode != PRED_DC {
+		return
+	}
+	// The average of the edges inside the image, or 128 when there are none.
+	sum, count := int32(0), int32(0)
+	if edges.hasTop {
+		for _, value := range edges.top {
+			sum += value
+		}
+		count += int32(size)
+	}
+	if edges.hasLeft {
+		for _, value := range edges.left {
+			sum += value
+		}
+		count += int32(size)
+	}
+	dc := int32(128)
+	if count > 0 {
+		dc = (sum + count/2) / count
+	}
+	for i := range prediction[:size*size] {
+		prediction[i] = dc
+	}
+}
+
+// Returns the mode whose predictions are closest to the blocks of the planes, which share the mode.
+func bestMode(edges []blockEdges, planes [][]uint8, stride int, x0 int, y0 int) int {
+	size := len(edges[0].top)
+	prediction := make([]int32, size*size)
+	best, bestError := PRED_DC, int64(math.MaxInt64)
+	for mode := 0; mode < PRED_COUNT; mode++ {
+		predictionError := int64(0)
+		for i, plane := range planes {
+			edges[i].predict(mode, prediction)
+			for y := 0; y < size; y++ {
+				for x := 0; x < size; x++ {
+					difference := int64(plane[(y0+y)*stride+x0+x]) - int64(prediction[y*size+x])
+					predictionError += difference * difference
+				}
+			}
+		}
+		if predictionError < bestError {
+			best, bestError = mode, predictionError
+		}
+	}
+	return best
+}
+
+// Returns the differences between the 4x4 block of the plane at (x, y) and its prediction at (predictionX, predictionY).
+func residual(plane []uint8, stride int, x int, y int, prediction []int32, predictionStride int, predictionX int, predictionY int) [16]int32 {
+	var block [16]int32
+	for j := 0; j < 4; j++ {
+		for i := 0; i < 4; i++ {
+			block[j*4+i] = int32(plane[(y+j)*stride+x+i]) - prediction[(predictionY+j)*predictionStride+predictionX+i]
+		}
+	}
+	return block
+}
+
+// The basis of the 4-point DCT, scaled so that the inverse DCT of the decoders restores the residuals.
+// The decoders' inverse is half of the orthonormal inverse DCT in two dimensions, so the coefficients are doubled.
+var dctBasis = func() [4][4]float64 {
+	var basis [4][4]float64
+	for k := 0; k < 4; k++ {
+		scale := math.Sqrt(0.5)
+		if k == 0 {
+			scale = 0.5
+		}
+		for n := 0; n < 4; n++ {
+			basis[k][n] = scale * math.Cos(math.Pi*float64((2*n+1)*k)/8)
+		}
+	}
+	return basis
+}()
+
+// Returns the DCT coefficients of the 4x4 residuals, with the vertical frequency in rows.
+func forwardDCT(block [16]int32) [16]int32 {
+	var rows [16]float64
+	for j := 0; j < 4; j++ {
+		for k := 0; k < 4; k++ {
+			for n := 0; n < 4; n++ {
+				rows[j*4+k] += dctBasis[k][n] * float64(block[j*4+n])
+			}
+		}
+	}
+	var coeffs [16]int32
+	for k := 0; k < 4; k++ {
+		for h := 0; h < 4; h++ {
+			sum := 0.0
+			for n := 0; n < 4; n++ {
+				sum += dctBasis[k][n] * rows[n*4+h]
+			}
+			coeffs[k*4+h] = int32(math.Round(2 * sum))
+		}
+	}
+	return coeffs
+}
+
+// The Walsh-Hadamard transform of the decoders, whose rows are also its columns.
+var walshHadamard = [4][4]int32{
+	{1, 1, 1, 1},
+	{1, 1, -1, -1},
+	{1, -1, -1, 1},
+	{1, -1, 1, -1},
+}
+
+// Returns the Y2 coefficients of the DC coefficients of the 16 luma blocks in raster order.
+// The decoders' inverse is an eighth of the transform in two dimensions, whose square is 16, so the coefficients are halved.
+func forwardWHT(dcs [16]int32) [16]int32 {
+	var rows [16]int32
+	for j := 0; j < 4; j++ {
+		for h := 0; h < 4; h++ {
+			for n := 0; n < 4; n++ {
+				rows[j*4+h] += walshHadamard[h][n] * dcs[j*4+n]
+			}
+		}
+	}
+	var coeffs [16]int32
+	for v := 0; v < 4; v++ {
+		for h := 0; h < 4; h++ {
+			sum := int32(0)
+			for n := 0; n < 4; n++ {
+				sum += walshHadamard[v][n] * rows[n*4+h]
+			}
+			coeffs[v*4+h] = int32(math.Round(float64(sum) / 2))
+		}
+	}
+	return coeffs
+}
+
+// Returns the DC coefficients of the 16 luma blocks from the Y2 coefficients, as the decoders do in section 14.3.
+func inverseWHT(coeffs [16]int32) [16]int32 {
+	var m [16]int32
+	for i := 0; i < 4; i++ {
+		a0 := coeffs[0+i] + coeffs[12+i]
+		a1 := coeffs[4+i] + coeffs[8+i]
+		a2 := coeffs[4+i] - coeffs[8+i]
+		a3 := coeffs[0+i] - coeffs[12+i]
+		m[0+i] = a0 + a1
+		m[8+i] = a0 - a1
+		m[4+i] = a3 + a2
+		m[12+i] = a3 - a2
+	}
+	var dcs [16]int32
+	for i := 0; i < 4; i++ {
+		dc := m[0+i*4] + 3
+		a0 := dc + m[3+i*4]
+		a1 := m[1+i*4] + m[2+i*4]
+		a2 := m[1+i*4] - m[2+i*4]
+		a3 := dc - m[3+i*4]
+		dcs[i*4+0] = (a0 + a1) >> 3
+		dcs[i*4+1] = (a3 + a2) >> 3
+		dcs[i*4+2] = (a0 - a1) >> 3
+		dcs[i*4+3] = (a3 - a2) >> 3
+	}
+	return dcs
+}
+
+// Adds the inverse DCT of the coefficients to the 4x4 prediction and writes the result to the plane,
+// as the decoders do in section 14.4.
+func reconstruct(plane []uint8, stride int, x int, y int, prediction []int32, predictionStride int, predictionX int, predictionY int, coeffs [16]int32) {
+	var m [4][4]int32
+	for i := 0; i < 4; i++ {
+		a := coeffs[i] + coeffs[8+i]
+		b := coeffs[i] - coeffs[8+i]
+		c := (coeffs[4+i]*IDCT_C2)>>16 - (coeffs[12+i]*IDCT_C1)>>16
+		d := (coeffs[4+i]*IDCT_C1)>>16 + (coeffs[12+i]*IDCT_C2)>>16
+		m[i] = [4]int32{a + d, b + c, b - c, a - d}
+	}
+	for j := 0; j < 4; j++ {
+		dc := m[0][j] + 4
+		a := dc + m[2][j]
+		b := dc - m[2][j]
+		c := (m[1][j]*IDCT_C2)>>16 - (m[3][j]*IDCT_C1)>>16
+		d := (m[1][j]*IDCT_C1)>>16 + (m[3][j]*IDCT_C2)>>16
+		residuals := [4]int32{(a + d) >> 3, (b + c) >> 3, (b - c) >> 3, (a - d) >> 3}
+		for i, residual := range residuals {
+			predicted := prediction[(predictionY+j)*predictionStride+predictionX+i]
+			plane[(y+j)*stride+x+i] = uint8(min(max(predicted+residual, 0), 255))
+		}
+	}
+}
+
+func quantize(coeff int32, step int32, rounding int32) int32 {
+	level := min((max(coeff, -coeff)+step*rounding>>8)/step, MAX_LEVEL)
+	if coeff < 0 {
+		return -level
+	}
+	return level
+}
+
+func dequantize(levels [16]int32, steps [2]int32) [16]int32 {
+	var coeffs [16]int32
+	for i, level := range levels {
+		coeffs[i] = level * steps[min(i, 1)]
+	}
+	return coeffs
+}
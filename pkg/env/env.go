@@ -42,3 +42,16 @@ func StringVariable(name, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// IntVariable returns the value of an environment variable as int or a default value, or panics if not an integer
+func IntVariable(name string, defaultValue int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		panic(fmt.Sprintf("environment variable %s must be an integer, got: %s", name, value))
+	}
+	return intValue
+}